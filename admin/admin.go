@@ -0,0 +1,137 @@
+// Package admin serves the endpoints used to correct published rates by hand, when the upstream
+// publishes a bad rate. Every correction is made together with an entry in an audit log that
+// records who made it, why, and the values before and after.
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+const (
+	// ActionPutRates replaces or creates the rates of a base on a date.
+	ActionPutRates = "rates.put"
+	// ActionDeleteRates removes the rates of a base on a date.
+	ActionDeleteRates = "rates.delete"
+	// ActionRegenerateSymbols rebuilds the symbols document from the latest rates.
+	ActionRegenerateSymbols = "symbols.regenerate"
+
+	ratesCollection   = "exchange_rates"
+	symbolsCollection = "symbols"
+)
+
+var (
+	ErrNotFound      = errors.New("rates not found")
+	ErrNoRates       = errors.New("there are no rates to generate the symbols from")
+	ErrMissingReason = errors.New("reason is required")
+	ErrInvalidRates  = errors.New("rates need at least one supported symbol other than the base, each with a positive rate")
+)
+
+// AuditEntry records a correction.
+type AuditEntry struct {
+	ID     string `json:"id" firestore:"-"`
+	Action string `json:"action" firestore:"action" enums:"rates.put,rates.delete,symbols.regenerate"`
+	// Document is the path of the corrected Firestore document.
+	Document string `json:"document" firestore:"document" example:"exchange_rates/EUR-2025-01-03"`
+	// Actor is the name of the admin whose token authorized the correction.
+	Actor  string `json:"actor" firestore:"actor" example:"alice"`
+	Reason string `json:"reason" firestore:"reason" example:"upstream published USD off by a factor 10"`
+	// OldValue is the document before the correction, left out when it didn't exist.
+	OldValue map[string]any `json:"old_value,omitempty" firestore:"old_value"`
+	// NewValue is the document after the correction, left out when it was deleted.
+	NewValue  map[string]any `json:"new_value,omitempty" firestore:"new_value"`
+	ChangedAt time.Time      `json:"changed_at" firestore:"changed_at"`
+}
+
+// ratesDocument is the id of the document with the rates of base on date.
+func ratesDocument(base string, date string) string {
+	return base + "-" + date
+}
+
+// rateFields returns the fields of the document of a rates record.
+func rateFields(record handlers.ExchangeRateRecord) map[string]any {
+	rates := make(map[string]any, len(record.Rates))
+	for symbol, rate := range record.Rates {
+		rates[symbol] = rate
+	}
+	return map[string]any{"base": record.Base, "date": record.Date, "rates": rates}
+}
+
+// symbolFields returns the fields of the document of a symbols record.
+func symbolFields(record handlers.SymbolsRecord) map[string]any {
+	return map[string]any{"date": record.Date, "symbols": record.Symbols}
+}
+
+// generateSymbols returns the symbols record of the latest date in records: every base and
+// symbol with rates on that date, sorted.
+func generateSymbols(records []handlers.ExchangeRateRecord) (handlers.SymbolsRecord, error) {
+	var latest string
+	for _, record := range records {
+		latest = max(latest, record.Date)
+	}
+	if latest == "" {
+		return handlers.SymbolsRecord{}, ErrNoRates
+	}
+
+	symbols := map[string]bool{}
+	for _, record := range records {
+		if record.Date != latest {
+			continue
+		}
+		symbols[record.Base] = true
+		for symbol := range record.Rates {
+			symbols[symbol] = true
+		}
+	}
+	return handlers.SymbolsRecord{Date: latest, Symbols: slices.Sorted(maps.Keys(symbols))}, nil
+}
+
+// normalizeKey validates the base and date identifying a rates document. Unlike the public
+// endpoints, which fall back to defaults, it rejects anything it doesn't recognize.
+func normalizeKey(base string, date string) (string, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	if !handlers.Registry.Contains(base) {
+		return "", fmt.Errorf("%w %q", handlers.ErrUnknownCurrency, base)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return "", fmt.Errorf("%w: %q", handlers.ErrInvalidDate, date)
+	}
+	return base, nil
+}
+
+// normalizeRates validates the rates of base on date sent by an admin.
+func normalizeRates(base string, date string, rates map[string]float64) (handlers.ExchangeRateRecord, error) {
+	base, err := normalizeKey(base, date)
+	if err != nil {
+		return handlers.ExchangeRateRecord{}, err
+	}
+
+	if len(rates) == 0 {
+		return handlers.ExchangeRateRecord{}, ErrInvalidRates
+	}
+	normalized := make(map[string]float64, len(rates))
+	for symbol, rate := range rates {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if !handlers.Registry.Contains(symbol) || symbol == base || rate <= 0 || math.IsInf(rate, 0) {
+			return handlers.ExchangeRateRecord{}, ErrInvalidRates
+		}
+		normalized[symbol] = rate
+	}
+	return handlers.ExchangeRateRecord{Base: base, Date: date, Rates: normalized}, nil
+}
+
+// normalizeReason trims the reason of a correction, which every correction needs.
+func normalizeReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", ErrMissingReason
+	}
+	return reason, nil
+}
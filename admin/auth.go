@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Authenticator identifies admins by the bearer token in the Authorization header.
+type Authenticator struct {
+	admins []admin
+}
+
+type admin struct {
+	name string
+	// digest is the SHA-256 of the token, so comparisons take the same time whatever its length.
+	digest [sha256.Size]byte
+}
+
+// NewAuthenticator accepts the tokens of credentials, which are name:token pairs as validated by
+// config.Config.Validate.
+func NewAuthenticator(credentials []string) *Authenticator {
+	authenticator := &Authenticator{}
+	for _, credential := range credentials {
+		name, token, _ := strings.Cut(credential, ":")
+		authenticator.admins = append(authenticator.admins, admin{name: name, digest: sha256.Sum256([]byte(token))})
+	}
+	return authenticator
+}
+
+// Authenticate returns the name of the admin whose token request carries, or false when it
+// carries none or an unknown one.
+func (a *Authenticator) Authenticate(request *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(request.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	digest := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	var name string
+	// Compare against every token, so the time taken doesn't reveal which one was close.
+	for _, admin := range a.admins {
+		if subtle.ConstantTimeCompare(digest[:], admin.digest[:]) == 1 {
+			name = admin.name
+		}
+	}
+	return name, name != ""
+}
@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	authenticator := NewAuthenticator([]string{"alice:" + testToken, "bob:bob-token-0123456789"})
+
+	tests := []struct {
+		header   string
+		wantName string
+		wantOK   bool
+	}{
+		{header: "Bearer " + testToken, wantName: "alice", wantOK: true},
+		{header: "bearer bob-token-0123456789", wantName: "bob", wantOK: true},
+		{header: "Bearer " + testToken + "x"},
+		{header: "Basic " + testToken},
+		{header: testToken},
+		{header: "Bearer "},
+		{header: ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, handlers.AdminAuditPath, nil)
+		req.Header.Set("Authorization", tt.header)
+
+		name, ok := authenticator.Authenticate(req)
+		if name != tt.wantName || ok != tt.wantOK {
+			t.Errorf("Authenticate() with %q = %q, %v, want %q, %v", tt.header, name, ok, tt.wantName, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kamaal111/forex-api/docs"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// TestSpecConformance runs every documented admin operation and fails when a response doesn't
+// match the spec.
+func TestSpecConformance(t *testing.T) {
+	validator, err := docs.NewValidator()
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	store := NewMemoryStore(handlers.ExchangeRateRecord{Base: "EUR", Date: "2025-01-03", Rates: map[string]float64{"USD": 10.3}})
+	mux := newTestMux(newTestAPI(store))
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		token  string
+	}{
+		{name: "put rates", method: http.MethodPut, target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":1.03},"reason":"fix"}`},
+		{name: "put invalid rates", method: http.MethodPut, target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":0},"reason":"fix"}`},
+		{name: "put without a token", method: http.MethodPut, target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":1.03},"reason":"fix"}`, token: "none"},
+		{name: "regenerate symbols", method: http.MethodPost, target: handlers.AdminSymbolsPath, body: `{"reason":"fix"}`},
+		{name: "list the audit log", method: http.MethodGet, target: handlers.AdminAuditPath},
+		{name: "list the audit log with an invalid limit", method: http.MethodGet, target: handlers.AdminAuditPath + "?limit=0"},
+		{name: "delete rates", method: http.MethodDelete, target: "/v1/admin/rates/EUR/2025-01-03", body: `{"reason":"fix"}`},
+		{name: "delete missing rates", method: http.MethodDelete, target: "/v1/admin/rates/EUR/2025-01-03", body: `{"reason":"fix"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.token == "" {
+				req.Header.Set("Authorization", "Bearer "+testToken)
+			}
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, req)
+
+			if err := validator.ValidateResponse(req, recorder.Code, recorder.Header(), recorder.Body.Bytes()); err != nil {
+				t.Errorf("ValidateResponse() for status %d error = %v", recorder.Code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamaal111/forex-api/utils"
+)
+
+const (
+	maxCorrectionBytes = 64 << 10
+
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// RatesCorrection is the body of a request replacing the rates of a base on a date.
+type RatesCorrection struct {
+	Rates  map[string]float64 `json:"rates"`
+	Reason string             `json:"reason" example:"upstream published USD off by a factor 10"`
+}
+
+// Reason is the body of a correction that only needs a reason.
+type Reason struct {
+	Reason string `json:"reason" example:"symbols missed GBP after a partial publication"`
+}
+
+type AuditRecord struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+// API serves the admin endpoints to the admins known to Authenticator.
+type API struct {
+	Store         Store
+	Authenticator *Authenticator
+	Now           func() time.Time
+}
+
+// NewAPI returns an API making corrections in store for the admins of credentials, which are
+// name:token pairs.
+func NewAPI(store Store, credentials []string) *API {
+	return &API{Store: store, Authenticator: NewAuthenticator(credentials), Now: time.Now}
+}
+
+// HandleRates routes requests to the rates of a base on a date by method.
+func (a *API) HandleRates(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodPut:
+		a.PutRates(writer, request)
+	case http.MethodDelete:
+		a.DeleteRates(writer, request)
+	default:
+		writer.Header().Set("Allow", strings.Join([]string{http.MethodPut, http.MethodDelete}, ", "))
+		utils.ErrorHandler(writer, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// PutRates handles requests to create or replace the rates of a base on a date.
+//
+// @Summary      Correct the rates of a date
+// @Description  Creates or replaces the rates of a base on a date, and records the change with the previous rates in the audit log.
+// @Description  Every symbol must be a supported currency other than the base, with a positive rate.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminToken
+// @Param        base        path      string           true  "Base currency code"  minlength(3)  maxlength(3)
+// @Param        date        path      string           true  "Publication date (YYYY-MM-DD)"  Format(date)
+// @Param        correction  body      RatesCorrection  true  "Corrected rates and the reason for the correction"
+// @Success      200         {object}  AuditEntry
+// @Failure      400         {object}  api.Error
+// @Failure      401         {object}  api.Error
+// @Failure      500         {object}  api.Error
+// @Router       /v1/admin/rates/{base}/{date} [put]
+func (a *API) PutRates(writer http.ResponseWriter, request *http.Request) {
+	actor, ok := a.authenticate(writer, request)
+	if !ok {
+		return
+	}
+
+	var correction RatesCorrection
+	if !decodeBody(writer, request, &correction, "body must be a JSON rates correction") {
+		return
+	}
+	record, err := normalizeRates(request.PathValue("base"), request.PathValue("date"), correction.Rates)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entry, ok := a.newEntry(writer, ActionPutRates, actor, correction.Reason)
+	if !ok {
+		return
+	}
+
+	recorded, err := a.Store.PutRates(request.Context(), record, entry)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJSON(writer, http.StatusOK, recorded)
+}
+
+// DeleteRates handles requests to remove the rates of a base on a date.
+//
+// @Summary      Delete the rates of a date
+// @Description  Removes the rates of a base on a date, and records them in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminToken
+// @Param        base    path      string  true  "Base currency code"  minlength(3)  maxlength(3)
+// @Param        date    path      string  true  "Publication date (YYYY-MM-DD)"  Format(date)
+// @Param        reason  body      Reason  true  "Reason for the deletion"
+// @Success      200     {object}  AuditEntry
+// @Failure      400     {object}  api.Error
+// @Failure      401     {object}  api.Error
+// @Failure      404     {object}  api.Error
+// @Failure      500     {object}  api.Error
+// @Router       /v1/admin/rates/{base}/{date} [delete]
+func (a *API) DeleteRates(writer http.ResponseWriter, request *http.Request) {
+	actor, ok := a.authenticate(writer, request)
+	if !ok {
+		return
+	}
+
+	var reason Reason
+	if !decodeBody(writer, request, &reason, "body must be a JSON object with a reason") {
+		return
+	}
+	date := request.PathValue("date")
+	base, err := normalizeKey(request.PathValue("base"), date)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entry, ok := a.newEntry(writer, ActionDeleteRates, actor, reason.Reason)
+	if !ok {
+		return
+	}
+
+	recorded, err := a.Store.DeleteRates(request.Context(), base, date, entry)
+	if errors.Is(err, ErrNotFound) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJSON(writer, http.StatusOK, recorded)
+}
+
+// RegenerateSymbols handles requests to rebuild the symbols document.
+//
+// @Summary      Regenerate the symbols
+// @Description  Rebuilds the symbols document of the latest date with rates from every base and symbol with rates on that date, and records the change in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     AdminToken
+// @Param        reason  body      Reason  true  "Reason for the regeneration"
+// @Success      200     {object}  AuditEntry
+// @Failure      400     {object}  api.Error
+// @Failure      401     {object}  api.Error
+// @Failure      404     {object}  api.Error
+// @Failure      405     {object}  api.Error
+// @Failure      500     {object}  api.Error
+// @Router       /v1/admin/symbols/regenerate [post]
+func (a *API) RegenerateSymbols(writer http.ResponseWriter, request *http.Request) {
+	actor, ok := a.authenticate(writer, request)
+	if !ok {
+		return
+	}
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		utils.ErrorHandler(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reason Reason
+	if !decodeBody(writer, request, &reason, "body must be a JSON object with a reason") {
+		return
+	}
+	entry, ok := a.newEntry(writer, ActionRegenerateSymbols, actor, reason.Reason)
+	if !ok {
+		return
+	}
+
+	recorded, err := a.Store.RegenerateSymbols(request.Context(), entry)
+	if errors.Is(err, ErrNoRates) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJSON(writer, http.StatusOK, recorded)
+}
+
+// ListAudit handles requests for the audit log.
+//
+// @Summary      List the audit log
+// @Description  Lists the latest corrections, newest first.
+// @Tags         admin
+// @Produce      json
+// @Security     AdminToken
+// @Param        limit  query     integer  false  "Number of entries to return (default: 50)"  minimum(1)  maximum(500)
+// @Success      200    {object}  AuditRecord
+// @Failure      400    {object}  api.Error
+// @Failure      401    {object}  api.Error
+// @Failure      405    {object}  api.Error
+// @Failure      500    {object}  api.Error
+// @Router       /v1/admin/audit [get]
+func (a *API) ListAudit(writer http.ResponseWriter, request *http.Request) {
+	if _, ok := a.authenticate(writer, request); !ok {
+		return
+	}
+	if request.Method != http.MethodGet {
+		writer.Header().Set("Allow", http.MethodGet)
+		utils.ErrorHandler(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := defaultAuditLimit
+	if raw := request.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAuditLimit {
+			utils.ErrorHandler(writer, "limit must be a whole number from 1 to 500", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	entries, err := a.Store.Audit(request.Context(), limit)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJSON(writer, http.StatusOK, AuditRecord{Entries: append([]AuditEntry{}, entries...)})
+}
+
+// authenticate returns the admin making request, or answers 401 and returns false.
+func (a *API) authenticate(writer http.ResponseWriter, request *http.Request) (string, bool) {
+	actor, ok := a.Authenticator.Authenticate(request)
+	if !ok {
+		writer.Header().Set("WWW-Authenticate", `Bearer realm="forex-api admin"`)
+		utils.ErrorHandler(writer, "a valid admin token is required", http.StatusUnauthorized)
+	}
+	return actor, ok
+}
+
+// newEntry starts the audit entry of a correction, or answers 400 and returns false when the
+// reason is missing.
+func (a *API) newEntry(writer http.ResponseWriter, action string, actor string, reason string) (AuditEntry, bool) {
+	reason, err := normalizeReason(reason)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return AuditEntry{}, false
+	}
+	return AuditEntry{Action: action, Actor: actor, Reason: reason, ChangedAt: a.Now().UTC()}, true
+}
+
+func decodeBody(writer http.ResponseWriter, request *http.Request, value any, message string) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxCorrectionBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		utils.ErrorHandler(writer, message, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+const testToken = "alice-token-0123456789"
+
+var testNow = time.Date(2025, time.January, 6, 9, 30, 0, 0, time.UTC)
+
+func newTestAPI(store Store) *API {
+	api := NewAPI(store, []string{"alice:" + testToken, "bob:bob-token-0123456789"})
+	api.Now = func() time.Time { return testNow }
+	return api
+}
+
+func newTestMux(api *API) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc(handlers.AdminRatesPath, api.HandleRates)
+	mux.HandleFunc(handlers.AdminSymbolsPath, api.RegenerateSymbols)
+	mux.HandleFunc(handlers.AdminAuditPath, api.ListAudit)
+	return mux
+}
+
+func serveAdmin(mux *http.ServeMux, method string, target string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func decodeEntry(t *testing.T, recorder *httptest.ResponseRecorder) AuditEntry {
+	t.Helper()
+	var entry AuditEntry
+	if err := json.Unmarshal(recorder.Body.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode response %s: %v", recorder.Body, err)
+	}
+	return entry
+}
+
+func TestPutRatesHandler(t *testing.T) {
+	store := NewMemoryStore(handlers.ExchangeRateRecord{Base: "EUR", Date: "2025-01-03", Rates: map[string]float64{"USD": 10.3}})
+	mux := newTestMux(newTestAPI(store))
+
+	recorder := serveAdmin(mux, http.MethodPut, "/v1/admin/rates/eur/2025-01-03", `{"rates":{"usd":1.03,"GBP":0.83},"reason":"  USD off by a factor 10 "}`)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("PutRates() status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	entry := decodeEntry(t, recorder)
+	want := AuditEntry{
+		ID:        "1",
+		Action:    ActionPutRates,
+		Document:  "exchange_rates/EUR-2025-01-03",
+		Actor:     "alice",
+		Reason:    "USD off by a factor 10",
+		OldValue:  map[string]any{"base": "EUR", "date": "2025-01-03", "rates": map[string]any{"USD": 10.3}},
+		NewValue:  map[string]any{"base": "EUR", "date": "2025-01-03", "rates": map[string]any{"USD": 1.03, "GBP": 0.83}},
+		ChangedAt: testNow,
+	}
+	if !reflect.DeepEqual(entry, want) {
+		t.Errorf("PutRates() = %+v, want %+v", entry, want)
+	}
+
+	stored := store.Rates("EUR", "2025-01-03")
+	if stored == nil || stored.Rates["USD"] != 1.03 || stored.Rates["GBP"] != 0.83 {
+		t.Errorf("PutRates() stored %+v", stored)
+	}
+}
+
+func TestPutRatesHandler_Rejects(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		body           string
+		token          string
+		wantStatusCode int
+	}{
+		{name: "missing token", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":1.03},"reason":"fix"}`, wantStatusCode: http.StatusUnauthorized},
+		{name: "unknown token", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":1.03},"reason":"fix"}`, token: "Bearer mallory-token-0123456789", wantStatusCode: http.StatusUnauthorized},
+		{name: "unknown base", target: "/v1/admin/rates/XYZ/2025-01-03", body: `{"rates":{"USD":1.03},"reason":"fix"}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+		{name: "invalid date", target: "/v1/admin/rates/EUR/03-01-2025", body: `{"rates":{"USD":1.03},"reason":"fix"}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+		{name: "no rates", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{},"reason":"fix"}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+		{name: "rate of the base", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"EUR":1},"reason":"fix"}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+		{name: "negative rate", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":-1},"reason":"fix"}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+		{name: "missing reason", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":1.03},"reason":" "}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+		{name: "unknown field", target: "/v1/admin/rates/EUR/2025-01-03", body: `{"rates":{"USD":1.03},"reason":"fix","base":"USD"}`, token: "Bearer " + testToken, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			mux := newTestMux(newTestAPI(store))
+
+			req := httptest.NewRequest(http.MethodPut, tt.target, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Errorf("PutRates() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode == http.StatusUnauthorized && recorder.Header().Get("WWW-Authenticate") == "" {
+				t.Error("PutRates() without a valid token sent no WWW-Authenticate header")
+			}
+			if store.Rates("EUR", "2025-01-03") != nil {
+				t.Error("PutRates() stored the rejected rates")
+			}
+		})
+	}
+}
+
+func TestDeleteRatesHandler(t *testing.T) {
+	store := NewMemoryStore(handlers.ExchangeRateRecord{Base: "USD", Date: "2025-01-03", Rates: map[string]float64{"EUR": 0.97}})
+	mux := newTestMux(newTestAPI(store))
+
+	recorder := serveAdmin(mux, http.MethodDelete, "/v1/admin/rates/USD/2025-01-03", `{"reason":"published on a holiday"}`)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("DeleteRates() status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	entry := decodeEntry(t, recorder)
+	if entry.Action != ActionDeleteRates || entry.OldValue == nil || entry.NewValue != nil {
+		t.Errorf("DeleteRates() = %+v, want the deleted rates as the old value only", entry)
+	}
+	if store.Rates("USD", "2025-01-03") != nil {
+		t.Error("DeleteRates() kept the rates")
+	}
+
+	recorder = serveAdmin(mux, http.MethodDelete, "/v1/admin/rates/USD/2025-01-03", `{"reason":"published on a holiday"}`)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("DeleteRates() of missing rates status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestRegenerateSymbolsHandler(t *testing.T) {
+	store := NewMemoryStore(
+		handlers.ExchangeRateRecord{Base: "EUR", Date: "2025-01-02", Rates: map[string]float64{"CHF": 0.94}},
+		handlers.ExchangeRateRecord{Base: "EUR", Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03, "GBP": 0.83}},
+		handlers.ExchangeRateRecord{Base: "USD", Date: "2025-01-03", Rates: map[string]float64{"EUR": 0.97, "JPY": 157.2}},
+	)
+	mux := newTestMux(newTestAPI(store))
+
+	recorder := serveAdmin(mux, http.MethodPost, handlers.AdminSymbolsPath, `{"reason":"GBP was missing"}`)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("RegenerateSymbols() status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	entry := decodeEntry(t, recorder)
+	if entry.Action != ActionRegenerateSymbols || entry.Document != "symbols/2025-01-03" {
+		t.Errorf("RegenerateSymbols() = %+v", entry)
+	}
+	want := &handlers.SymbolsRecord{Date: "2025-01-03", Symbols: []string{"EUR", "GBP", "JPY", "USD"}}
+	if got := store.Symbols("2025-01-03"); !reflect.DeepEqual(got, want) {
+		t.Errorf("RegenerateSymbols() stored %+v, want %+v", got, want)
+	}
+
+	recorder = serveAdmin(newTestMux(newTestAPI(NewMemoryStore())), http.MethodPost, handlers.AdminSymbolsPath, `{"reason":"GBP was missing"}`)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("RegenerateSymbols() without rates status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestListAuditHandler(t *testing.T) {
+	store := NewMemoryStore()
+	mux := newTestMux(newTestAPI(store))
+	for _, date := range []string{"2025-01-02", "2025-01-03", "2025-01-06"} {
+		serveAdmin(mux, http.MethodPut, "/v1/admin/rates/EUR/"+date, `{"rates":{"USD":1.03},"reason":"backfill"}`)
+	}
+
+	recorder := serveAdmin(mux, http.MethodGet, handlers.AdminAuditPath+"?limit=2", "")
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("ListAudit() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	var record AuditRecord
+	if err := json.Unmarshal(recorder.Body.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	var documents []string
+	for _, entry := range record.Entries {
+		documents = append(documents, entry.Document)
+	}
+	want := []string{"exchange_rates/EUR-2025-01-06", "exchange_rates/EUR-2025-01-03"}
+	if !reflect.DeepEqual(documents, want) {
+		t.Errorf("ListAudit() documents = %v, want %v", documents, want)
+	}
+
+	for _, limit := range []string{"0", "501", "many"} {
+		if recorder := serveAdmin(mux, http.MethodGet, handlers.AdminAuditPath+"?limit="+limit, ""); recorder.Code != http.StatusBadRequest {
+			t.Errorf("ListAudit() with limit %s status = %d, want %d", limit, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// Store makes corrections and records them in the audit log, both or neither. The entries
+// passed in carry the action, actor, reason and time; the store fills in the rest and returns
+// the recorded entry.
+type Store interface {
+	// PutRates creates or replaces the rates of record.Base on record.Date.
+	PutRates(ctx context.Context, record handlers.ExchangeRateRecord, entry AuditEntry) (*AuditEntry, error)
+	// DeleteRates removes the rates of base on date, or returns ErrNotFound when there are none.
+	DeleteRates(ctx context.Context, base string, date string, entry AuditEntry) (*AuditEntry, error)
+	// RegenerateSymbols rebuilds the symbols document of the latest date with rates, or returns
+	// ErrNoRates when there are none.
+	RegenerateSymbols(ctx context.Context, entry AuditEntry) (*AuditEntry, error)
+	// Audit returns the latest limit entries of the audit log, newest first.
+	Audit(ctx context.Context, limit int) ([]AuditEntry, error)
+}
+
+// MemoryStore keeps rates, symbols and the audit log in memory, they are lost on restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	rates   map[string]handlers.ExchangeRateRecord
+	symbols map[string]handlers.SymbolsRecord
+	audit   []AuditEntry
+}
+
+func NewMemoryStore(records ...handlers.ExchangeRateRecord) *MemoryStore {
+	store := &MemoryStore{
+		rates:   map[string]handlers.ExchangeRateRecord{},
+		symbols: map[string]handlers.SymbolsRecord{},
+	}
+	for _, record := range records {
+		store.rates[ratesDocument(record.Base, record.Date)] = record
+	}
+	return store
+}
+
+func (s *MemoryStore) PutRates(ctx context.Context, record handlers.ExchangeRateRecord, entry AuditEntry) (*AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := ratesDocument(record.Base, record.Date)
+	entry.Document = ratesCollection + "/" + id
+	if old, ok := s.rates[id]; ok {
+		entry.OldValue = rateFields(old)
+	}
+	entry.NewValue = rateFields(record)
+	s.rates[id] = record
+	return s.record(entry), nil
+}
+
+func (s *MemoryStore) DeleteRates(ctx context.Context, base string, date string, entry AuditEntry) (*AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := ratesDocument(base, date)
+	old, ok := s.rates[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	entry.Document = ratesCollection + "/" + id
+	entry.OldValue = rateFields(old)
+	delete(s.rates, id)
+	return s.record(entry), nil
+}
+
+func (s *MemoryStore) RegenerateSymbols(ctx context.Context, entry AuditEntry) (*AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	symbols, err := generateSymbols(slices.Collect(maps.Values(s.rates)))
+	if err != nil {
+		return nil, err
+	}
+	entry.Document = symbolsCollection + "/" + symbols.Date
+	if old, ok := s.symbols[symbols.Date]; ok {
+		entry.OldValue = symbolFields(old)
+	}
+	entry.NewValue = symbolFields(symbols)
+	s.symbols[symbols.Date] = symbols
+	return s.record(entry), nil
+}
+
+func (s *MemoryStore) Audit(ctx context.Context, limit int) ([]AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := slices.Clone(s.audit)
+	slices.Reverse(entries)
+	return entries[:min(limit, len(entries))], nil
+}
+
+// Rates returns the rates of base on date, or nil when there are none.
+func (s *MemoryStore) Rates(base string, date string) *handlers.ExchangeRateRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.rates[ratesDocument(base, date)]
+	if !ok {
+		return nil
+	}
+	return &record
+}
+
+// Symbols returns the symbols document of date, or nil when there is none.
+func (s *MemoryStore) Symbols(date string) *handlers.SymbolsRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.symbols[date]
+	if !ok {
+		return nil
+	}
+	return &record
+}
+
+// record appends entry to the audit log, the caller must hold the lock.
+func (s *MemoryStore) record(entry AuditEntry) *AuditEntry {
+	entry.ID = strconv.Itoa(len(s.audit) + 1)
+	s.audit = append(s.audit, entry)
+	return &entry
+}
+
+// FirestoreStore corrects the exchange_rates and symbols collections, and keeps the audit log
+// in AuditCollection, through Client, the Firestore client the server shares. Each correction
+// runs in a transaction with its audit entry.
+type FirestoreStore struct {
+	Client          *firestore.Client
+	AuditCollection string
+}
+
+func (s FirestoreStore) PutRates(ctx context.Context, record handlers.ExchangeRateRecord, entry AuditEntry) (*AuditEntry, error) {
+	return s.correct(ctx, entry, func(transaction *firestore.Transaction, entry *AuditEntry) error {
+		document := s.Client.Collection(ratesCollection).Doc(ratesDocument(record.Base, record.Date))
+		old, err := existingFields(transaction, document)
+		if err != nil {
+			return err
+		}
+
+		entry.Document = ratesCollection + "/" + document.ID
+		entry.OldValue = old
+		entry.NewValue = rateFields(record)
+		return transaction.Set(document, entry.NewValue)
+	})
+}
+
+func (s FirestoreStore) DeleteRates(ctx context.Context, base string, date string, entry AuditEntry) (*AuditEntry, error) {
+	return s.correct(ctx, entry, func(transaction *firestore.Transaction, entry *AuditEntry) error {
+		document := s.Client.Collection(ratesCollection).Doc(ratesDocument(base, date))
+		old, err := existingFields(transaction, document)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return ErrNotFound
+		}
+
+		entry.Document = ratesCollection + "/" + document.ID
+		entry.OldValue = old
+		return transaction.Delete(document)
+	})
+}
+
+func (s FirestoreStore) RegenerateSymbols(ctx context.Context, entry AuditEntry) (*AuditEntry, error) {
+	return s.correct(ctx, entry, func(transaction *firestore.Transaction, entry *AuditEntry) error {
+		rates := s.Client.Collection(ratesCollection)
+		latest, err := readRates(transaction, rates.OrderBy("date", firestore.Desc).Limit(1))
+		if err != nil {
+			return err
+		}
+		if len(latest) == 0 {
+			return ErrNoRates
+		}
+		records, err := readRates(transaction, rates.Where("date", "==", latest[0].Date))
+		if err != nil {
+			return err
+		}
+		symbols, err := generateSymbols(records)
+		if err != nil {
+			return err
+		}
+
+		document := s.Client.Collection(symbolsCollection).Doc(symbols.Date)
+		old, err := existingFields(transaction, document)
+		if err != nil {
+			return err
+		}
+
+		entry.Document = symbolsCollection + "/" + document.ID
+		entry.OldValue = old
+		entry.NewValue = symbolFields(symbols)
+		return transaction.Set(document, entry.NewValue)
+	})
+}
+
+func (s FirestoreStore) Audit(ctx context.Context, limit int) ([]AuditEntry, error) {
+	documents := s.Client.Collection(s.AuditCollection).OrderBy("changed_at", firestore.Desc).Limit(limit).Documents(ctx)
+	defer documents.Stop()
+
+	var entries []AuditEntry
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var entry AuditEntry
+		if err := document.DataTo(&entry); err != nil {
+			return nil, err
+		}
+		entry.ID = document.Ref.ID
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// correct runs a correction in a transaction that also records its audit entry. The
+// transaction may run more than once, so change must fill in the entry on every run.
+func (s FirestoreStore) correct(ctx context.Context, entry AuditEntry, change func(*firestore.Transaction, *AuditEntry) error) (*AuditEntry, error) {
+	audit := s.Client.Collection(s.AuditCollection).NewDoc()
+	recorded := entry
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, transaction *firestore.Transaction) error {
+		recorded = entry
+		if err := change(transaction, &recorded); err != nil {
+			return err
+		}
+		return transaction.Create(audit, recorded)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recorded.ID = audit.ID
+	return &recorded, nil
+}
+
+// existingFields reads the fields of document, nil when it doesn't exist.
+func existingFields(transaction *firestore.Transaction, document *firestore.DocumentRef) (map[string]any, error) {
+	snapshot, err := transaction.Get(document)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Data(), nil
+}
+
+func readRates(transaction *firestore.Transaction, query firestore.Query) ([]handlers.ExchangeRateRecord, error) {
+	documents := transaction.Documents(query)
+	defer documents.Stop()
+
+	var records []handlers.ExchangeRateRecord
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var record handlers.ExchangeRateRecord
+		if err := document.DataTo(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
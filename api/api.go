@@ -0,0 +1,4 @@
+// Package api holds the types the Forex API sends and receives and the paths it serves them on.
+// It only depends on the standard library, so clients can import it without pulling in the
+// server's dependencies.
+package api
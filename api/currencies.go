@@ -0,0 +1,35 @@
+package api
+
+type CurrencyRecord struct {
+	Code          string   `json:"code"`
+	Locale        string   `json:"locale"`
+	Name          string   `json:"name"`
+	Sign          string   `json:"sign"`
+	SignPlacement string   `json:"sign_placement" enums:"before,after"`
+	NumericCode   string   `json:"numeric_code"`
+	MinorUnits    int      `json:"minor_units"`
+	Countries     []string `json:"countries"`
+	Status        string   `json:"status" enums:"active,withdrawn"`
+	Withdrawn     string   `json:"withdrawn,omitempty"`
+	ReplacedBy    string   `json:"replaced_by,omitempty"`
+}
+
+type NamedSymbol struct {
+	Symbol        string `json:"symbol"`
+	Name          string `json:"name"`
+	Sign          string `json:"sign"`
+	SignPlacement string `json:"sign_placement" enums:"before,after"`
+}
+
+type CurrenciesRecord struct {
+	Date   string        `json:"date"`
+	Locale string        `json:"locale"`
+	Data   []NamedSymbol `json:"data"`
+}
+
+type FormattedAmountRecord struct {
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Locale    string  `json:"locale"`
+	Formatted string  `json:"formatted"`
+}
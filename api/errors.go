@@ -0,0 +1,7 @@
+package api
+
+// Error is the body of every error response.
+type Error struct {
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
@@ -0,0 +1,19 @@
+package api
+
+type GraphQLRequest struct {
+	Query         string         `json:"query" example:"{ latest(base: \"USD\") { date rates(symbols: [\"EUR\", \"JPY\"]) } }"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type GraphQLError struct {
+	Message   string            `json:"message"`
+	Locations []GraphQLLocation `json:"locations,omitempty"`
+	// Path leads to the field that failed, through field names and list indexes.
+	Path []any `json:"path,omitempty"`
+}
@@ -0,0 +1,15 @@
+package api
+
+type HealthRecord struct {
+	Status string `json:"status"`
+}
+
+type ReadinessCheck struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type ReadinessRecord struct {
+	Status string                    `json:"status"`
+	Checks map[string]ReadinessCheck `json:"checks"`
+}
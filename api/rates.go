@@ -0,0 +1,119 @@
+package api
+
+import "time"
+
+type ExchangeRateRecord struct {
+	Base      string             `json:"base"`
+	Date      string             `json:"date"`
+	Rates     map[string]float64 `json:"rates"`
+	Freshness *Freshness         `json:"freshness,omitempty" firestore:"-"`
+}
+
+type SymbolsRecord struct {
+	Date    string   `json:"date" firestore:"date"`
+	Symbols []string `json:"symbols" firestore:"symbols"`
+}
+
+// Freshness describes how far a record lags behind the publication calendar.
+type Freshness struct {
+	// ExpectedDate is the date of the most recent publication that should be available.
+	ExpectedDate string `json:"expected_date"`
+	// MissedPublications counts the publications between the record and ExpectedDate.
+	MissedPublications int `json:"missed_publications"`
+	// StaleSeconds is how long ago the first missed publication was due, 0 when up to date.
+	StaleSeconds int64 `json:"stale_seconds"`
+	Stale        bool  `json:"stale"`
+}
+
+// StaleFor returns how long the record has been overdue for replacement.
+func (f *Freshness) StaleFor() time.Duration {
+	return time.Duration(f.StaleSeconds) * time.Second
+}
+
+const (
+	// MaxBatchQueries is the largest number of queries accepted in one batch request.
+	MaxBatchQueries = 25
+
+	// FormatJSON and FormatCSV are the formats the candles can be requested in.
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+type BatchQuery struct {
+	Base    string   `json:"base" example:"USD"`
+	Symbols []string `json:"symbols,omitempty" example:"EUR,GBP"`
+	// Date selects the rates in effect on a day (YYYY-MM-DD), the latest rates when empty.
+	Date string `json:"date,omitempty" example:"2025-11-21"`
+}
+
+type BatchResult struct {
+	Query BatchQuery          `json:"query"`
+	Data  *ExchangeRateRecord `json:"data,omitempty"`
+	Error *Error              `json:"error,omitempty"`
+}
+
+type BatchRecord struct {
+	Results []BatchResult `json:"results"`
+}
+
+// Fluctuation describes how the rate of one currency moved between two dates.
+type Fluctuation struct {
+	StartRate float64 `json:"start_rate"`
+	EndRate   float64 `json:"end_rate"`
+	Change    float64 `json:"change"`
+	// ChangePercent is Change relative to StartRate, in percent.
+	ChangePercent float64 `json:"change_pct"`
+}
+
+type FluctuationRecord struct {
+	Base string `json:"base"`
+	// StartDate and EndDate are the dates of the publications compared, which are the most
+	// recent ones on or before the requested dates.
+	StartDate string                 `json:"start_date"`
+	EndDate   string                 `json:"end_date"`
+	Rates     map[string]Fluctuation `json:"rates"`
+}
+
+// RateStats aggregates the daily rates of one currency over a period.
+type RateStats struct {
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	// StdDev is the population standard deviation of the daily rates.
+	StdDev float64 `json:"std_dev"`
+	// Count is the number of publications the aggregates are computed from.
+	Count int `json:"count"`
+}
+
+type StatsPeriod struct {
+	Start string               `json:"start"`
+	End   string               `json:"end"`
+	Rates map[string]RateStats `json:"rates"`
+}
+
+type StatsRecord struct {
+	Base      string        `json:"base"`
+	StartDate string        `json:"start_date"`
+	EndDate   string        `json:"end_date"`
+	Interval  string        `json:"interval"`
+	Periods   []StatsPeriod `json:"periods"`
+}
+
+// Candle summarizes the rates of one currency over a period as open, high, low and close.
+type Candle struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Open  float64 `json:"open"`
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+}
+
+type CandlesRecord struct {
+	Base      string `json:"base"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Interval  string `json:"interval"`
+	// Candles maps currency codes to their candles, oldest first.
+	Candles map[string][]Candle `json:"candles"`
+}
@@ -0,0 +1,31 @@
+package api
+
+const (
+	LatestPath       = "/v1/rates/latest"
+	HistoricalPath   = "/v1/rates/historical"
+	SymbolsPath      = "/v1/rates/symbols"
+	BatchPath        = "/v1/rates/batch"
+	FluctuationPath  = "/v1/rates/fluctuation"
+	StatsPath        = "/v1/rates/stats"
+	CandlesPath      = "/v1/rates/ohlc"
+	StreamPath       = "/v1/rates/stream"
+	CurrenciesPath   = "/v1/currencies"
+	CurrencyPath     = "/v1/currencies/{code}"
+	FormatPath       = "/v1/format"
+	WebhooksPath     = "/v1/webhooks"
+	WebhookPath      = "/v1/webhooks/{id}"
+	AdminRatesPath   = "/v1/admin/rates/{base}/{date}"
+	AdminSymbolsPath = "/v1/admin/symbols/regenerate"
+	AdminAuditPath   = "/v1/admin/audit"
+	V2LatestPath     = "/v2/rates/latest"
+	V2SymbolsPath    = "/v2/rates/symbols"
+	V2CurrenciesPath = "/v2/currencies"
+	GraphQLPath      = "/graphql"
+	OpenAPISpecPath  = "/openapi.yaml"
+	OpenAPI3Path     = "/openapi.json"
+	DocsPath         = "/docs/"
+	DebugVarsPath    = "/debug/vars"
+	HealthPath       = "/healthz"
+	ReadinessPath    = "/readyz"
+	VersionPath      = "/version"
+)
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	// EventRatesPublished is sent when rates with a newer date are published for a base.
+	EventRatesPublished = "rates.published"
+	// EventThresholdCrossed is sent when a rate crosses a webhook's threshold.
+	EventThresholdCrossed = "rates.threshold_crossed"
+
+	DirectionUp   = "up"
+	DirectionDown = "down"
+
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// Threshold makes a webhook fire when the rate of Symbol crosses Value, instead of on every
+// publication.
+type Threshold struct {
+	Symbol string  `json:"symbol" firestore:"symbol" example:"USD"`
+	Value  float64 `json:"value" firestore:"value" example:"1.1"`
+}
+
+type Webhook struct {
+	ID  string `json:"id" firestore:"id"`
+	URL string `json:"url" firestore:"url" example:"https://example.com/hooks/rates"`
+	// Base is the base currency the webhook watches.
+	Base string `json:"base" firestore:"base" example:"EUR"`
+	// Symbols limits the rates sent on publication, all rates are sent when empty.
+	Symbols   []string   `json:"symbols,omitempty" firestore:"symbols"`
+	Threshold *Threshold `json:"threshold,omitempty" firestore:"threshold"`
+	// Secret signs the deliveries. It is only returned when the webhook is created.
+	Secret string `json:"secret,omitempty" firestore:"secret"`
+	// Token authenticates the owner of the webhook to list and delete their webhooks. It is
+	// only returned when the webhook is created without one, which makes a new owner.
+	Token     string    `json:"token,omitempty" firestore:"-"`
+	Owner     string    `json:"-" firestore:"owner"`
+	CreatedAt time.Time `json:"created_at" firestore:"created_at"`
+}
+
+// Event returns the kind of event the webhook receives.
+func (w *Webhook) Event() string {
+	if w.Threshold != nil {
+		return EventThresholdCrossed
+	}
+	return EventRatesPublished
+}
+
+// Crossing describes a rate moving across a webhook threshold between two publications.
+type Crossing struct {
+	Symbol    string  `json:"symbol"`
+	Threshold float64 `json:"threshold"`
+	Previous  float64 `json:"previous"`
+	Current   float64 `json:"current"`
+	Direction string  `json:"direction"`
+}
+
+// Payload is the JSON body posted to a webhook.
+type Payload struct {
+	// ID identifies the delivery, it stays the same across retries and is the same for every
+	// instance of the server.
+	ID        string              `json:"id"`
+	Event     string              `json:"event"`
+	WebhookID string              `json:"webhook_id"`
+	CreatedAt time.Time           `json:"created_at"`
+	Rates     *ExchangeRateRecord `json:"rates"`
+	Crossing  *Crossing           `json:"crossing,omitempty"`
+}
+
+type Registration struct {
+	URL     string   `json:"url" example:"https://example.com/hooks/rates"`
+	Base    string   `json:"base,omitempty" example:"EUR"`
+	Symbols []string `json:"symbols,omitempty" example:"USD,GBP"`
+	// Threshold makes the webhook fire only when a rate crosses a value.
+	Threshold *Threshold `json:"threshold,omitempty"`
+	// Secret signs the deliveries, one is generated when it is empty.
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhooksRecord struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// Sign computes the signature of a delivery: the hex encoded HMAC-SHA256 of the timestamp,
+// a dot and the body, keyed with the webhook secret. Receivers recompute it to verify that a
+// delivery is authentic and compare the timestamp to reject replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
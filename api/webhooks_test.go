@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1764950400, 0)
+	body := []byte(`{"event":"rates.published"}`)
+
+	signature := Sign("secret", timestamp, body)
+
+	want := "sha256=c0dda056165bf9c931b876f6408063b07be5db71358e29c791dbe759b0e76d44"
+	if signature != want {
+		t.Errorf("Sign() = %q, want %q", signature, want)
+	}
+	if Sign("other", timestamp, body) == signature || Sign("secret", timestamp.Add(time.Second), body) == signature {
+		t.Error("Sign() doesn't depend on the secret and timestamp")
+	}
+}
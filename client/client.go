@@ -0,0 +1,272 @@
+// Package client is a typed Go client for the Forex API. It shares its record types with the
+// server through the api package, so they never drift apart.
+//
+//	forex, err := client.New("https://forex.example.com", client.WithCache(time.Minute))
+//	...
+//	record, err := forex.Latest(ctx, "USD", "EUR", "GBP")
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = 200 * time.Millisecond
+)
+
+// Error is returned for responses with an error status. Its message comes from the error body
+// the API sends.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("forex api: %d %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	var apiError *Error
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
+
+type Client struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	cache       *responseCache
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends requests through httpClient instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times idempotent requests are tried when they fail with a network
+// error, a 429 or a 5xx status, and the wait before the first retry, which doubles after every
+// retry. One attempt disables retries.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = max(maxAttempts, 1)
+		c.backoff = backoff
+	}
+}
+
+// WithCache keeps successful GET responses in memory for ttl. Rates change once a day, so
+// even a short ttl saves most requests of a busy service.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = &responseCache{ttl: ttl, entries: map[string]cacheEntry{}}
+	}
+}
+
+// New creates a client for the API served at baseURL, e.g. http://localhost:8000.
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("forex api: base URL %q must be absolute", baseURL)
+	}
+
+	client := &Client{
+		baseURL:     parsed,
+		httpClient:  http.DefaultClient,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+	}
+	for _, option := range options {
+		option(client)
+	}
+	return client, nil
+}
+
+// request describes a call to the API.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   any
+	// idempotent requests are retried and, for GET, cached unless uncached is set.
+	idempotent bool
+	uncached   bool
+	// accept lists the error statuses whose body decodes into the result like a success.
+	accept []int
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	target := *c.baseURL
+	target.Path += path
+	target.RawQuery = query.Encode()
+	return target.String()
+}
+
+// do sends a request and decodes its JSON response into result, unless result is nil.
+func (c *Client) do(ctx context.Context, req request, result any) error {
+	body, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
+
+// send sends a request, retrying and caching it when it is idempotent, and returns the body
+// of the response.
+func (c *Client) send(ctx context.Context, req request) ([]byte, error) {
+	target := c.url(req.path, req.query)
+	cacheKey := req.method + " " + target + " " + req.header.Get("Accept-Language") + " " + req.header.Get("Accept")
+	cacheable := c.cache != nil && req.idempotent && !req.uncached && req.method == http.MethodGet
+	if cacheable {
+		if body, ok := c.cache.get(cacheKey); ok {
+			return body, nil
+		}
+	}
+
+	var payload []byte
+	if req.body != nil {
+		encoded, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, err
+		}
+		payload = encoded
+	}
+
+	attempts := 1
+	if req.idempotent {
+		attempts = c.maxAttempts
+	}
+
+	backoff := c.backoff
+	var body []byte
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retryable bool
+		body, retryable, err = c.attempt(ctx, req, target, payload)
+		if err == nil || !retryable || attempt >= attempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		c.cache.set(cacheKey, body)
+	}
+	return body, nil
+}
+
+// attempt sends a request once and reports whether a failure is worth retrying.
+func (c *Client) attempt(ctx context.Context, req request, target string, payload []byte) ([]byte, bool, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, req.method, target, reader)
+	if err != nil {
+		return nil, false, err
+	}
+	for key, values := range req.header {
+		httpRequest.Header[key] = values
+	}
+	if payload != nil {
+		httpRequest.Header.Set("content-type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode <= 299 {
+		return body, false, nil
+	}
+	for _, status := range req.accept {
+		if response.StatusCode == status {
+			return body, false, nil
+		}
+	}
+
+	retryable := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+	return nil, retryable, decodeError(response.StatusCode, body)
+}
+
+// decodeError turns the error body of a response into an Error, falling back to the status
+// text for bodies that don't come from the API, like those of proxies.
+func decodeError(statusCode int, body []byte) *Error {
+	apiError := &Error{StatusCode: statusCode, Message: http.StatusText(statusCode)}
+	var decoded api.Error
+	if json.Unmarshal(body, &decoded) == nil && decoded.Message != "" {
+		apiError.Message = decoded.Message
+	}
+	return apiError
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+type responseCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func (c *responseCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+func (c *responseCache) set(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{body: body, expires: time.Now().Add(c.ttl)}
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/utils"
+)
+
+// newTestClient returns a client for an API served by handler, retrying without delay.
+func newTestClient(t *testing.T, handler http.HandlerFunc, options ...Option) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL, append([]Option{WithRetries(3, time.Millisecond)}, options...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+// respond returns a handler that checks the requested path and replies with body.
+func respond(t *testing.T, wantPath string, status int, body any) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != wantPath {
+			t.Errorf("requested path = %q, want %q", request.URL.Path, wantPath)
+		}
+		utils.WriteJSON(writer, status, body)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{name: "absolute URL", baseURL: "http://localhost:8000"},
+		{name: "trailing slash", baseURL: "https://forex.example.com/"},
+		{name: "relative URL", baseURL: "/v1", wantErr: true},
+		{name: "invalid URL", baseURL: "http://[::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.baseURL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New(%q) error = %v, wantErr %v", tt.baseURL, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         any
+		wantAttempts int32
+		wantMessage  string
+	}{
+		{name: "decodes API errors", status: http.StatusNotFound, body: utils.Error{Message: "Base not found", Status: http.StatusNotFound}, wantAttempts: 1, wantMessage: "Base not found"},
+		{name: "falls back to the status text", status: http.StatusBadGateway, body: "upstream down", wantAttempts: 3, wantMessage: "Bad Gateway"},
+		{name: "retries rate limits", status: http.StatusTooManyRequests, body: utils.Error{Message: "Slow down"}, wantAttempts: 3, wantMessage: "Slow down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+				attempts.Add(1)
+				utils.WriteJSON(writer, tt.status, tt.body)
+			})
+
+			_, err := client.Latest(context.Background(), "XYZ")
+
+			var apiError *Error
+			if !errors.As(err, &apiError) {
+				t.Fatalf("Latest() error = %v, want an *Error", err)
+			}
+			if apiError.StatusCode != tt.status || apiError.Message != tt.wantMessage {
+				t.Errorf("Latest() error = %+v, want status %d and message %q", apiError, tt.status, tt.wantMessage)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("Latest() attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if IsNotFound(err) != (tt.status == http.StatusNotFound) {
+				t.Errorf("IsNotFound() = %v for status %d", IsNotFound(err), tt.status)
+			}
+		})
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		if attempts.Add(1) == 1 {
+			utils.ErrorHandler(writer, "Service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		utils.WriteJSON(writer, http.StatusOK, ExchangeRateRecord{Base: "EUR", Date: "2025-12-05"})
+	})
+
+	record, err := client.Latest(context.Background(), "EUR")
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if record.Date != "2025-12-05" || attempts.Load() != 2 {
+		t.Errorf("Latest() = %+v after %d attempts, want the record after 2", record, attempts.Load())
+	}
+}
+
+func TestClient_RetryCanceled(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		utils.ErrorHandler(writer, "Service unavailable", http.StatusServiceUnavailable)
+	}, WithRetries(3, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Latest(ctx, "EUR"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Latest() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_Cache(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		attempts.Add(1)
+		utils.WriteJSON(writer, http.StatusOK, ExchangeRateRecord{Base: request.URL.Query().Get("base")})
+	}, WithCache(time.Minute))
+
+	ctx := context.Background()
+	for _, base := range []string{"EUR", "EUR", "USD"} {
+		record, err := client.Latest(ctx, base)
+		if err != nil {
+			t.Fatalf("Latest() error = %v", err)
+		}
+		if record.Base != base {
+			t.Errorf("Latest(%q) base = %q", base, record.Base)
+		}
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2 with the repeated base cached", got)
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kamaal111/forex-api/api"
+)
+
+type (
+	NamedSymbol           = api.NamedSymbol
+	CurrenciesRecord      = api.CurrenciesRecord
+	CurrencyRecord        = api.CurrencyRecord
+	FormattedAmountRecord = api.FormattedAmountRecord
+)
+
+// localeQuery asks for names and formats in locale, e.g. de or nl-BE. An empty locale leaves
+// the choice to the API, which falls back to English.
+func localeQuery(locale string) url.Values {
+	query := url.Values{}
+	setQuery(query, "locale", locale)
+	return query
+}
+
+// Currencies returns the available currencies with their names in locale, ordered by sort
+// ("code" or "name") when it isn't empty.
+func (c *Client) Currencies(ctx context.Context, sort string, locale string) (*CurrenciesRecord, error) {
+	query := localeQuery(locale)
+	setQuery(query, "sort", sort)
+
+	var record CurrenciesRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.CurrenciesPath, query: query, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Currency returns the ISO 4217 details of the currency with code, named in locale.
+func (c *Client) Currency(ctx context.Context, code string, locale string) (*CurrencyRecord, error) {
+	path := strings.Replace(api.CurrencyPath, "{code}", url.PathEscape(code), 1)
+
+	var record CurrencyRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: path, query: localeQuery(locale), idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Format formats amount of currency the way locale writes it.
+func (c *Client) Format(ctx context.Context, amount float64, currency string, locale string) (*FormattedAmountRecord, error) {
+	query := localeQuery(locale)
+	query.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	query.Set("currency", currency)
+
+	var record FormattedAmountRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.FormatPath, query: query, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
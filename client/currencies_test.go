@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+func TestClient_Currencies(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		if query := request.URL.Query().Encode(); query != "locale=de&sort=name" {
+			t.Errorf("requested query = %q", query)
+		}
+		utils.WriteJSON(writer, http.StatusOK, CurrenciesRecord{Locale: "de", Data: []NamedSymbol{{Symbol: "EUR", Name: "Euro"}}})
+	})
+
+	record, err := client.Currencies(context.Background(), "name", "de")
+	if err != nil {
+		t.Fatalf("Currencies() error = %v", err)
+	}
+	if record.Locale != "de" || len(record.Data) != 1 {
+		t.Errorf("Currencies() = %+v", record)
+	}
+}
+
+func TestClient_Currency(t *testing.T) {
+	client := newTestClient(t, respond(t, "/v1/currencies/JPY", http.StatusOK, CurrencyRecord{Code: "JPY", MinorUnits: 0}))
+
+	record, err := client.Currency(context.Background(), "JPY", "")
+	if err != nil {
+		t.Fatalf("Currency() error = %v", err)
+	}
+	if record.Code != "JPY" {
+		t.Errorf("Currency() = %+v", record)
+	}
+}
+
+func TestClient_Format(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != handlers.FormatPath {
+			t.Errorf("requested path = %q, want %q", request.URL.Path, handlers.FormatPath)
+		}
+		if query := request.URL.Query().Encode(); query != "amount=1234.5&currency=EUR&locale=nl" {
+			t.Errorf("requested query = %q", query)
+		}
+		utils.WriteJSON(writer, http.StatusOK, FormattedAmountRecord{Amount: 1234.5, Currency: "EUR", Locale: "nl", Formatted: "€ 1.234,50"})
+	})
+
+	record, err := client.Format(context.Background(), 1234.5, "EUR", "nl")
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if record.Formatted != "€ 1.234,50" {
+		t.Errorf("Format() = %+v", record)
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/version"
+)
+
+type (
+	HealthRecord    = api.HealthRecord
+	ReadinessCheck  = api.ReadinessCheck
+	ReadinessRecord = api.ReadinessRecord
+	VersionInfo     = version.Info
+)
+
+// Health reports whether the API process is up. Probes aren't retried nor cached, so they
+// always describe the API as it is.
+func (c *Client) Health(ctx context.Context) (*HealthRecord, error) {
+	var record HealthRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.HealthPath}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Readiness returns the checks of the API dependencies. A report with an unavailable status
+// isn't an error, so callers can tell which dependency failed.
+func (c *Client) Readiness(ctx context.Context) (*ReadinessRecord, error) {
+	var record ReadinessRecord
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   api.ReadinessPath,
+		accept: []int{http.StatusServiceUnavailable},
+	}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Version returns the build information of the API.
+func (c *Client) Version(ctx context.Context) (*VersionInfo, error) {
+	var info VersionInfo
+	err := c.do(ctx, request{method: http.MethodGet, path: api.VersionPath, idempotent: true}, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// OpenAPISpec returns the Swagger 2.0 document of the API in YAML.
+func (c *Client) OpenAPISpec(ctx context.Context) ([]byte, error) {
+	return c.send(ctx, request{method: http.MethodGet, path: api.OpenAPISpecPath, idempotent: true})
+}
+
+// OpenAPI3Spec returns the OpenAPI 3.1 document of the API in JSON.
+func (c *Client) OpenAPI3Spec(ctx context.Context) ([]byte, error) {
+	return c.send(ctx, request{method: http.MethodGet, path: api.OpenAPI3Path, idempotent: true})
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+func TestClient_Readiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus string
+	}{
+		{name: "ready", status: http.StatusOK, wantStatus: handlers.StatusOK},
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantStatus: handlers.StatusUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, respond(t, handlers.ReadinessPath, tt.status, ReadinessRecord{
+				Status: tt.wantStatus,
+				Checks: map[string]ReadinessCheck{"repository": {Status: tt.wantStatus}},
+			}))
+
+			record, err := client.Readiness(context.Background())
+			if err != nil {
+				t.Fatalf("Readiness() error = %v", err)
+			}
+			if record.Status != tt.wantStatus || record.Checks["repository"].Status != tt.wantStatus {
+				t.Errorf("Readiness() = %+v, want status %q", record, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClient_Version(t *testing.T) {
+	client := newTestClient(t, respond(t, handlers.VersionPath, http.StatusOK, VersionInfo{Commit: "abc123", GoVersion: "go1.25"}))
+
+	info, err := client.Version(context.Background())
+	if err != nil {
+		t.Fatalf("Version() error = %v", err)
+	}
+	if info.Commit != "abc123" {
+		t.Errorf("Version() = %+v", info)
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kamaal111/forex-api/api"
+)
+
+type (
+	ExchangeRateRecord = api.ExchangeRateRecord
+	Freshness          = api.Freshness
+	SymbolsRecord      = api.SymbolsRecord
+	BatchQuery         = api.BatchQuery
+	BatchResult        = api.BatchResult
+	BatchRecord        = api.BatchRecord
+	Fluctuation        = api.Fluctuation
+	FluctuationRecord  = api.FluctuationRecord
+	RateStats          = api.RateStats
+	StatsPeriod        = api.StatsPeriod
+	StatsRecord        = api.StatsRecord
+	Candle             = api.Candle
+	CandlesRecord      = api.CandlesRecord
+)
+
+// RangeParams selects the rates of a date range, for fluctuations, statistics and candles.
+type RangeParams struct {
+	// Start and End are formatted as YYYY-MM-DD.
+	Start   string
+	End     string
+	Base    string
+	Symbols []string
+	// Interval is ignored by Fluctuation.
+	Interval string
+}
+
+func (p RangeParams) query() url.Values {
+	query := url.Values{}
+	query.Set("start", p.Start)
+	query.Set("end", p.End)
+	setQuery(query, "base", p.Base)
+	setQuery(query, "symbols", strings.Join(p.Symbols, ","))
+	setQuery(query, "interval", p.Interval)
+	return query
+}
+
+// setQuery sets key only when value isn't empty, leaving the default to the API.
+func setQuery(query url.Values, key string, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// Latest returns the latest rates of base, limited to symbols when any are given.
+func (c *Client) Latest(ctx context.Context, base string, symbols ...string) (*ExchangeRateRecord, error) {
+	query := url.Values{}
+	setQuery(query, "base", base)
+	setQuery(query, "symbols", strings.Join(symbols, ","))
+
+	var record ExchangeRateRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.LatestPath, query: query, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Historical returns the rates of base in effect on date (YYYY-MM-DD), limited to symbols when
+// any are given.
+func (c *Client) Historical(ctx context.Context, date string, base string, symbols ...string) (*ExchangeRateRecord, error) {
+	query := url.Values{}
+	setQuery(query, "date", date)
+	setQuery(query, "base", base)
+	setQuery(query, "symbols", strings.Join(symbols, ","))
+
+	var record ExchangeRateRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.HistoricalPath, query: query, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// timeSeriesQuery selects the daily rates of a date range from the GraphQL endpoint, the only
+// one serving them one by one.
+const timeSeriesQuery = `query TimeSeries($start: String!, $end: String!, $base: String, $symbols: [String!]) {
+  timeSeries(start: $start, end: $end, base: $base) { base date rates(symbols: $symbols) }
+}`
+
+// TimeSeries returns the rates published within a date range, oldest first. The interval of
+// params is ignored.
+func (c *Client) TimeSeries(ctx context.Context, params RangeParams) ([]ExchangeRateRecord, error) {
+	variables := map[string]any{"start": params.Start, "end": params.End}
+	if params.Base != "" {
+		variables["base"] = params.Base
+	}
+	if len(params.Symbols) > 0 {
+		variables["symbols"] = params.Symbols
+	}
+
+	var response struct {
+		Data struct {
+			TimeSeries []ExchangeRateRecord `json:"timeSeries"`
+		} `json:"data"`
+		Errors []api.GraphQLError `json:"errors"`
+	}
+	err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       api.GraphQLPath,
+		body:       api.GraphQLRequest{Query: timeSeriesQuery, Variables: variables},
+		idempotent: true,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("forex api: %s", response.Errors[0].Message)
+	}
+	return response.Data.TimeSeries, nil
+}
+
+// Symbols returns the available currency symbols, ordered by sort ("code" or "name") when
+// it isn't empty.
+func (c *Client) Symbols(ctx context.Context, sort string) (*SymbolsRecord, error) {
+	query := url.Values{}
+	setQuery(query, "sort", sort)
+
+	var record SymbolsRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.SymbolsPath, query: query, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Batch runs up to api.MaxBatchQueries rate queries in one request. A query that fails
+// doesn't fail the batch; its result holds the error instead.
+func (c *Client) Batch(ctx context.Context, queries []BatchQuery) (*BatchRecord, error) {
+	var record BatchRecord
+	// Batches only read rates, so they are safe to retry even though they are posted.
+	err := c.do(ctx, request{method: http.MethodPost, path: api.BatchPath, body: queries, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Fluctuation returns how the rates changed between the start and end of a date range.
+func (c *Client) Fluctuation(ctx context.Context, params RangeParams) (*FluctuationRecord, error) {
+	params.Interval = ""
+
+	var record FluctuationRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.FluctuationPath, query: params.query(), idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Stats returns the average, minimum, maximum and standard deviation of the rates of a date
+// range per interval.
+func (c *Client) Stats(ctx context.Context, params RangeParams) (*StatsRecord, error) {
+	var record StatsRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.StatsPath, query: params.query(), idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Candles returns the open, high, low and close rates of a date range per interval.
+func (c *Client) Candles(ctx context.Context, params RangeParams) (*CandlesRecord, error) {
+	query := params.query()
+	query.Set("format", api.FormatJSON)
+
+	var record CandlesRecord
+	err := c.do(ctx, request{method: http.MethodGet, path: api.CandlesPath, query: query, idempotent: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CandlesCSV returns the candles of Candles as CSV, ready to be written to a file.
+func (c *Client) CandlesCSV(ctx context.Context, params RangeParams) ([]byte, error) {
+	query := params.query()
+	query.Set("format", api.FormatCSV)
+
+	return c.send(ctx, request{method: http.MethodGet, path: api.CandlesPath, query: query, idempotent: true})
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+func TestClient_Latest(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != handlers.LatestPath {
+			t.Errorf("requested path = %q, want %q", request.URL.Path, handlers.LatestPath)
+		}
+		if query := request.URL.Query().Encode(); query != "base=USD&symbols=EUR%2CGBP" {
+			t.Errorf("requested query = %q", query)
+		}
+		utils.WriteJSON(writer, http.StatusOK, ExchangeRateRecord{Base: "USD", Date: "2025-12-05", Rates: map[string]float64{"EUR": 0.86, "GBP": 0.75}})
+	})
+
+	record, err := client.Latest(context.Background(), "USD", "EUR", "GBP")
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if record.Base != "USD" || record.Rates["GBP"] != 0.75 {
+		t.Errorf("Latest() = %+v", record)
+	}
+}
+
+func TestClient_Historical(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != handlers.HistoricalPath {
+			t.Errorf("requested path = %q, want %q", request.URL.Path, handlers.HistoricalPath)
+		}
+		if query := request.URL.Query().Encode(); query != "base=USD&date=2025-01-05&symbols=EUR" {
+			t.Errorf("requested query = %q", query)
+		}
+		utils.WriteJSON(writer, http.StatusOK, ExchangeRateRecord{Base: "USD", Date: "2025-01-03", Rates: map[string]float64{"EUR": 0.97}})
+	})
+
+	record, err := client.Historical(context.Background(), "2025-01-05", "USD", "EUR")
+	if err != nil {
+		t.Fatalf("Historical() error = %v", err)
+	}
+	if record.Date != "2025-01-03" || record.Rates["EUR"] != 0.97 {
+		t.Errorf("Historical() = %+v", record)
+	}
+}
+
+func TestClient_TimeSeries(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		var graphQLRequest handlers.GraphQLRequest
+		if err := json.NewDecoder(request.Body).Decode(&graphQLRequest); err != nil {
+			t.Fatalf("failed to decode query: %v", err)
+		}
+		if request.URL.Path != handlers.GraphQLPath || graphQLRequest.Variables["start"] != "2025-01-01" {
+			t.Errorf("requested %s with %+v", request.URL.Path, graphQLRequest)
+		}
+
+		if graphQLRequest.Variables["end"] == "2024-12-01" {
+			utils.WriteJSON(writer, http.StatusOK, handlers.GraphQLResponse{Errors: []handlers.GraphQLError{{Message: handlers.ErrInvalidDateRange.Error()}}})
+			return
+		}
+		utils.WriteJSON(writer, http.StatusOK, map[string]any{"data": map[string]any{"timeSeries": []ExchangeRateRecord{
+			{Base: "EUR", Date: "2025-01-02", Rates: map[string]float64{"USD": 1.03}},
+			{Base: "EUR", Date: "2025-01-03", Rates: map[string]float64{"USD": 1.04}},
+		}}})
+	})
+
+	records, err := client.TimeSeries(context.Background(), RangeParams{Start: "2025-01-01", End: "2025-01-03", Symbols: []string{"USD"}})
+	if err != nil {
+		t.Fatalf("TimeSeries() error = %v", err)
+	}
+	if len(records) != 2 || records[1].Rates["USD"] != 1.04 {
+		t.Errorf("TimeSeries() = %+v", records)
+	}
+
+	if _, err := client.TimeSeries(context.Background(), RangeParams{Start: "2025-01-01", End: "2024-12-01"}); err == nil {
+		t.Error("TimeSeries() error = nil, want the error of the query")
+	}
+}
+
+func TestClient_Symbols(t *testing.T) {
+	client := newTestClient(t, respond(t, handlers.SymbolsPath, http.StatusOK, SymbolsRecord{Date: "2025-12-05", Symbols: []string{"EUR", "USD"}}))
+
+	record, err := client.Symbols(context.Background(), "code")
+	if err != nil {
+		t.Fatalf("Symbols() error = %v", err)
+	}
+	if len(record.Symbols) != 2 {
+		t.Errorf("Symbols() = %+v", record)
+	}
+}
+
+func TestClient_Batch(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		var queries []BatchQuery
+		if err := json.NewDecoder(request.Body).Decode(&queries); err != nil {
+			t.Fatalf("failed to decode queries: %v", err)
+		}
+		if request.Method != http.MethodPost || len(queries) != 2 {
+			t.Errorf("requested %s with %+v", request.Method, queries)
+		}
+
+		utils.WriteJSON(writer, http.StatusOK, BatchRecord{Results: []BatchResult{
+			{Query: queries[0], Data: &ExchangeRateRecord{Base: "USD"}},
+			{Query: queries[1], Error: &utils.Error{Message: "Base not found", Status: http.StatusNotFound}},
+		}})
+	})
+
+	record, err := client.Batch(context.Background(), []BatchQuery{{Base: "USD"}, {Base: "XYZ"}})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if len(record.Results) != 2 || record.Results[0].Data == nil || record.Results[1].Error == nil {
+		t.Errorf("Batch() = %+v", record)
+	}
+}
+
+func TestClient_RangeEndpoints(t *testing.T) {
+	params := RangeParams{Start: "2025-01-01", End: "2025-01-31", Base: "EUR", Symbols: []string{"USD"}, Interval: "week"}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		path      string
+		wantQuery string
+		call      func(*Client) (any, error)
+	}{
+		{
+			name:      "fluctuation",
+			path:      handlers.FluctuationPath,
+			wantQuery: "base=EUR&end=2025-01-31&start=2025-01-01&symbols=USD",
+			call:      func(c *Client) (any, error) { return c.Fluctuation(ctx, params) },
+		},
+		{
+			name:      "stats",
+			path:      handlers.StatsPath,
+			wantQuery: "base=EUR&end=2025-01-31&interval=week&start=2025-01-01&symbols=USD",
+			call:      func(c *Client) (any, error) { return c.Stats(ctx, params) },
+		},
+		{
+			name:      "candles",
+			path:      handlers.CandlesPath,
+			wantQuery: "base=EUR&end=2025-01-31&format=json&interval=week&start=2025-01-01&symbols=USD",
+			call:      func(c *Client) (any, error) { return c.Candles(ctx, params) },
+		},
+		{
+			name:      "candles as CSV",
+			path:      handlers.CandlesPath,
+			wantQuery: "base=EUR&end=2025-01-31&format=csv&interval=week&start=2025-01-01&symbols=USD",
+			call:      func(c *Client) (any, error) { return c.CandlesCSV(ctx, params) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+				if request.URL.Path != tt.path {
+					t.Errorf("requested path = %q, want %q", request.URL.Path, tt.path)
+				}
+				if query := request.URL.Query().Encode(); query != tt.wantQuery {
+					t.Errorf("requested query = %q, want %q", query, tt.wantQuery)
+				}
+				utils.WriteJSON(writer, http.StatusOK, map[string]any{})
+			})
+
+			if _, err := tt.call(client); err != nil {
+				t.Errorf("%s error = %v", tt.name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+)
+
+// defaultStreamRetry is the reconnection delay used until the API suggests one.
+const defaultStreamRetry = 5 * time.Second
+
+// StreamParams selects the rates to stream.
+type StreamParams struct {
+	Bases   []string
+	Symbols []string
+	// LastEventID resumes an earlier stream: only rates newer than the ones it lists per base
+	// are received.
+	LastEventID string
+}
+
+// Stream calls fn with the latest rates of the requested bases, first once per base and then
+// whenever newer rates are published. Dropped connections are resumed from the last event
+// received. Stream returns when ctx is done, when fn returns an error or when the API refuses
+// the stream.
+func (c *Client) Stream(ctx context.Context, params StreamParams, fn func(*ExchangeRateRecord) error) error {
+	query := url.Values{}
+	setQuery(query, "base", strings.Join(params.Bases, ","))
+	setQuery(query, "symbols", strings.Join(params.Symbols, ","))
+	target := c.url(api.StreamPath, query)
+
+	stream := &eventStream{lastEventID: params.LastEventID, retry: defaultStreamRetry}
+	for {
+		err := stream.follow(ctx, c.httpClient, target, fn)
+		var apiError *Error
+		var callbackError *streamCallbackError
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.As(err, &callbackError):
+			return callbackError.err
+		case errors.As(err, &apiError):
+			return err
+		}
+
+		timer := time.NewTimer(stream.retry)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// streamCallbackError wraps the errors of the stream callback, which end the stream instead
+// of causing a reconnection.
+type streamCallbackError struct {
+	err error
+}
+
+func (e *streamCallbackError) Error() string {
+	return e.err.Error()
+}
+
+// eventStream keeps the state of a stream across reconnections.
+type eventStream struct {
+	lastEventID string
+	retry       time.Duration
+}
+
+// follow reads events from a connection until it drops.
+func (s *eventStream) follow(ctx context.Context, httpClient *http.Client, target string, fn func(*ExchangeRateRecord) error) error {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Accept", "text/event-stream")
+	if s.lastEventID != "" {
+		httpRequest.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	response, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return decodeError(response.StatusCode, body)
+	}
+
+	var id, event string
+	var data strings.Builder
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if event == "rates" && data.Len() > 0 {
+				var record ExchangeRateRecord
+				if err := json.Unmarshal([]byte(data.String()), &record); err != nil {
+					return err
+				}
+				if err := fn(&record); err != nil {
+					return &streamCallbackError{err: err}
+				}
+				if id != "" {
+					s.lastEventID = id
+				}
+			}
+			id, event = "", ""
+			data.Reset()
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			event = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if milliseconds, err := strconv.Atoi(value); err == nil {
+				s.retry = time.Duration(milliseconds) * time.Millisecond
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
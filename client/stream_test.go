@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/kamaal111/forex-api/utils"
+)
+
+func TestClient_Stream(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("base") != "EUR,USD" {
+			t.Errorf("requested bases = %q", request.URL.Query().Get("base"))
+		}
+
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, request.Header.Get("Last-Event-ID"))
+		connection := len(lastEventIDs)
+		mu.Unlock()
+
+		writer.Header().Set("content-type", "text/event-stream")
+		fmt.Fprint(writer, "retry: 1\n\n: heartbeat\n\n")
+		// Every connection sends one event and drops, so the client has to resume.
+		date := fmt.Sprintf("2025-12-0%d", connection)
+		fmt.Fprintf(writer, "id: EUR:%s\nevent: rates\ndata: {\"base\":\"EUR\",\"date\":%q,\"rates\":{\"USD\":1.1}}\n\n", date, date)
+	})
+
+	stop := errors.New("enough")
+	var dates []string
+	err := client.Stream(context.Background(), StreamParams{Bases: []string{"EUR", "USD"}, LastEventID: "EUR:2025-11-30"}, func(record *ExchangeRateRecord) error {
+		dates = append(dates, record.Date)
+		if len(dates) == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, stop) {
+		t.Fatalf("Stream() error = %v, want the callback error", err)
+	}
+	if len(dates) != 2 || dates[0] != "2025-12-01" || dates[1] != "2025-12-02" {
+		t.Errorf("Stream() received %v", dates)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lastEventIDs) != 2 || lastEventIDs[0] != "EUR:2025-11-30" || lastEventIDs[1] != "EUR:2025-12-01" {
+		t.Errorf("Last-Event-ID headers = %q, want the resumed event ids", lastEventIDs)
+	}
+}
+
+func TestClient_Stream_Refused(t *testing.T) {
+	client := newTestClient(t, func(writer http.ResponseWriter, request *http.Request) {
+		utils.ErrorHandler(writer, "Base not found", http.StatusNotFound)
+	})
+
+	err := client.Stream(context.Background(), StreamParams{Bases: []string{"XYZ"}}, func(*ExchangeRateRecord) error {
+		t.Error("Stream() called the callback for a refused stream")
+		return nil
+	})
+
+	if !IsNotFound(err) {
+		t.Errorf("Stream() error = %v, want a not found error", err)
+	}
+}
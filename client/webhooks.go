@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kamaal111/forex-api/api"
+)
+
+type (
+	Registration   = api.Registration
+	Threshold      = api.Threshold
+	Webhook        = api.Webhook
+	WebhooksRecord = api.WebhooksRecord
+	// Payload is the body of a delivery. Check its signature with api.Sign before
+	// trusting it.
+	Payload = api.Payload
+)
+
+// CreateWebhook registers a webhook for the owner of token. An empty token makes a new owner,
+// whose token the returned webhook holds. The returned webhook also holds the secret its
+// deliveries are signed with. The API never shows the token or the secret again.
+func (c *Client) CreateWebhook(ctx context.Context, token string, registration Registration) (*Webhook, error) {
+	var header http.Header
+	if token != "" {
+		header = bearer(token)
+	}
+
+	var webhook Webhook
+	err := c.do(ctx, request{method: http.MethodPost, path: api.WebhooksPath, header: header, body: registration}, &webhook)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks returns the webhooks of the owner of token, without their secrets.
+func (c *Client) ListWebhooks(ctx context.Context, token string) (*WebhooksRecord, error) {
+	var record WebhooksRecord
+	// Registrations change underneath the cache, so the list is always fetched.
+	err := c.do(ctx, request{method: http.MethodGet, path: api.WebhooksPath, header: bearer(token), idempotent: true, uncached: true}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// DeleteWebhook removes the webhook with id of the owner of token.
+func (c *Client) DeleteWebhook(ctx context.Context, token string, id string) error {
+	path := strings.Replace(api.WebhookPath, "{id}", url.PathEscape(id), 1)
+	return c.do(ctx, request{method: http.MethodDelete, path: path, header: bearer(token), idempotent: true}, nil)
+}
+
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/webhooks"
+)
+
+// newWebhooksClient returns a client for an API serving the webhook endpoints from memory.
+func newWebhooksClient(t *testing.T) *Client {
+	t.Helper()
+
+	dispatcher := webhooks.NewDispatcher(webhooks.NewMemoryStore())
+	dispatcher.LookupIP = func(ctx context.Context, host string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("93.184.215.14")}, nil
+	}
+	api := &webhooks.API{Dispatcher: dispatcher}
+	mux := http.NewServeMux()
+	mux.HandleFunc(handlers.WebhooksPath, api.HandleWebhooks)
+	mux.HandleFunc(handlers.WebhookPath, api.DeleteWebhook)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL, WithRetries(1, 0), WithCache(time.Minute))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func TestClient_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	client := newWebhooksClient(t)
+
+	webhook, err := client.CreateWebhook(ctx, "", Registration{URL: "https://example.com/hook", Base: "usd"})
+	if err != nil {
+		t.Fatalf("CreateWebhook() error = %v", err)
+	}
+	if webhook.ID == "" || webhook.Secret == "" || webhook.Token == "" || webhook.Base != "USD" {
+		t.Errorf("CreateWebhook() = %+v, want a normalized webhook with id, secret and token", webhook)
+	}
+	token := webhook.Token
+
+	kept, err := client.CreateWebhook(ctx, token, Registration{URL: "https://example.com/kept"})
+	if err != nil {
+		t.Fatalf("CreateWebhook() for the same owner error = %v", err)
+	}
+
+	record, err := client.ListWebhooks(ctx, token)
+	if err != nil {
+		t.Fatalf("ListWebhooks() error = %v", err)
+	}
+	if len(record.Webhooks) != 2 || record.Webhooks[0].ID != webhook.ID || record.Webhooks[1].ID != kept.ID {
+		t.Errorf("ListWebhooks() = %+v, want the created webhooks", record)
+	}
+
+	if err := client.DeleteWebhook(ctx, token, webhook.ID); err != nil {
+		t.Fatalf("DeleteWebhook() error = %v", err)
+	}
+	if err := client.DeleteWebhook(ctx, token, webhook.ID); !IsNotFound(err) {
+		t.Errorf("DeleteWebhook() of a deleted webhook error = %v, want a not found error", err)
+	}
+
+	record, err = client.ListWebhooks(ctx, token)
+	if err != nil {
+		t.Fatalf("ListWebhooks() error = %v", err)
+	}
+	if len(record.Webhooks) != 1 || record.Webhooks[0].ID != kept.ID {
+		t.Errorf("ListWebhooks() after deleting = %+v, want the kept webhook", record)
+	}
+
+	if _, err := client.ListWebhooks(ctx, "unknown"); err == nil {
+		t.Error("ListWebhooks() with an unknown token succeeded")
+	}
+}
+
+func TestClient_CreateWebhook_Invalid(t *testing.T) {
+	client := newWebhooksClient(t)
+
+	_, err := client.CreateWebhook(context.Background(), "", Registration{URL: "example.com"})
+
+	apiError, ok := err.(*Error)
+	if !ok || apiError.StatusCode != http.StatusBadRequest {
+		t.Errorf("CreateWebhook() error = %v, want a bad request error", err)
+	}
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kamaal111/forex-api/client"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+const defaultServer = "http://localhost:8000"
+
+// options are the flags every command shares: where to read rates from and how to print them.
+type options struct {
+	server    string
+	data      string
+	firestore bool
+	output    string
+}
+
+func (o *options) register(flags *flag.FlagSet, defaultOutput string) {
+	server := os.Getenv("FOREX_API_URL")
+	if server == "" {
+		server = defaultServer
+	}
+
+	flags.StringVar(&o.server, "server", server, "URL of the Forex API server, defaults to FOREX_API_URL")
+	flags.StringVar(&o.data, "data", "", "read rates offline from a JSON file written by forex export")
+	flags.BoolVar(&o.firestore, "firestore", false, "read rates straight from Firestore, in the GCP_PROJECT_ID project")
+	flags.StringVar(&o.output, "o", defaultOutput, "output format: table, json or csv")
+}
+
+// check validates the flags against the outputs a command supports.
+func (o *options) check(outputs ...string) error {
+	if !slices.Contains(outputs, o.output) {
+		return fmt.Errorf("%w: -o must be one of: %s", errUsage, strings.Join(outputs, ", "))
+	}
+	if o.data != "" && o.firestore {
+		return fmt.Errorf("%w: -data and -firestore can't be combined", errUsage)
+	}
+	return nil
+}
+
+// open returns the source selected by the flags, with a function that releases it.
+func (o *options) open(ctx context.Context) (source, func(), error) {
+	switch {
+	case o.data != "":
+		repository, err := loadFileRepository(o.data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &serviceSource{service: handlers.NewRatesService(repository)}, func() {}, nil
+	case o.firestore:
+		service, closeService, err := handlers.OpenRatesService(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &serviceSource{service: service}, closeService, nil
+	}
+
+	forex, err := client.New(o.server)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return &serverSource{client: forex}, func() {}, nil
+}
+
+func newFlagSet(name string, arguments string, summary string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: forex %s [flags]%s\n\n%s.\n\nflags:\n", name, arguments, summary)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses args and checks that the command got wantArguments positional arguments.
+func parseFlags(flags *flag.FlagSet, args []string, wantArguments int) error {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return errReported
+	}
+	if flags.NArg() != wantArguments {
+		flags.Usage()
+		return errReported
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag into its non-empty items.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func runLatest(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("latest", "", "Show the latest rates, or the rates in effect on a date", stderr)
+	var opts options
+	opts.register(flags, outputTable)
+	base := flags.String("base", "", "base currency (default EUR)")
+	symbols := flags.String("symbols", "", "comma-separated target currencies (default all)")
+	date := flags.String("date", "", "show the rates in effect on a date, as YYYY-MM-DD")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if err := opts.check(outputTable, outputJSON, outputCSV); err != nil {
+		return err
+	}
+
+	rates, closeSource, err := opts.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	var record *handlers.ExchangeRateRecord
+	if *date == "" {
+		record, err = rates.Latest(ctx, *base, splitList(*symbols))
+	} else {
+		record, err = rates.Historical(ctx, *date, *base, splitList(*symbols))
+	}
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errNotFound
+	}
+
+	result := table{header: []string{"DATE", "BASE", "SYMBOL", "RATE"}}
+	for _, symbol := range slices.Sorted(maps.Keys(record.Rates)) {
+		result.rows = append(result.rows, []string{record.Date, record.Base, symbol, formatNumber(record.Rates[symbol])})
+	}
+	return render(stdout, opts.output, record, result)
+}
+
+func runConvert(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("convert", " AMOUNT FROM TO", "Convert an amount between two currencies", stderr)
+	var opts options
+	opts.register(flags, outputTable)
+	date := flags.String("date", "", "convert at the rate in effect on a date, as YYYY-MM-DD")
+	if err := parseFlags(flags, args, 3); err != nil {
+		return err
+	}
+	if err := opts.check(outputTable, outputJSON, outputCSV); err != nil {
+		return err
+	}
+	amount, err := strconv.ParseFloat(flags.Arg(0), 64)
+	if err != nil {
+		return fmt.Errorf("%w: amount %q must be a number", errUsage, flags.Arg(0))
+	}
+
+	rates, closeSource, err := opts.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	record, err := rates.Convert(ctx, flags.Arg(1), flags.Arg(2), amount, *date)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errNotFound
+	}
+
+	result := table{
+		header: []string{"DATE", "FROM", "TO", "AMOUNT", "RATE", "RESULT"},
+		rows: [][]string{{
+			record.Date, record.From, record.To,
+			formatNumber(record.Amount), formatNumber(record.Rate), formatNumber(record.Result),
+		}},
+	}
+	return render(stdout, opts.output, record, result)
+}
+
+func runCurrencies(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("currencies", "", "List the available currencies", stderr)
+	var opts options
+	opts.register(flags, outputTable)
+	locale := flags.String("locale", "", "language of the names, e.g. de or nl-BE (default en)")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if err := opts.check(outputTable, outputJSON, outputCSV); err != nil {
+		return err
+	}
+
+	rates, closeSource, err := opts.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	record, err := rates.Currencies(ctx, *locale)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errNotFound
+	}
+
+	result := table{header: []string{"SYMBOL", "NAME", "SIGN"}}
+	for _, named := range record.Data {
+		result.rows = append(result.rows, []string{named.Symbol, named.Name, named.Sign})
+	}
+	return render(stdout, opts.output, record, result)
+}
+
+// rangeFlags are the flags selecting the rates of a date range.
+type rangeFlags struct {
+	start   *string
+	end     *string
+	base    *string
+	symbols *string
+}
+
+func registerRange(flags *flag.FlagSet, baseUsage string) rangeFlags {
+	return rangeFlags{
+		start:   flags.String("start", "", "first day of the range, as YYYY-MM-DD (required)"),
+		end:     flags.String("end", "", "last day of the range, as YYYY-MM-DD (required)"),
+		base:    flags.String("base", "", baseUsage),
+		symbols: flags.String("symbols", "", "comma-separated target currencies (default all)"),
+	}
+}
+
+func (r rangeFlags) check() error {
+	if *r.start == "" || *r.end == "" {
+		return fmt.Errorf("%w: -start and -end are required", errUsage)
+	}
+	return nil
+}
+
+func runHistory(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("history", "", "Show the daily rates of a date range, one row per day", stderr)
+	var opts options
+	opts.register(flags, outputTable)
+	dateRange := registerRange(flags, "base currency (default EUR)")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if err := opts.check(outputTable, outputJSON, outputCSV); err != nil {
+		return err
+	}
+	if err := dateRange.check(); err != nil {
+		return err
+	}
+
+	rates, closeSource, err := opts.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	records, err := rates.TimeSeries(ctx, *dateRange.start, *dateRange.end, *dateRange.base, splitList(*dateRange.symbols))
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return errNotFound
+	}
+
+	var symbols []string
+	for _, record := range records {
+		for symbol := range record.Rates {
+			if !slices.Contains(symbols, symbol) {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	slices.Sort(symbols)
+
+	result := table{header: append([]string{"DATE"}, symbols...)}
+	for _, record := range records {
+		row := []string{record.Date}
+		for _, symbol := range symbols {
+			rate, ok := record.Rates[symbol]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, formatNumber(rate))
+		}
+		result.rows = append(result.rows, row)
+	}
+	return render(stdout, opts.output, records, result)
+}
+
+func runExport(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("export", "", "Export the daily rates of a date range, as JSON that -data reads back or as CSV", stderr)
+	var opts options
+	opts.register(flags, outputJSON)
+	dateRange := registerRange(flags, "comma-separated base currencies (default EUR)")
+	file := flags.String("file", "", "file to write to instead of the standard output")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if err := opts.check(outputJSON, outputCSV); err != nil {
+		return err
+	}
+	if err := dateRange.check(); err != nil {
+		return err
+	}
+
+	rates, closeSource, err := opts.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	bases := splitList(*dateRange.base)
+	if len(bases) == 0 {
+		bases = []string{""}
+	}
+	records := []handlers.ExchangeRateRecord{}
+	for _, base := range bases {
+		series, err := rates.TimeSeries(ctx, *dateRange.start, *dateRange.end, base, splitList(*dateRange.symbols))
+		if err != nil {
+			return err
+		}
+		for _, record := range series {
+			// Freshness describes the moment of the query, not the rates.
+			record.Freshness = nil
+			records = append(records, record)
+		}
+	}
+	if len(records) == 0 {
+		return errNotFound
+	}
+
+	result := table{header: []string{"date", "base", "symbol", "rate"}}
+	for _, record := range records {
+		for _, symbol := range slices.Sorted(maps.Keys(record.Rates)) {
+			result.rows = append(result.rows, []string{record.Date, record.Base, symbol, formatNumber(record.Rates[symbol])})
+		}
+	}
+
+	if *file == "" {
+		return render(stdout, opts.output, records, result)
+	}
+	output, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	if err := render(output, opts.output, records, result); err != nil {
+		output.Close()
+		return err
+	}
+	return output.Close()
+}
@@ -0,0 +1,121 @@
+// Command forex queries exchange rates from the command line, from a running Forex API server
+// or straight from a rates repository for offline use.
+//
+//	forex latest -base USD -symbols EUR,GBP
+//	forex convert -date 2025-01-03 100 EUR USD
+//	forex export -start 2025-01-01 -end 2025-01-31 -file rates.json
+//	forex history -data rates.json -start 2025-01-01 -end 2025-01-31 -symbols USD -o csv
+//
+// It exits with 0 on success, 1 when a query fails, 2 on invalid usage or input and 3 when
+// there are no rates for the query.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/kamaal111/forex-api/client"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2
+	exitNotFound = 3
+)
+
+var (
+	errUsage    = errors.New("usage")
+	errNotFound = errors.New("no rates found")
+	// errReported is returned for invalid flags, which the flag package has already reported.
+	errReported = errors.New("invalid flags")
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "latest", summary: "Show the latest rates, or the rates of a date", run: runLatest},
+	{name: "convert", summary: "Convert an amount between two currencies", run: runConvert},
+	{name: "currencies", summary: "List the available currencies", run: runCurrencies},
+	{name: "history", summary: "Show the daily rates of a date range", run: runHistory},
+	{name: "export", summary: "Export the daily rates of a date range as JSON or CSV", run: runExport},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+	if name := args[0]; name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	for _, command := range commands {
+		if command.name != args[0] {
+			continue
+		}
+
+		err := command.run(ctx, args[1:], stdout, stderr)
+		if err == nil || errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		if !errors.Is(err, errReported) {
+			fmt.Fprintf(stderr, "forex: %v\n", err)
+		}
+		return exitCode(err)
+	}
+
+	fmt.Fprintf(stderr, "forex: unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return exitUsage
+}
+
+func printUsage(writer io.Writer) {
+	fmt.Fprintln(writer, "usage: forex <command> [flags] [arguments]")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "commands:")
+	for _, command := range commands {
+		fmt.Fprintf(writer, "  %-12s%s\n", command.name, command.summary)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, `Run "forex <command> -h" for the flags of a command.`)
+}
+
+// exitCode tells invalid input and missing rates apart from failures, for scripts.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, errUsage),
+		errors.Is(err, errReported),
+		errors.Is(err, handlers.ErrInvalidDate),
+		errors.Is(err, handlers.ErrInvalidDateRange),
+		errors.Is(err, handlers.ErrUnknownCurrency):
+		return exitUsage
+	case errors.Is(err, errNotFound), client.IsNotFound(err):
+		return exitNotFound
+	}
+
+	var apiError *client.Error
+	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusBadRequest {
+		return exitUsage
+	}
+	return exitFailure
+}
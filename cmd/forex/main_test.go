@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+// writeData writes rates for the -data flag and returns its path.
+func writeData(t *testing.T) string {
+	t.Helper()
+
+	records := []handlers.ExchangeRateRecord{
+		{Base: "EUR", Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03, "GBP": 0.83}},
+		{Base: "EUR", Date: "2025-01-02", Rates: map[string]float64{"USD": 1.02, "GBP": 0.82}},
+		{Base: "USD", Date: "2025-01-03", Rates: map[string]float64{"EUR": 0.97}},
+	}
+	content, err := json.Marshal(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runForex(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Offline(t *testing.T) {
+	data := writeData(t)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "latest as a table",
+			args:       []string{"latest", "-data", data},
+			wantCode:   exitOK,
+			wantStdout: "DATE        BASE  SYMBOL  RATE\n2025-01-03  EUR   GBP     0.83\n2025-01-03  EUR   USD     1.03\n",
+		},
+		{
+			name:       "rates of a date as CSV",
+			args:       []string{"latest", "-data", data, "-date", "2025-01-02", "-symbols", "USD", "-o", "csv"},
+			wantCode:   exitOK,
+			wantStdout: "DATE,BASE,SYMBOL,RATE\n2025-01-02,EUR,USD,1.02\n",
+		},
+		{
+			name:       "convert",
+			args:       []string{"convert", "-data", data, "-o", "csv", "100", "usd", "EUR"},
+			wantCode:   exitOK,
+			wantStdout: "DATE,FROM,TO,AMOUNT,RATE,RESULT\n2025-01-03,USD,EUR,100,0.97,97\n",
+		},
+		{
+			name:       "history",
+			args:       []string{"history", "-data", data, "-start", "2025-01-01", "-end", "2025-01-31"},
+			wantCode:   exitOK,
+			wantStdout: "DATE        GBP   USD\n2025-01-02  0.82  1.02\n2025-01-03  0.83  1.03\n",
+		},
+		{
+			name:       "currencies",
+			args:       []string{"currencies", "-data", data, "-locale", "de", "-o", "csv"},
+			wantCode:   exitOK,
+			wantStdout: "SYMBOL,NAME,SIGN\nEUR,Euro,€\nGBP,Britisches Pfund,£\nUSD,US-Dollar,$\n",
+		},
+		{
+			name:       "base without rates",
+			args:       []string{"latest", "-data", data, "-base", "GBP"},
+			wantCode:   exitNotFound,
+			wantStderr: "forex: no rates found\n",
+		},
+		{
+			name:       "unknown currency",
+			args:       []string{"convert", "-data", data, "1", "EUR", "XYZ"},
+			wantCode:   exitUsage,
+			wantStderr: "forex: " + handlers.ErrUnknownCurrency.Error() + "\n",
+		},
+		{
+			name:       "invalid date",
+			args:       []string{"latest", "-data", data, "-date", "yesterday"},
+			wantCode:   exitUsage,
+			wantStderr: "forex: " + handlers.ErrInvalidDate.Error(),
+		},
+		{
+			name:       "missing range",
+			args:       []string{"history", "-data", data},
+			wantCode:   exitUsage,
+			wantStderr: "forex: usage: -start and -end are required\n",
+		},
+		{
+			name:       "combined sources",
+			args:       []string{"latest", "-data", data, "-firestore"},
+			wantCode:   exitUsage,
+			wantStderr: "forex: usage: -data and -firestore can't be combined\n",
+		},
+		{
+			name:       "missing file",
+			args:       []string{"latest", "-data", filepath.Join(t.TempDir(), "missing.json")},
+			wantCode:   exitFailure,
+			wantStderr: "no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runForex(tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("run() = %d, want %d: %s", code, tt.wantCode, stderr)
+			}
+			if stdout != tt.wantStdout {
+				t.Errorf("run() stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("run() stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRun_ExportRoundTrip(t *testing.T) {
+	exported := filepath.Join(t.TempDir(), "export.json")
+	code, _, stderr := runForex("export", "-data", writeData(t), "-start", "2025-01-01", "-end", "2025-01-31", "-base", "EUR,USD", "-file", exported)
+	if code != exitOK {
+		t.Fatalf("export = %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr := runForex("latest", "-data", exported, "-base", "USD", "-o", "json")
+	if code != exitOK {
+		t.Fatalf("latest = %d: %s", code, stderr)
+	}
+	var record handlers.ExchangeRateRecord
+	if err := json.Unmarshal([]byte(stdout), &record); err != nil {
+		t.Fatalf("failed to decode %q: %v", stdout, err)
+	}
+	if record.Date != "2025-01-03" || record.Rates["EUR"] != 0.97 {
+		t.Errorf("latest of the export = %+v", record)
+	}
+
+	code, _, _ = runForex("export", "-data", exported, "-start", "2025-01-01", "-end", "2025-01-31", "-o", "table")
+	if code != exitUsage {
+		t.Errorf("export as a table = %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRun_Server(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != handlers.LatestPath {
+			t.Errorf("requested path = %q, want %q", request.URL.Path, handlers.LatestPath)
+		}
+		switch request.URL.Query().Get("base") {
+		case "USD":
+			utils.WriteJSON(writer, http.StatusOK, handlers.ExchangeRateRecord{Base: "USD", Date: "2025-01-03", Rates: map[string]float64{"EUR": 0.97}})
+		case "GBP":
+			utils.ErrorHandler(writer, "Base not found", http.StatusNotFound)
+		default:
+			utils.ErrorHandler(writer, "Invalid symbols", http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	code, stdout, stderr := runForex("convert", "-server", server.URL, "-o", "json", "10", "USD", "EUR")
+	if code != exitOK {
+		t.Fatalf("convert = %d: %s", code, stderr)
+	}
+	var record handlers.ConversionRecord
+	if err := json.Unmarshal([]byte(stdout), &record); err != nil {
+		t.Fatalf("failed to decode %q: %v", stdout, err)
+	}
+	if record.Result != 9.7 {
+		t.Errorf("convert = %+v", record)
+	}
+
+	if code, _, _ := runForex("latest", "-server", server.URL, "-base", "GBP"); code != exitNotFound {
+		t.Errorf("latest of a missing base = %d, want %d", code, exitNotFound)
+	}
+	if code, _, _ := runForex("latest", "-server", server.URL, "-base", "EUR"); code != exitUsage {
+		t.Errorf("latest of a bad request = %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "no command", wantCode: exitUsage},
+		{name: "help", args: []string{"help"}, wantCode: exitOK},
+		{name: "unknown command", args: []string{"rates"}, wantCode: exitUsage},
+		{name: "command help", args: []string{"latest", "-h"}, wantCode: exitOK},
+		{name: "unknown flag", args: []string{"latest", "-nope"}, wantCode: exitUsage},
+		{name: "missing arguments", args: []string{"convert", "1", "EUR"}, wantCode: exitUsage},
+		{name: "invalid amount", args: []string{"convert", "ten", "EUR", "USD"}, wantCode: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _, stderr := runForex(tt.args...); code != tt.wantCode {
+				t.Errorf("run() = %d, want %d: %s", code, tt.wantCode, stderr)
+			}
+		})
+	}
+}
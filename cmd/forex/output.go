@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+// table is the tabular form of a command result, for the table and CSV outputs.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// render writes value as indented JSON, or its table as aligned columns or CSV.
+func render(writer io.Writer, output string, value any, result table) error {
+	switch output {
+	case outputJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case outputCSV:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Write(result.header)
+		csvWriter.WriteAll(result.rows)
+		return csvWriter.Error()
+	}
+
+	tableWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tableWriter, strings.Join(result.header, "\t"))
+	for _, row := range result.rows {
+		fmt.Fprintln(tableWriter, strings.Join(row, "\t"))
+	}
+	return tableWriter.Flush()
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kamaal111/forex-api/client"
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/locales"
+)
+
+// source is where the commands read rates from: a running server, or a rates repository for
+// offline use. Methods return nil records when there are no rates.
+type source interface {
+	Latest(ctx context.Context, base string, symbols []string) (*handlers.ExchangeRateRecord, error)
+	Historical(ctx context.Context, date string, base string, symbols []string) (*handlers.ExchangeRateRecord, error)
+	TimeSeries(ctx context.Context, start string, end string, base string, symbols []string) ([]handlers.ExchangeRateRecord, error)
+	Convert(ctx context.Context, from string, to string, amount float64, date string) (*handlers.ConversionRecord, error)
+	Currencies(ctx context.Context, locale string) (*handlers.CurrenciesRecord, error)
+}
+
+// serverSource reads from the API of a running server.
+type serverSource struct {
+	client *client.Client
+}
+
+// notFoundAsNil turns the not found errors of the API into nil records, like the repository
+// sources return.
+func notFoundAsNil[T any](record *T, err error) (*T, error) {
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+	return record, err
+}
+
+func (s *serverSource) Latest(ctx context.Context, base string, symbols []string) (*handlers.ExchangeRateRecord, error) {
+	return notFoundAsNil(s.client.Latest(ctx, base, symbols...))
+}
+
+func (s *serverSource) Historical(ctx context.Context, date string, base string, symbols []string) (*handlers.ExchangeRateRecord, error) {
+	return notFoundAsNil(s.client.Historical(ctx, date, base, symbols...))
+}
+
+func (s *serverSource) TimeSeries(ctx context.Context, start string, end string, base string, symbols []string) ([]handlers.ExchangeRateRecord, error) {
+	return s.client.TimeSeries(ctx, client.RangeParams{Start: start, End: end, Base: base, Symbols: symbols})
+}
+
+// Convert converts with the rates of the API, the way handlers.RatesService.Convert does. The
+// currencies are checked first, since the API falls back to the default base for unknown ones.
+func (s *serverSource) Convert(ctx context.Context, from string, to string, amount float64, date string) (*handlers.ConversionRecord, error) {
+	from = strings.ToUpper(strings.TrimSpace(from))
+	to = strings.ToUpper(strings.TrimSpace(to))
+	if !handlers.Registry.Contains(from) || !handlers.Registry.Contains(to) {
+		return nil, handlers.ErrUnknownCurrency
+	}
+
+	var record *handlers.ExchangeRateRecord
+	var err error
+	if date == "" {
+		record, err = s.Latest(ctx, from, []string{to})
+	} else {
+		record, err = s.Historical(ctx, date, from, []string{to})
+	}
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	rate, ok := record.Rates[to]
+	if from == to {
+		rate, ok = 1, true
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &handlers.ConversionRecord{From: from, To: to, Amount: amount, Rate: rate, Result: amount * rate, Date: record.Date}, nil
+}
+
+func (s *serverSource) Currencies(ctx context.Context, locale string) (*handlers.CurrenciesRecord, error) {
+	return notFoundAsNil(s.client.Currencies(ctx, "", locale))
+}
+
+// serviceSource reads straight from a rates repository, through the service the server uses.
+type serviceSource struct {
+	service *handlers.RatesService
+}
+
+func (s *serviceSource) Latest(ctx context.Context, base string, symbols []string) (*handlers.ExchangeRateRecord, error) {
+	return s.service.GetLatestRate(base, strings.Join(symbols, ","))
+}
+
+func (s *serviceSource) Historical(ctx context.Context, date string, base string, symbols []string) (*handlers.ExchangeRateRecord, error) {
+	return s.service.GetRateOnDate(base, strings.Join(symbols, ","), date)
+}
+
+func (s *serviceSource) TimeSeries(ctx context.Context, start string, end string, base string, symbols []string) ([]handlers.ExchangeRateRecord, error) {
+	return s.service.GetTimeSeries(base, strings.Join(symbols, ","), start, end)
+}
+
+func (s *serviceSource) Convert(ctx context.Context, from string, to string, amount float64, date string) (*handlers.ConversionRecord, error) {
+	return s.service.Convert(from, to, amount, date)
+}
+
+func (s *serviceSource) Currencies(ctx context.Context, locale string) (*handlers.CurrenciesRecord, error) {
+	return s.service.GetLocalizedNamedSymbols(locales.Negotiate(locale, ""))
+}
+
+// fileRepository serves the records of a JSON file written by the export command, so rates can
+// be queried without a server or a database.
+type fileRepository struct {
+	// records are sorted by base, then date.
+	records []handlers.ExchangeRateRecord
+}
+
+func loadFileRepository(path string) (*fileRepository, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []handlers.ExchangeRateRecord
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("%s must hold a JSON array of rates, as written by forex export: %w", path, err)
+	}
+	slices.SortFunc(records, func(a, b handlers.ExchangeRateRecord) int {
+		return cmp.Or(cmp.Compare(a.Base, b.Base), cmp.Compare(a.Date, b.Date))
+	})
+	return &fileRepository{records: records}, nil
+}
+
+func (r *fileRepository) GetLatestRate(base string) (*handlers.ExchangeRateRecord, error) {
+	return r.GetRateOnDate(base, "9999-12-31")
+}
+
+func (r *fileRepository) GetRateOnDate(base string, date string) (*handlers.ExchangeRateRecord, error) {
+	var found *handlers.ExchangeRateRecord
+	for i, record := range r.records {
+		if record.Base == base && record.Date <= date {
+			found = &r.records[i]
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	record := *found
+	return &record, nil
+}
+
+func (r *fileRepository) GetRatesBetween(base string, start string, end string) ([]handlers.ExchangeRateRecord, error) {
+	var records []handlers.ExchangeRateRecord
+	for _, record := range r.records {
+		if record.Base == base && record.Date >= start && record.Date <= end {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// GetAllSymbols returns every currency the file has rates of or for.
+func (r *fileRepository) GetAllSymbols() (*handlers.SymbolsRecord, error) {
+	if len(r.records) == 0 {
+		return nil, nil
+	}
+
+	symbols := &handlers.SymbolsRecord{}
+	for _, record := range r.records {
+		symbols.Date = max(symbols.Date, record.Date)
+		symbols.Symbols = append(symbols.Symbols, record.Base)
+		for symbol := range record.Rates {
+			symbols.Symbols = append(symbols.Symbols, symbol)
+		}
+	}
+	slices.Sort(symbols.Symbols)
+	symbols.Symbols = slices.Compact(symbols.Symbols)
+	return symbols, nil
+}
@@ -0,0 +1,339 @@
+// Package config loads the configuration of the server once at startup. Every setting can come
+// from a YAML or TOML file, the environment or a flag, in increasing order of precedence:
+//
+//	max_data_age: 36h        # in the file
+//	MAX_DATA_AGE=36h         # in the environment
+//	-max-data-age 36h        # as a flag
+//
+// Secret settings have no flag, as command lines show up in process listings. They can only
+// come from the file or the environment.
+//
+// Load reports every invalid setting at once, so a misconfigured deployment can be fixed in
+// one go.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the settings of the server. The config tag names a setting in files, and gives
+// its environment variable in upper case and its flag with dashes. The secret tag redacts a
+// setting when printed and leaves it without a flag.
+type Config struct {
+	ServerAddress string `config:"server_address" usage:"address to serve HTTP on, e.g. 127.0.0.1:8000"`
+	Port          string `config:"port" usage:"port to serve HTTP on, on every interface, when server_address is empty"`
+	GRPCAddress   string `config:"grpc_address" usage:"address to serve the gRPC API on, which is off when empty"`
+	GCPProjectID  string `config:"gcp_project_id" usage:"Google Cloud project with the Firestore database"`
+
+	TLSCertFile       string        `config:"tls_cert_file" usage:"PEM certificate chain to serve HTTPS and gRPC with, reloaded when it changes"`
+	TLSKeyFile        string        `config:"tls_key_file" usage:"PEM private key of tls_cert_file"`
+	TLSClientCAFile   string        `config:"tls_client_ca_file" usage:"PEM certificates of the CAs that must sign client certificates, which turns on mutual TLS"`
+	TLSReloadInterval time.Duration `config:"tls_reload_interval" usage:"how often to check the certificate files for a rotated certificate"`
+	H2C               bool          `config:"h2c" usage:"serve HTTP/2 without TLS next to HTTP/1.1, for internal traffic"`
+
+	MaxDataAge       time.Duration `config:"max_data_age" usage:"how long the latest rates may be overdue before the readiness probe fails"`
+	RateFeedInterval time.Duration `config:"rate_feed_interval" usage:"how often to check for new rates while streams or webhooks are active"`
+
+	GraphQLMaxDepth      int `config:"graphql_max_depth" usage:"deepest selection nesting accepted by /graphql"`
+	GraphQLMaxComplexity int `config:"graphql_max_complexity" usage:"highest estimated cost of a query accepted by /graphql"`
+
+	OpenAPIServers []string `config:"openapi_servers" usage:"comma-separated server URLs listed in the OpenAPI 3.1 spec"`
+
+	CurrencyRegistryFile           string        `config:"currency_registry_file" usage:"JSON file with currency definitions that override or extend the embedded registry"`
+	CurrencyRegistryCollection     string        `config:"currency_registry_collection" usage:"Firestore collection with currency definitions that override or extend the registry"`
+	CurrencyRegistryReloadInterval time.Duration `config:"currency_registry_reload_interval" usage:"how often to reload the currency registry, never when zero"`
+
+	WebhooksCollection string `config:"webhooks_collection" usage:"Firestore collection holding webhook subscriptions"`
+
+	AdminTokens          []string `config:"admin_tokens" secret:"true" usage:"comma-separated name:token pairs of the admins allowed to correct rates, the admin API is off when empty"`
+	AdminAuditCollection string   `config:"admin_audit_collection" usage:"Firestore collection holding the audit log of rate corrections"`
+
+	ValidateRequests  bool `config:"validate_requests" usage:"reject requests that don't match the OpenAPI spec"`
+	ValidateResponses bool `config:"validate_responses" usage:"replace responses that don't match the OpenAPI spec with a 500"`
+
+	V1Deprecation     time.Time `config:"v1_deprecation" usage:"when v1 was or will be deprecated, as YYYY-MM-DD or an RFC 3339 timestamp"`
+	V1Sunset          time.Time `config:"v1_sunset" usage:"when v1 is expected to stop being served, as YYYY-MM-DD or an RFC 3339 timestamp"`
+	V1DeprecationLink string    `config:"v1_deprecation_link" usage:"URL documenting the v1 deprecation"`
+}
+
+// Default returns the settings used when nothing else is configured.
+func Default() *Config {
+	return &Config{
+		TLSReloadInterval:    time.Minute,
+		MaxDataAge:           24 * time.Hour,
+		RateFeedInterval:     time.Minute,
+		GraphQLMaxDepth:      6,
+		GraphQLMaxComplexity: 1000,
+		WebhooksCollection:   "webhooks",
+		AdminAuditCollection: "admin_audit",
+	}
+}
+
+// Address is the address to serve HTTP on.
+func (c *Config) Address() string {
+	if c.ServerAddress != "" {
+		return c.ServerAddress
+	}
+	return ":" + c.Port
+}
+
+// Validate reports every setting that is missing or out of range.
+func (c *Config) Validate() error {
+	var problems []error
+	if c.ServerAddress == "" && c.Port == "" {
+		problems = append(problems, errors.New("server_address or port is required"))
+	}
+	if c.GCPProjectID == "" {
+		problems = append(problems, errors.New("gcp_project_id is required"))
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		problems = append(problems, errors.New("tls_cert_file and tls_key_file must be set together"))
+	}
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		problems = append(problems, errors.New("tls_client_ca_file requires tls_cert_file"))
+	}
+	if c.TLSReloadInterval <= 0 {
+		problems = append(problems, fmt.Errorf("tls_reload_interval must be positive, got %s", c.TLSReloadInterval))
+	}
+	if c.H2C && c.TLSCertFile != "" {
+		problems = append(problems, errors.New("h2c can't be combined with tls_cert_file, which serves HTTP/2 over TLS"))
+	}
+	if c.MaxDataAge <= 0 {
+		problems = append(problems, fmt.Errorf("max_data_age must be positive, got %s", c.MaxDataAge))
+	}
+	if c.RateFeedInterval <= 0 {
+		problems = append(problems, fmt.Errorf("rate_feed_interval must be positive, got %s", c.RateFeedInterval))
+	}
+	if c.GraphQLMaxDepth <= 0 {
+		problems = append(problems, fmt.Errorf("graphql_max_depth must be positive, got %d", c.GraphQLMaxDepth))
+	}
+	if c.GraphQLMaxComplexity <= 0 {
+		problems = append(problems, fmt.Errorf("graphql_max_complexity must be positive, got %d", c.GraphQLMaxComplexity))
+	}
+	if c.CurrencyRegistryReloadInterval < 0 {
+		problems = append(problems, fmt.Errorf("currency_registry_reload_interval can't be negative, got %s", c.CurrencyRegistryReloadInterval))
+	}
+	if c.WebhooksCollection == "" {
+		problems = append(problems, errors.New("webhooks_collection can't be empty"))
+	}
+	admins := map[string]bool{}
+	for i, credential := range c.AdminTokens {
+		name, token, _ := strings.Cut(credential, ":")
+		switch {
+		case name == "" || token == "":
+			// The credential holds a token, so only its position is reported.
+			problems = append(problems, fmt.Errorf("admin_tokens must hold name:token pairs, entry %d doesn't", i+1))
+		case len(token) < minAdminTokenLength:
+			problems = append(problems, fmt.Errorf("admin_tokens: the token of %s must be at least %d characters", name, minAdminTokenLength))
+		case admins[name]:
+			problems = append(problems, fmt.Errorf("admin_tokens: %s is listed more than once", name))
+		}
+		admins[name] = true
+	}
+	if len(c.AdminTokens) > 0 && c.AdminAuditCollection == "" {
+		problems = append(problems, errors.New("admin_audit_collection can't be empty when admin_tokens are set"))
+	}
+	if !c.V1Sunset.IsZero() && c.V1Sunset.Before(c.V1Deprecation) {
+		problems = append(problems, fmt.Errorf("v1_sunset %s is before v1_deprecation %s", c.V1Sunset.Format(time.RFC3339), c.V1Deprecation.Format(time.RFC3339)))
+	}
+	for _, server := range c.OpenAPIServers {
+		if parsed, err := url.Parse(server); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			problems = append(problems, fmt.Errorf("openapi_servers must hold absolute URLs, got %q", server))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// minAdminTokenLength keeps admin tokens long enough that they can't be guessed.
+const minAdminTokenLength = 16
+
+// Print writes the settings in the format of a YAML configuration file. Secrets and the
+// passwords of URLs are redacted, so the output can be shared.
+func (c *Config) Print(writer io.Writer) error {
+	for _, field := range fields(c) {
+		value := field.format()
+		if field.secret && value != "" {
+			value = redacted
+		}
+		if _, err := fmt.Fprintf(writer, "%s: %s\n", field.key, strconv.Quote(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const redacted = "REDACTED"
+
+// field is a setting of a Config, found through its config tag.
+type field struct {
+	key    string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+func fields(c *Config) []field {
+	value := reflect.ValueOf(c).Elem()
+	var found []field
+	for i := range value.NumField() {
+		structField := value.Type().Field(i)
+		key := structField.Tag.Get("config")
+		if key == "" {
+			continue
+		}
+		found = append(found, field{
+			key:    key,
+			usage:  structField.Tag.Get("usage"),
+			secret: structField.Tag.Get("secret") == "true",
+			value:  value.Field(i),
+		})
+	}
+	return found
+}
+
+func (f field) environmentKey() string {
+	return strings.ToUpper(f.key)
+}
+
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.key, "_", "-")
+}
+
+// set parses raw into the setting, with the syntax of its environment variable.
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch target := f.value.Addr().Interface().(type) {
+	case *string:
+		*target = raw
+	case *bool:
+		if raw == "" {
+			*target = false
+			return nil
+		}
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*target = value
+	case *int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		*target = value
+	case *time.Duration:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return errors.New("must be a duration such as 90s, 30m or 36h")
+		}
+		*target = value
+	case *time.Time:
+		value, err := parseTime(raw)
+		if err != nil {
+			return err
+		}
+		*target = value
+	case *[]string:
+		*target = nil
+		for item := range strings.SplitSeq(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*target = append(*target, item)
+			}
+		}
+	default:
+		panic(fmt.Sprintf("config: unsupported type %s of %s", f.value.Type(), f.key))
+	}
+	return nil
+}
+
+// format returns the setting with the syntax set parses.
+func (f field) format() string {
+	switch value := f.value.Interface().(type) {
+	case string:
+		return redactURL(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case int:
+		return strconv.Itoa(value)
+	case time.Duration:
+		return value.String()
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format(time.RFC3339)
+	case []string:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = redactURL(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(f.value.Interface())
+}
+
+// parseTime parses a date (YYYY-MM-DD, midnight UTC) or RFC 3339 timestamp. An empty value is
+// the zero time.
+func parseTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if value, err := time.Parse(layout, raw); err == nil {
+			return value, nil
+		}
+	}
+	return time.Time{}, errors.New("must be YYYY-MM-DD or an RFC 3339 timestamp")
+}
+
+// redactURL hides the password of a URL with credentials, and leaves any other value alone.
+func redactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.User == nil {
+		return value
+	}
+	if _, ok := parsed.User.Password(); !ok {
+		return value
+	}
+	parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+	return parsed.String()
+}
+
+// settingFlag is the flag of a setting. It only records its raw value, which Load applies
+// after the file and the environment.
+type settingFlag struct {
+	field field
+	raw   string
+}
+
+func (f *settingFlag) String() string {
+	return f.raw
+}
+
+func (f *settingFlag) Set(raw string) error {
+	f.raw = raw
+	return nil
+}
+
+// IsBoolFlag lets boolean settings be enabled without a value, like -validate-requests.
+func (f *settingFlag) IsBoolFlag() bool {
+	return f.field.value.Kind() == reflect.Bool
+}
+
+// registerFlags adds a flag per setting of c to flags, except for secrets.
+func registerFlags(flags *flag.FlagSet, c *Config) {
+	for _, field := range fields(c) {
+		if field.secret {
+			continue
+		}
+		flags.Var(&settingFlag{field: field}, field.flagName(), fmt.Sprintf("%s (env %s)", field.usage, field.environmentKey()))
+	}
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/oasdiff/yaml"
+)
+
+// Options are the flags that control loading rather than the server.
+type Options struct {
+	// File is the YAML or TOML configuration file, from -config or CONFIG_FILE.
+	File string
+	// PrintConfig asks to print the configuration instead of serving, from -print-config.
+	PrintConfig bool
+}
+
+// Load reads the configuration from the defaults, the configuration file, the environment
+// through lookupEnv and the flags in args, each overriding the ones before. Empty environment
+// variables count as unset. It returns flag.ErrHelp when args ask for help, and otherwise every
+// problem it found joined into one error.
+func Load(args []string, lookupEnv func(key string) (string, bool)) (*Config, *Options, error) {
+	config := Default()
+	options := &Options{}
+
+	flags := flag.NewFlagSet("forex-api", flag.ContinueOnError)
+	flags.StringVar(&options.File, "config", "", "YAML or TOML configuration file (env CONFIG_FILE)")
+	flags.BoolVar(&options.PrintConfig, "print-config", false, "print the configuration, with secrets redacted, and exit")
+	registerFlags(flags, config)
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	if options.File == "" {
+		options.File, _ = lookupEnv("CONFIG_FILE")
+	}
+
+	settings := map[string]field{}
+	for _, field := range fields(config) {
+		settings[field.key] = field
+	}
+
+	var problems []error
+	if options.File != "" {
+		values, err := readFile(options.File)
+		if err != nil {
+			problems = append(problems, err)
+		}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			field, ok := settings[key]
+			if !ok {
+				problems = append(problems, fmt.Errorf("%s: unknown setting %q", options.File, key))
+				continue
+			}
+			raw, err := fileValue(values[key])
+			if err == nil {
+				err = field.set(raw)
+			}
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: %s %v", options.File, key, err))
+			}
+		}
+	}
+
+	for _, field := range fields(config) {
+		raw, ok := lookupEnv(field.environmentKey())
+		if !ok || raw == "" {
+			continue
+		}
+		if err := field.set(raw); err != nil {
+			problems = append(problems, fmt.Errorf("%s %v, got %q", field.environmentKey(), err, raw))
+		}
+	}
+
+	flags.Visit(func(flag *flag.Flag) {
+		value, ok := flag.Value.(*settingFlag)
+		if !ok {
+			return
+		}
+		if err := value.field.set(value.raw); err != nil {
+			problems = append(problems, fmt.Errorf("-%s %v, got %q", flag.Name, err, value.raw))
+		}
+	})
+
+	problems = append(problems, config.Validate())
+	if err := errors.Join(problems...); err != nil {
+		return nil, nil, err
+	}
+	return config, options, nil
+}
+
+// readFile decodes a configuration file, as YAML or TOML depending on its extension.
+func readFile(path string) (map[string]any, error) {
+	extension := strings.ToLower(filepath.Ext(path))
+	if extension != ".yaml" && extension != ".yml" && extension != ".toml" {
+		return nil, fmt.Errorf("%s: configuration files must be .yaml, .yml or .toml", path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	if extension == ".toml" {
+		_, err = toml.Decode(string(content), &values)
+	} else {
+		content, err = yaml.YAMLToJSON(content)
+		if err == nil && strings.TrimSpace(string(content)) != "null" {
+			err = json.Unmarshal(content, &values)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// fileValue turns a value decoded from a file into the syntax of environment variables, so
+// every source goes through the same parsing.
+func fileValue(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case time.Time:
+		return value.Format(time.RFC3339), nil
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			raw, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = raw
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", errors.New("must be a single value or a list")
+}
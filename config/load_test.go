@@ -0,0 +1,178 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// environment returns a lookup over values, standing in for os.LookupEnv.
+func environment(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+var requiredEnvironment = map[string]string{"PORT": "8000", "GCP_PROJECT_ID": "forex"}
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, options, err := Load(nil, environment(requiredEnvironment))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Address() != ":8000" || cfg.MaxDataAge != 24*time.Hour || cfg.WebhooksCollection != "webhooks" || cfg.GraphQLMaxDepth != 6 {
+		t.Errorf("Load() = %+v, want the defaults", cfg)
+	}
+	if options.File != "" || options.PrintConfig {
+		t.Errorf("Load() options = %+v, want none", options)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	file := writeFile(t, "forex.yaml", `
+server_address: 127.0.0.1:7000
+gcp_project_id: from-file
+max_data_age: 12h
+graphql_max_depth: 4
+openapi_servers:
+  - https://a.example.com
+  - https://b.example.com
+validate_requests: true
+v1_deprecation: "2026-01-01"
+`)
+
+	cfg, options, err := Load(
+		[]string{"-config", file, "-max-data-age", "48h", "-validate-responses", "-print-config"},
+		environment(map[string]string{"GCP_PROJECT_ID": "from-env", "MAX_DATA_AGE": "36h", "GRPC_ADDRESS": ""}),
+	)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.ServerAddress != "127.0.0.1:7000" || cfg.GraphQLMaxDepth != 4 || !cfg.ValidateRequests || !cfg.ValidateResponses {
+		t.Errorf("Load() = %+v, want the settings of the file", cfg)
+	}
+	if len(cfg.OpenAPIServers) != 2 || cfg.OpenAPIServers[1] != "https://b.example.com" {
+		t.Errorf("Load() openapi servers = %v", cfg.OpenAPIServers)
+	}
+	if !cfg.V1Deprecation.Equal(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Load() v1 deprecation = %s", cfg.V1Deprecation)
+	}
+	if cfg.GCPProjectID != "from-env" {
+		t.Errorf("Load() project = %q, want the environment to override the file", cfg.GCPProjectID)
+	}
+	if cfg.MaxDataAge != 48*time.Hour {
+		t.Errorf("Load() max data age = %s, want the flag to override the environment", cfg.MaxDataAge)
+	}
+	if cfg.GRPCAddress != "" {
+		t.Errorf("Load() gRPC address = %q, want empty variables to count as unset", cfg.GRPCAddress)
+	}
+	if options.File != file || !options.PrintConfig {
+		t.Errorf("Load() options = %+v", options)
+	}
+}
+
+func TestLoad_TOML(t *testing.T) {
+	file := writeFile(t, "forex.toml", `
+port = "9000"
+gcp_project_id = "forex"
+rate_feed_interval = "30s"
+graphql_max_complexity = 500
+openapi_servers = ["https://forex.example.com"]
+`)
+
+	cfg, _, err := Load(nil, environment(map[string]string{"CONFIG_FILE": file}))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Address() != ":9000" || cfg.RateFeedInterval != 30*time.Second || cfg.GraphQLMaxComplexity != 500 || len(cfg.OpenAPIServers) != 1 {
+		t.Errorf("Load() = %+v, want the settings of the file", cfg)
+	}
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	file := writeFile(t, "forex.yaml", "port: 8000\ngcp_project: forex\n")
+
+	_, _, err := Load(
+		[]string{"-config", file, "-graphql-max-depth", "deep"},
+		environment(map[string]string{"MAX_DATA_AGE": "a day", "VALIDATE_REQUESTS": "yes"}),
+	)
+	if err == nil {
+		t.Fatal("Load() error = nil, want the problems of the configuration")
+	}
+
+	for _, want := range []string{
+		`unknown setting "gcp_project"`,
+		`MAX_DATA_AGE must be a duration such as 90s, 30m or 36h, got "a day"`,
+		`VALIDATE_REQUESTS must be true or false, got "yes"`,
+		`-graphql-max-depth must be a whole number, got "deep"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Load() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "missing settings", env: map[string]string{}, wantErr: "server_address or port is required\ngcp_project_id is required"},
+		{name: "unknown file format", args: []string{"-config", "forex.json"}, env: requiredEnvironment, wantErr: "configuration files must be .yaml, .yml or .toml"},
+		{name: "missing file", args: []string{"-config", "missing.yaml"}, env: requiredEnvironment, wantErr: "no such file or directory"},
+		{name: "unexpected argument", args: []string{"serve"}, env: requiredEnvironment, wantErr: "unexpected arguments: serve"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Load(tt.args, environment(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_SecretsHaveNoFlags(t *testing.T) {
+	if _, _, err := Load([]string{"-admin-tokens", "alice:0123456789abcdef"}, environment(requiredEnvironment)); err == nil || !strings.Contains(err.Error(), "flag provided but not defined") {
+		t.Errorf("Load() error = %v, want the secret flag to be undefined", err)
+	}
+
+	values := map[string]string{"ADMIN_TOKENS": "alice:0123456789abcdef"}
+	for key, value := range requiredEnvironment {
+		values[key] = value
+	}
+	cfg, _, err := Load(nil, environment(values))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.AdminTokens) != 1 || cfg.AdminTokens[0] != "alice:0123456789abcdef" {
+		t.Errorf("Load() admin tokens = %v, want them from the environment", cfg.AdminTokens)
+	}
+}
+
+func TestLoad_Help(t *testing.T) {
+	_, _, err := Load([]string{"-h"}, environment(nil))
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("Load() error = %v, want %v", err, flag.ErrHelp)
+	}
+}
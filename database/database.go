@@ -8,7 +8,18 @@ import (
 	"github.com/kamaal111/forex-api/utils"
 )
 
+// ProjectID is the Google Cloud project holding the database. The server sets it from its
+// configuration; when it's empty, GCP_PROJECT_ID is used.
+var ProjectID string
+
 func CreateClient(ctx context.Context) (*firestore.Client, error) {
-	projectID := utils.UnwrapEnvironment("GCP_PROJECT_ID")
+	projectID := ProjectID
+	if projectID == "" {
+		var err error
+		projectID, err = utils.UnwrapEnvironment("GCP_PROJECT_ID")
+		if err != nil {
+			return nil, err
+		}
+	}
 	return firestore.NewClient(ctx, projectID)
 }
@@ -15,6 +15,123 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/graphql": {
+            "post": {
+                "description": "Runs a query against the GraphQL schema, which exposes the latest and historical rates, time series and currencies.\nQueries are also accepted with GET, in the query, operationName and variables query parameters.\nQueries nested too deeply or estimated too expensive are rejected with 400, and the repository calls of a query run in one batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL query",
+                "parameters": [
+                    {
+                        "description": "GraphQL query",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphQLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphQLResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphQLResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphQLResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Reports that the process is up. Does not touch the database.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.HealthRecord"
+                        }
+                    }
+                }
+            }
+        },
+        "/openapi.json": {
+            "get": {
+                "description": "Returns the OpenAPI 3.1 specification for this API, in JSON by default or in YAML when requested through the format parameter or an Accept header of application/yaml.\nIts servers are the configured server URLs, or the host the specification was requested from.",
+                "produces": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "openapi"
+                ],
+                "summary": "Download OpenAPI 3.1 spec",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "yaml"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OpenAPI 3.1 spec",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/openapi.yaml": {
             "get": {
                 "description": "Returns the OpenAPI specification for this API in YAML format.",
@@ -35,141 +152,1668 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/currencies": {
+        "/readyz": {
             "get": {
-                "description": "Returns all available currencies with their human-readable names and currency signs.",
+                "description": "Reports whether the database is reachable and the latest rates are not overdue according to the publication calendar.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "currencies"
+                    "health"
                 ],
-                "summary": "Get currencies with names and signs",
+                "summary": "Readiness probe",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CurrenciesRecord"
+                            "$ref": "#/definitions/api.ReadinessRecord"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReadinessRecord"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists the latest corrections, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the audit log",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of entries to return (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/admin.AuditRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
                         "schema": {
-                            "$ref": "#/definitions/utils.Error"
+                            "$ref": "#/definitions/api.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/utils.Error"
+                            "$ref": "#/definitions/api.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/rates/latest": {
-            "get": {
-                "description": "Get the latest currency exchange rates, optionally filtered by base currency and target symbols.",
+        "/v1/admin/rates/{base}/{date}": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Creates or replaces the rates of a base on a date, and records the change with the previous rates in the audit log.\nEvery symbol must be a supported currency other than the base, with a positive rate.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "rates"
+                    "admin"
                 ],
-                "summary": "Get latest exchange rates",
+                "summary": "Correct the rates of a date",
                 "parameters": [
                     {
+                        "maxLength": 3,
+                        "minLength": 3,
                         "type": "string",
-                        "description": "Base currency code (default: EUR)",
+                        "description": "Base currency code",
                         "name": "base",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of target currency symbols",
-                        "name": "symbols",
-                        "in": "query"
+                        "format": "date",
+                        "description": "Publication date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Corrected rates and the reason for the correction",
+                        "name": "correction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.RatesCorrection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/admin.AuditEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Removes the rates of a base on a date, and records them in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete the rates of a date",
+                "parameters": [
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code",
+                        "name": "base",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Publication date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the deletion",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.Reason"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExchangeRateRecord"
+                            "$ref": "#/definitions/admin.AuditEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/utils.Error"
+                            "$ref": "#/definitions/api.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/utils.Error"
+                            "$ref": "#/definitions/api.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/rates/symbols": {
-            "get": {
-                "description": "Returns a list of all available currency symbols.",
+        "/v1/admin/symbols/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Rebuilds the symbols document of the latest date with rates from every base and symbol with rates on that date, and records the change in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "rates"
+                    "admin"
+                ],
+                "summary": "Regenerate the symbols",
+                "parameters": [
+                    {
+                        "description": "Reason for the regeneration",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.Reason"
+                        }
+                    }
                 ],
-                "summary": "Get available currency symbols",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SymbolsRecord"
+                            "$ref": "#/definitions/admin.AuditEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/utils.Error"
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/utils.Error"
+                            "$ref": "#/definitions/api.Error"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.CurrenciesRecord": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.NamedSymbol"
+        },
+        "/v1/currencies": {
+            "get": {
+                "description": "Returns all available currencies with their human-readable names and currency signs.\nNames and sign placement are localized, falling back from the region to the language and finally to English.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Get currencies with names and signs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "code",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Order of the currencies, by code or localized name (default: stored order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale of the currency names, e.g. de or nl-BE (overrides Accept-Language)",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the currency names",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CurrenciesRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
                     }
-                },
-                "date": {
-                    "type": "string"
                 }
             }
         },
-        "handlers.ExchangeRateRecord": {
-            "type": "object",
-            "properties": {
+        "/v1/currencies/{code}": {
+            "get": {
+                "description": "Returns the ISO 4217 metadata of a currency: numeric code, minor units, countries and whether it is active or withdrawn, including its replacement.\nThe name and sign placement are localized, falling back from the region to the language and finally to English.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Get currency metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code, e.g. JPY",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale of the currency name, e.g. de or nl-BE (overrides Accept-Language)",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the currency name",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CurrencyRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/format": {
+            "get": {
+                "description": "Formats an amount in a currency for a locale, using the currency's minor units and sign and the locale's separators and sign placement.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Format an amount of money",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Amount to format, e.g. 1234.5",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code, e.g. JPY",
+                        "name": "currency",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to format for, e.g. de or nl-BE (overrides Accept-Language)",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to format for",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.FormattedAmountRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/batch": {
+            "post": {
+                "description": "Runs a list of rate queries concurrently and returns a result or an error for each of them, in request order.\nEach query takes a base, optional symbols and an optional date; without a date the latest rates are returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get rates for several queries at once",
+                "parameters": [
+                    {
+                        "description": "Queries to run, at most 25",
+                        "name": "queries",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.BatchQuery"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.BatchRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/fluctuation": {
+            "get": {
+                "description": "Compares the rates in effect on the start date with those in effect on the end date and reports the start rate, end rate, absolute change and percentage change of every currency.\nWhen no rates were published on a requested date, the most recent publication before it is used.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get rate fluctuations between two dates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code; unsupported codes fall back to the default (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.FluctuationRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/historical": {
+            "get": {
+                "description": "Get the currency exchange rates in effect on a date, which are those of the most recent publication on or before it.\nThe freshness of the rates is judged against the publication calendar as it stood at the end of the requested day.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get historical exchange rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code; unsupported codes fall back to the default (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ExchangeRateRecord"
+                        },
+                        "headers": {
+                            "X-Data-Expected-Date": {
+                                "type": "string",
+                                "description": "Date of the latest publication that should have been available on the date"
+                            },
+                            "X-Data-Staleness": {
+                                "type": "integer",
+                                "description": "Seconds the rates had been overdue on the date, 0 when up to date"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/latest": {
+            "get": {
+                "description": "Get the latest currency exchange rates, optionally filtered by base currency and target symbols.\nThe X-Data-Staleness header reports, in seconds, how long the rates have been overdue according to the publication calendar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get latest exchange rates",
+                "parameters": [
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code; unsupported codes fall back to the default (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ExchangeRateRecord"
+                        },
+                        "headers": {
+                            "X-Data-Expected-Date": {
+                                "type": "string",
+                                "description": "Date of the latest publication that should be available"
+                            },
+                            "X-Data-Staleness": {
+                                "type": "integer",
+                                "description": "Seconds the rates have been overdue, 0 when up to date"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/ohlc": {
+            "get": {
+                "description": "Resamples the daily rates of a date range into open, high, low and close candles per week, month or year.\nWeeks run from Monday to Sunday. The first and last candles are clipped to the requested range, and periods without publications are left out.\nResponds with CSV, one row per candle, when format is csv or the Accept header asks for text/csv.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get OHLC candles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "End date (YYYY-MM-DD); the range spans at most 366 days unless the interval is year",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code; unsupported codes fall back to the default (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "month",
+                            "year"
+                        ],
+                        "type": "string",
+                        "description": "Candle interval (default: month)",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CandlesRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/stats": {
+            "get": {
+                "description": "Computes the average, minimum, maximum and population standard deviation of the daily rates of every currency, per week or per month of the requested date range.\nWeeks run from Monday to Sunday. The first and last periods are clipped to the requested range, and periods without publications are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get rate statistics over a period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "End date (YYYY-MM-DD); the range spans at most 366 days",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code; unsupported codes fall back to the default (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Aggregation interval (default: month)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatsRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/stream": {
+            "get": {
+                "description": "Opens a Server-Sent Events stream that pushes the latest exchange rates of the requested bases as an event named rates, first on connection and then whenever rates with a newer date are published.\nEach event id lists the date of the latest rates sent per base, e.g. EUR:2025-01-03,USD:2025-01-02. Clients reconnecting with a Last-Event-ID header only receive rates newer than the date of their base, and the latest rates of bases it doesn't list. Heartbeat comments are sent while the stream is idle.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Stream rate updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of base currency codes (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received, to resume after a reconnection",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ExchangeRateRecord"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rates/symbols": {
+            "get": {
+                "description": "Returns a list of all available currency symbols.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get available currency symbols",
+                "parameters": [
+                    {
+                        "enum": [
+                            "code",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Order of the symbols, by currency code or English name (default: stored order)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SymbolsRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "WebhookToken": []
+                    }
+                ],
+                "description": "Lists the webhooks of the owner of the token, oldest first. Secrets are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.WebhooksRecord"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a URL to receive a signed POST when rates with a newer date are published for a base, or, with a threshold, when a rate crosses a value.\nDeliveries carry an X-Webhook-Signature header with the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body, keyed with the secret returned here.\nThe URL must not resolve to a loopback, private, link-local or unspecified address.\nWithout an Authorization header the webhook gets a new owner, whose token is returned here and nowhere else. Send it as a bearer token to add more webhooks to the same owner, and to list and delete them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook to register",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.Registration"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "WebhookToken": []
+                    }
+                ],
+                "description": "Removes a webhook of the owner of the token, it receives no further deliveries.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/currencies": {
+            "get": {
+                "description": "Returns a page of the available currencies with their human-readable names and currency signs.\nNames and sign placement are localized, falling back from the region to the language and finally to English.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get currencies with names and signs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "code",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Order of the currencies, by code or localized name (default: stored order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale of the currency names, e.g. de or nl-BE (overrides Accept-Language)",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page to return, starting at 1 (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of currencies per page (default: 50)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the currency names",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.NamedSymbol"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/rates/latest": {
+            "get": {
+                "description": "Get the latest currency exchange rates, optionally filtered by base currency and target symbols.\nThe freshness of the rates is reported in the metadata and in the X-Data-Staleness header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get latest exchange rates",
+                "parameters": [
+                    {
+                        "maxLength": 3,
+                        "minLength": 3,
+                        "type": "string",
+                        "description": "Base currency code; unsupported codes fall back to the default (default: EUR)",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of target currency symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.ExchangeRateRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Data-Expected-Date": {
+                                "type": "string",
+                                "description": "Date of the latest publication that should be available"
+                            },
+                            "X-Data-Staleness": {
+                                "type": "integer",
+                                "description": "Seconds the rates have been overdue, 0 when up to date"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/rates/symbols": {
+            "get": {
+                "description": "Returns a page of the available currency symbols.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get available currency symbols",
+                "parameters": [
+                    {
+                        "enum": [
+                            "code",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Order of the symbols, by currency code or English name (default: stored order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page to return, starting at 1 (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of symbols per page (default: 50)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Returns the commit, build time and Go version of the running binary.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get build information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/version.Info"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "rates.put",
+                        "rates.delete",
+                        "symbols.regenerate"
+                    ]
+                },
+                "actor": {
+                    "description": "Actor is the name of the admin whose token authorized the correction.",
+                    "type": "string",
+                    "example": "alice"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "document": {
+                    "description": "Document is the path of the corrected Firestore document.",
+                    "type": "string",
+                    "example": "exchange_rates/EUR-2025-01-03"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "description": "NewValue is the document after the correction, left out when it was deleted.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "old_value": {
+                    "description": "OldValue is the document before the correction, left out when it didn't exist.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "upstream published USD off by a factor 10"
+                }
+            }
+        },
+        "admin.AuditRecord": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.AuditEntry"
+                    }
+                }
+            }
+        },
+        "admin.RatesCorrection": {
+            "type": "object",
+            "properties": {
+                "rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "upstream published USD off by a factor 10"
+                }
+            }
+        },
+        "admin.Reason": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "symbols missed GBP after a partial publication"
+                }
+            }
+        },
+        "api.BatchQuery": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "date": {
+                    "description": "Date selects the rates in effect on a day (YYYY-MM-DD), the latest rates when empty.",
+                    "type": "string",
+                    "example": "2025-11-21"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "EUR",
+                        "GBP"
+                    ]
+                }
+            }
+        },
+        "api.BatchRecord": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BatchResult"
+                    }
+                }
+            }
+        },
+        "api.BatchResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/api.ExchangeRateRecord"
+                },
+                "error": {
+                    "$ref": "#/definitions/api.Error"
+                },
+                "query": {
+                    "$ref": "#/definitions/api.BatchQuery"
+                }
+            }
+        },
+        "api.Candle": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CandlesRecord": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "candles": {
+                    "description": "Candles maps currency codes to their candles, oldest first.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/api.Candle"
+                        }
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CurrenciesRecord": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.NamedSymbol"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CurrencyRecord": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "minor_units": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numeric_code": {
+                    "type": "string"
+                },
+                "replaced_by": {
+                    "type": "string"
+                },
+                "sign": {
+                    "type": "string"
+                },
+                "sign_placement": {
+                    "type": "string",
+                    "enum": [
+                        "before",
+                        "after"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "withdrawn"
+                    ]
+                },
+                "withdrawn": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.Error": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ExchangeRateRecord": {
+            "type": "object",
+            "properties": {
                 "base": {
                     "type": "string"
                 },
                 "date": {
                     "type": "string"
                 },
+                "freshness": {
+                    "$ref": "#/definitions/api.Freshness"
+                },
                 "rates": {
                     "type": "object",
                     "additionalProperties": {
@@ -179,7 +1823,137 @@ const docTemplate = `{
                 }
             }
         },
-        "handlers.NamedSymbol": {
+        "api.Fluctuation": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "number"
+                },
+                "change_pct": {
+                    "description": "ChangePercent is Change relative to StartRate, in percent.",
+                    "type": "number"
+                },
+                "end_rate": {
+                    "type": "number"
+                },
+                "start_rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.FluctuationRecord": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.Fluctuation"
+                    }
+                },
+                "start_date": {
+                    "description": "StartDate and EndDate are the dates of the publications compared, which are the most\nrecent ones on or before the requested dates.",
+                    "type": "string"
+                }
+            }
+        },
+        "api.FormattedAmountRecord": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "formatted": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.Freshness": {
+            "type": "object",
+            "properties": {
+                "expected_date": {
+                    "description": "ExpectedDate is the date of the most recent publication that should be available.",
+                    "type": "string"
+                },
+                "missed_publications": {
+                    "description": "MissedPublications counts the publications between the record and ExpectedDate.",
+                    "type": "integer"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "stale_seconds": {
+                    "description": "StaleSeconds is how long ago the first missed publication was due, 0 when up to date.",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.GraphQLError": {
+            "type": "object",
+            "properties": {
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.GraphQLLocation"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path leads to the field that failed, through field names and list indexes.",
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "api.GraphQLLocation": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ latest(base: \"USD\") { date rates(symbols: [\"EUR\", \"JPY\"]) } }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "api.HealthRecord": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.NamedSymbol": {
             "type": "object",
             "properties": {
                 "name": {
@@ -188,12 +1962,141 @@ const docTemplate = `{
                 "sign": {
                     "type": "string"
                 },
+                "sign_placement": {
+                    "type": "string",
+                    "enum": [
+                        "before",
+                        "after"
+                    ]
+                },
                 "symbol": {
                     "type": "string"
                 }
             }
         },
-        "handlers.SymbolsRecord": {
+        "api.RateStats": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "count": {
+                    "description": "Count is the number of publications the aggregates are computed from.",
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "std_dev": {
+                    "description": "StdDev is the population standard deviation of the daily rates.",
+                    "type": "number"
+                }
+            }
+        },
+        "api.ReadinessCheck": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ReadinessRecord": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.ReadinessCheck"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.Registration": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "secret": {
+                    "description": "Secret signs the deliveries, one is generated when it is empty.",
+                    "type": "string"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "USD",
+                        "GBP"
+                    ]
+                },
+                "threshold": {
+                    "description": "Threshold makes the webhook fire only when a rate crosses a value.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Threshold"
+                        }
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/rates"
+                }
+            }
+        },
+        "api.StatsPeriod": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.RateStats"
+                    }
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.StatsRecord": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.StatsPeriod"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SymbolsRecord": {
             "type": "object",
             "properties": {
                 "date": {
@@ -207,16 +2110,171 @@ const docTemplate = `{
                 }
             }
         },
-        "utils.Error": {
+        "api.Threshold": {
             "type": "object",
             "properties": {
-                "message": {
+                "symbol": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 1.1
+                }
+            }
+        },
+        "api.Webhook": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "description": "Base is the base currency the webhook watches.",
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "status": {
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret signs the deliveries. It is only returned when the webhook is created.",
+                    "type": "string"
+                },
+                "symbols": {
+                    "description": "Symbols limits the rates sent on publication, all rates are sent when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "threshold": {
+                    "$ref": "#/definitions/api.Threshold"
+                },
+                "token": {
+                    "description": "Token authenticates the owner of the webhook to list and delete their webhooks. It is\nonly returned when the webhook is created without one, which makes a new owner.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/rates"
+                }
+            }
+        },
+        "api.WebhooksRecord": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Webhook"
+                    }
+                }
+            }
+        },
+        "handlers.Envelope": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Error"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/handlers.Meta"
+                }
+            }
+        },
+        "handlers.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.GraphQLError"
+                    }
+                }
+            }
+        },
+        "handlers.Meta": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Date is the publication date of the data.",
+                    "type": "string"
+                },
+                "freshness": {
+                    "description": "Freshness is set on responses serving rates.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Freshness"
+                        }
+                    ]
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "description": "Pagination is set on responses listing a page of a collection.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.Pagination"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.Pagination": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
                     "type": "integer"
                 }
             }
+        },
+        "version.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "commit_time": {
+                    "description": "CommitTime is when Commit was made, as stamped by the Go toolchain.",
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Token of an admin, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "WebhookToken": {
+            "description": "Token returned when the first webhook of an owner is registered, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -224,7 +2282,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8000",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Forex API",
@@ -4,3 +4,6 @@ import _ "embed"
 
 //go:embed swagger.yaml
 var SwaggerYAML []byte
+
+//go:embed swagger.json
+var SwaggerJSON []byte
@@ -0,0 +1,36 @@
+package docs
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3conv"
+)
+
+// OpenAPIVersion is the version of the document returned by OpenAPI3.
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPI3 returns the generated Swagger 2.0 spec converted to OpenAPI 3.1. The document is
+// converted once and shared, so callers must copy it before changing it. It has no servers;
+// those depend on where the API is deployed.
+var OpenAPI3 = sync.OnceValues(func() (*openapi3.T, error) {
+	var swagger openapi2.T
+	if err := json.Unmarshal(SwaggerJSON, &swagger); err != nil {
+		return nil, err
+	}
+
+	document, err := openapi2conv.ToV3(&swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Upgrade rewrites the schemas into their JSON Schema 2020-12 form, which 3.1 shares with
+	// later versions, and stamps the latest version it knows. Tools widely support 3.1 only.
+	openapi3conv.Upgrade(document)
+	document.OpenAPI = OpenAPIVersion
+	document.Servers = nil
+	return document, nil
+})
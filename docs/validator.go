@@ -0,0 +1,177 @@
+package docs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// ErrUndocumented is returned for requests to operations the spec doesn't describe.
+var ErrUndocumented = errors.New("operation is not documented")
+
+// Validator checks requests and responses against the OpenAPI 3.1 document, so the
+// annotations on the handlers can't drift away from what they do.
+type Validator struct {
+	router routers.Router
+}
+
+func NewValidator() (*Validator, error) {
+	document, err := OpenAPI3()
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := legacy.NewRouter(document)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{router: router}, nil
+}
+
+func (v *Validator) route(request *http.Request) (*routers.Route, map[string]string, error) {
+	route, pathParams, err := v.router.FindRoute(request)
+	var routeError *routers.RouteError
+	if errors.As(err, &routeError) {
+		return nil, nil, ErrUndocumented
+	}
+	return route, pathParams, err
+}
+
+// ValidateQuery checks the query parameters of a request: documented ones must match their
+// schema and undocumented ones aren't allowed.
+func (v *Validator) ValidateQuery(request *http.Request) error {
+	route, pathParams, err := v.route(request)
+	if err != nil {
+		return err
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    request,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    &openapi3filter.Options{SkipSettingDefaults: true},
+	}
+
+	documented := map[string]bool{}
+	for _, parameter := range route.Operation.Parameters {
+		if parameter.Value == nil || parameter.Value.In != openapi3.ParameterInQuery {
+			continue
+		}
+		documented[parameter.Value.Name] = true
+		if err := openapi3filter.ValidateParameter(request.Context(), input, parameter.Value); err != nil {
+			return queryError(err)
+		}
+	}
+
+	for name := range request.URL.Query() {
+		if !documented[name] {
+			return fmt.Errorf("query parameter %q is not supported", name)
+		}
+	}
+	return nil
+}
+
+// queryError shortens the errors of kin-openapi, which include the whole schema, to a message
+// fit for a client.
+func queryError(err error) error {
+	var requestError *openapi3filter.RequestError
+	if !errors.As(err, &requestError) || requestError.Parameter == nil {
+		return err
+	}
+
+	reason := requestError.Reason
+	var schemaError *openapi3.SchemaError
+	if errors.As(requestError.Err, &schemaError) {
+		// The JSON Schema validator puts the reason on the last line, after its location.
+		lines := strings.Split(schemaError.Reason, "\n")
+		reason = strings.TrimSpace(lines[len(lines)-1])
+		if location, cause, ok := strings.Cut(reason, ": "); ok && strings.HasPrefix(location, "- at ") {
+			reason = cause
+		}
+	} else if reason == "" && requestError.Err != nil {
+		reason = requestError.Err.Error()
+	}
+	return fmt.Errorf("query parameter %q is invalid: %s", requestError.Parameter.Name, reason)
+}
+
+// Streams reports whether request is for an operation that only produces a stream of events,
+// whose responses can't be buffered and validated.
+func (v *Validator) Streams(request *http.Request) bool {
+	route, _, err := v.route(request)
+	if err != nil {
+		return false
+	}
+
+	for _, response := range route.Operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		for mediaType := range response.Value.Content {
+			if mediaType != "text/event-stream" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ValidateResponse checks that a response has a documented status, content type and headers,
+// and that JSON bodies match their schema. Other bodies aren't checked, because swag documents
+// every media type of an operation with the schema of its JSON body.
+func (v *Validator) ValidateResponse(request *http.Request, status int, header http.Header, body []byte) error {
+	route, pathParams, err := v.route(request)
+	if err != nil {
+		return err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	options := &openapi3filter.Options{
+		IncludeResponseStatus: true,
+		ExcludeResponseBody:   mediaType != "application/json",
+	}
+	if options.ExcludeResponseBody && len(body) > 0 {
+		if err := checkMediaType(route, status, mediaType); err != nil {
+			return err
+		}
+	}
+
+	return openapi3filter.ValidateResponse(context.WithoutCancel(request.Context()), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status:  status,
+		Header:  header,
+		Body:    io.NopCloser(bytes.NewReader(body)),
+		Options: options,
+	})
+}
+
+func checkMediaType(route *routers.Route, status int, mediaType string) error {
+	response := route.Operation.Responses.Status(status)
+	if response == nil || response.Value == nil {
+		return nil
+	}
+
+	documented := make([]string, 0, len(response.Value.Content))
+	for candidate := range response.Value.Content {
+		documented = append(documented, candidate)
+	}
+	if len(documented) == 0 || slices.Contains(documented, mediaType) {
+		return nil
+	}
+	slices.Sort(documented)
+	return fmt.Errorf("response content type %q is not documented, want one of %v", mediaType, documented)
+}
@@ -0,0 +1,103 @@
+package docs
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	validator, err := NewValidator()
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+	return validator
+}
+
+func TestValidator_ValidateQuery(t *testing.T) {
+	validator := newTestValidator(t)
+
+	tests := []struct {
+		name        string
+		target      string
+		wantErr     error
+		wantMessage string
+	}{
+		{name: "documented parameters", target: "/v1/rates/latest?base=usd&symbols=EUR,GBP"},
+		{name: "path parameters", target: "/v1/currencies/JPY?locale=ja"},
+		{name: "undocumented parameter", target: "/v1/rates/latest?bse=USD", wantMessage: `query parameter "bse" is not supported`},
+		{name: "base of the wrong length", target: "/v1/rates/latest?base=EURO", wantMessage: `query parameter "base" is invalid`},
+		{name: "missing required parameter", target: "/v1/rates/stats?end=2025-02-01", wantMessage: `query parameter "start" is invalid: value is required but missing`},
+		{name: "value outside the enum", target: "/v1/rates/stats?start=2025-01-01&end=2025-02-01&interval=day", wantMessage: `query parameter "interval" is invalid: value must be one of 'week', 'month'`},
+		{name: "malformed date", target: "/v1/rates/ohlc?start=2025-13-01&end=2025-02-01", wantMessage: `query parameter "start" is invalid`},
+		{name: "malformed number", target: "/v1/format?amount=many&currency=EUR", wantMessage: `query parameter "amount" is invalid`},
+		{name: "undocumented path", target: "/docs/?theme=dark", wantErr: ErrUndocumented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			err := validator.ValidateQuery(req)
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ValidateQuery() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantMessage == "":
+				if err != nil {
+					t.Errorf("ValidateQuery() error = %v, want nil", err)
+				}
+			case err == nil || !strings.HasPrefix(err.Error(), tt.wantMessage):
+				t.Errorf("ValidateQuery() error = %v, want it to start with %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	validator := newTestValidator(t)
+
+	tests := []struct {
+		name        string
+		target      string
+		status      int
+		contentType string
+		body        string
+		wantErr     bool
+	}{
+		{name: "matching body", target: "/v1/rates/latest", status: http.StatusOK, contentType: "application/json", body: `{"base":"EUR","date":"2025-12-05","rates":{"USD":1.16}}`},
+		{name: "documented error", target: "/v1/rates/latest", status: http.StatusNotFound, contentType: "application/json", body: `{"message":"Rates not found","status":404}`},
+		{name: "mistyped field", target: "/v1/rates/latest", status: http.StatusOK, contentType: "application/json", body: `{"base":"EUR","date":"2025-12-05","rates":{"USD":"1.16"}}`, wantErr: true},
+		{name: "undocumented status", target: "/v1/rates/latest", status: http.StatusTeapot, contentType: "application/json", body: `{}`, wantErr: true},
+		{name: "documented media type", target: "/v1/rates/ohlc", status: http.StatusOK, contentType: "text/csv; charset=utf-8", body: "base,symbol\n"},
+		{name: "undocumented media type", target: "/v1/rates/ohlc", status: http.StatusOK, contentType: "text/html", body: "<p></p>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			header := http.Header{"Content-Type": []string{tt.contentType}}
+
+			err := validator.ValidateResponse(req, tt.status, header, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_Streams(t *testing.T) {
+	validator := newTestValidator(t)
+
+	for target, want := range map[string]bool{"/v1/rates/stream": true, "/v1/rates/latest": false, "/docs/": false} {
+		if got := validator.Streams(httptest.NewRequest(http.MethodGet, target, nil)); got != want {
+			t.Errorf("Streams(%q) = %v, want %v", target, got, want)
+		}
+	}
+}
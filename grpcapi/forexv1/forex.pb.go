@@ -0,0 +1,1001 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: forex/v1/forex.proto
+
+package forexv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetLatestRatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base currency code; unsupported codes fall back to EUR.
+	Base string `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Target currency symbols, all of them when empty.
+	Symbols       []string `protobuf:"bytes,2,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestRatesRequest) Reset() {
+	*x = GetLatestRatesRequest{}
+	mi := &file_forex_v1_forex_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestRatesRequest) ProtoMessage() {}
+
+func (x *GetLatestRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestRatesRequest) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetLatestRatesRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *GetLatestRatesRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+type GetHistoricalRatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base currency code; unsupported codes fall back to EUR.
+	Base string `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Target currency symbols, all of them when empty.
+	Symbols []string `protobuf:"bytes,2,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	// Date as YYYY-MM-DD.
+	Date          string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoricalRatesRequest) Reset() {
+	*x = GetHistoricalRatesRequest{}
+	mi := &file_forex_v1_forex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoricalRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoricalRatesRequest) ProtoMessage() {}
+
+func (x *GetHistoricalRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoricalRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoricalRatesRequest) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetHistoricalRatesRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *GetHistoricalRatesRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *GetHistoricalRatesRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type Rates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Publication date as YYYY-MM-DD.
+	Date  string             `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Rates map[string]float64 `protobuf:"bytes,3,rep,name=rates,proto3" json:"rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// Only set on the latest and historical rates.
+	Freshness     *Freshness `protobuf:"bytes,4,opt,name=freshness,proto3" json:"freshness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rates) Reset() {
+	*x = Rates{}
+	mi := &file_forex_v1_forex_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rates) ProtoMessage() {}
+
+func (x *Rates) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rates.ProtoReflect.Descriptor instead.
+func (*Rates) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Rates) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *Rates) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Rates) GetRates() map[string]float64 {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *Rates) GetFreshness() *Freshness {
+	if x != nil {
+		return x.Freshness
+	}
+	return nil
+}
+
+// Freshness describes how far rates lag behind the publication calendar.
+type Freshness struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Date of the most recent publication that should be available, as YYYY-MM-DD.
+	ExpectedDate       string `protobuf:"bytes,1,opt,name=expected_date,json=expectedDate,proto3" json:"expected_date,omitempty"`
+	MissedPublications int32  `protobuf:"varint,2,opt,name=missed_publications,json=missedPublications,proto3" json:"missed_publications,omitempty"`
+	// How long ago the first missed publication was due, 0 when up to date.
+	StaleSeconds  int64 `protobuf:"varint,3,opt,name=stale_seconds,json=staleSeconds,proto3" json:"stale_seconds,omitempty"`
+	Stale         bool  `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Freshness) Reset() {
+	*x = Freshness{}
+	mi := &file_forex_v1_forex_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Freshness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Freshness) ProtoMessage() {}
+
+func (x *Freshness) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Freshness.ProtoReflect.Descriptor instead.
+func (*Freshness) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Freshness) GetExpectedDate() string {
+	if x != nil {
+		return x.ExpectedDate
+	}
+	return ""
+}
+
+func (x *Freshness) GetMissedPublications() int32 {
+	if x != nil {
+		return x.MissedPublications
+	}
+	return 0
+}
+
+func (x *Freshness) GetStaleSeconds() int64 {
+	if x != nil {
+		return x.StaleSeconds
+	}
+	return 0
+}
+
+func (x *Freshness) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+type ConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Currency code of the amount.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Currency code to convert to.
+	To     string  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Amount float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Date of the rate as YYYY-MM-DD, the latest rate when empty.
+	Date          string `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_forex_v1_forex_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConvertRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	From   string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Amount float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Rate   float64                `protobuf:"fixed64,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	Result float64                `protobuf:"fixed64,5,opt,name=result,proto3" json:"result,omitempty"`
+	// Publication date of the rate as YYYY-MM-DD.
+	Date          string `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_forex_v1_forex_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConvertResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type ListCurrenciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Locale of the currency names, e.g. de or nl-BE.
+	Locale        string `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	mi := &file_forex_v1_forex_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesRequest) ProtoMessage() {}
+
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCurrenciesRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ListCurrenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Currencies    []*NamedCurrency       `protobuf:"bytes,3,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	mi := &file_forex_v1_forex_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesResponse) ProtoMessage() {}
+
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCurrenciesResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ListCurrenciesResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ListCurrenciesResponse) GetCurrencies() []*NamedCurrency {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+type NamedCurrency struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sign   string                 `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`
+	// Either "before" or "after" the amount.
+	SignPlacement string `protobuf:"bytes,4,opt,name=sign_placement,json=signPlacement,proto3" json:"sign_placement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamedCurrency) Reset() {
+	*x = NamedCurrency{}
+	mi := &file_forex_v1_forex_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamedCurrency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedCurrency) ProtoMessage() {}
+
+func (x *NamedCurrency) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedCurrency.ProtoReflect.Descriptor instead.
+func (*NamedCurrency) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NamedCurrency) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *NamedCurrency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamedCurrency) GetSign() string {
+	if x != nil {
+		return x.Sign
+	}
+	return ""
+}
+
+func (x *NamedCurrency) GetSignPlacement() string {
+	if x != nil {
+		return x.SignPlacement
+	}
+	return ""
+}
+
+type GetCurrencyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 4217 currency code.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Locale of the currency name, e.g. de or nl-BE.
+	Locale        string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrencyRequest) Reset() {
+	*x = GetCurrencyRequest{}
+	mi := &file_forex_v1_forex_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrencyRequest) ProtoMessage() {}
+
+func (x *GetCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCurrencyRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetCurrencyRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type Currency struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Code   string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Locale string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Name   string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Sign   string                 `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+	// Either "before" or "after" the amount.
+	SignPlacement string `protobuf:"bytes,5,opt,name=sign_placement,json=signPlacement,proto3" json:"sign_placement,omitempty"`
+	NumericCode   string `protobuf:"bytes,6,opt,name=numeric_code,json=numericCode,proto3" json:"numeric_code,omitempty"`
+	MinorUnits    int32  `protobuf:"varint,7,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
+	// ISO 3166-1 alpha-2 codes of the countries using the currency.
+	Countries []string `protobuf:"bytes,8,rep,name=countries,proto3" json:"countries,omitempty"`
+	// Either "active" or "withdrawn".
+	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	// Year and month (YYYY-MM) the currency was withdrawn, empty while active.
+	Withdrawn     string `protobuf:"bytes,10,opt,name=withdrawn,proto3" json:"withdrawn,omitempty"`
+	ReplacedBy    string `protobuf:"bytes,11,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Currency) Reset() {
+	*x = Currency{}
+	mi := &file_forex_v1_forex_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Currency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Currency) ProtoMessage() {}
+
+func (x *Currency) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Currency.ProtoReflect.Descriptor instead.
+func (*Currency) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Currency) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Currency) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Currency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Currency) GetSign() string {
+	if x != nil {
+		return x.Sign
+	}
+	return ""
+}
+
+func (x *Currency) GetSignPlacement() string {
+	if x != nil {
+		return x.SignPlacement
+	}
+	return ""
+}
+
+func (x *Currency) GetNumericCode() string {
+	if x != nil {
+		return x.NumericCode
+	}
+	return ""
+}
+
+func (x *Currency) GetMinorUnits() int32 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
+func (x *Currency) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *Currency) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Currency) GetWithdrawn() string {
+	if x != nil {
+		return x.Withdrawn
+	}
+	return ""
+}
+
+func (x *Currency) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type StreamRatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base currency codes, EUR when empty.
+	Bases []string `protobuf:"bytes,1,rep,name=bases,proto3" json:"bases,omitempty"`
+	// Target currency symbols, all of them when empty.
+	Symbols       []string `protobuf:"bytes,2,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRatesRequest) Reset() {
+	*x = StreamRatesRequest{}
+	mi := &file_forex_v1_forex_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRatesRequest) ProtoMessage() {}
+
+func (x *StreamRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forex_v1_forex_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamRatesRequest) Descriptor() ([]byte, []int) {
+	return file_forex_v1_forex_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamRatesRequest) GetBases() []string {
+	if x != nil {
+		return x.Bases
+	}
+	return nil
+}
+
+func (x *StreamRatesRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+var File_forex_v1_forex_proto protoreflect.FileDescriptor
+
+const file_forex_v1_forex_proto_rawDesc = "" +
+	"\n" +
+	"\x14forex/v1/forex.proto\x12\bforex.v1\"E\n" +
+	"\x15GetLatestRatesRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x18\n" +
+	"\asymbols\x18\x02 \x03(\tR\asymbols\"]\n" +
+	"\x19GetHistoricalRatesRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x18\n" +
+	"\asymbols\x18\x02 \x03(\tR\asymbols\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\"\xce\x01\n" +
+	"\x05Rates\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x120\n" +
+	"\x05rates\x18\x03 \x03(\v2\x1a.forex.v1.Rates.RatesEntryR\x05rates\x121\n" +
+	"\tfreshness\x18\x04 \x01(\v2\x13.forex.v1.FreshnessR\tfreshness\x1a8\n" +
+	"\n" +
+	"RatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x9c\x01\n" +
+	"\tFreshness\x12#\n" +
+	"\rexpected_date\x18\x01 \x01(\tR\fexpectedDate\x12/\n" +
+	"\x13missed_publications\x18\x02 \x01(\x05R\x12missedPublications\x12#\n" +
+	"\rstale_seconds\x18\x03 \x01(\x03R\fstaleSeconds\x12\x14\n" +
+	"\x05stale\x18\x04 \x01(\bR\x05stale\"`\n" +
+	"\x0eConvertRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x12\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\"\x8d\x01\n" +
+	"\x0fConvertResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\x01R\x04rate\x12\x16\n" +
+	"\x06result\x18\x05 \x01(\x01R\x06result\x12\x12\n" +
+	"\x04date\x18\x06 \x01(\tR\x04date\"/\n" +
+	"\x15ListCurrenciesRequest\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\"}\n" +
+	"\x16ListCurrenciesResponse\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x127\n" +
+	"\n" +
+	"currencies\x18\x03 \x03(\v2\x17.forex.v1.NamedCurrencyR\n" +
+	"currencies\"v\n" +
+	"\rNamedCurrency\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04sign\x18\x03 \x01(\tR\x04sign\x12%\n" +
+	"\x0esign_placement\x18\x04 \x01(\tR\rsignPlacement\"@\n" +
+	"\x12GetCurrencyRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"\xbe\x02\n" +
+	"\bCurrency\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04sign\x18\x04 \x01(\tR\x04sign\x12%\n" +
+	"\x0esign_placement\x18\x05 \x01(\tR\rsignPlacement\x12!\n" +
+	"\fnumeric_code\x18\x06 \x01(\tR\vnumericCode\x12\x1f\n" +
+	"\vminor_units\x18\a \x01(\x05R\n" +
+	"minorUnits\x12\x1c\n" +
+	"\tcountries\x18\b \x03(\tR\tcountries\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1c\n" +
+	"\twithdrawn\x18\n" +
+	" \x01(\tR\twithdrawn\x12\x1f\n" +
+	"\vreplaced_by\x18\v \x01(\tR\n" +
+	"replacedBy\"D\n" +
+	"\x12StreamRatesRequest\x12\x14\n" +
+	"\x05bases\x18\x01 \x03(\tR\x05bases\x12\x18\n" +
+	"\asymbols\x18\x02 \x03(\tR\asymbols2\xb4\x03\n" +
+	"\fForexService\x12B\n" +
+	"\x0eGetLatestRates\x12\x1f.forex.v1.GetLatestRatesRequest\x1a\x0f.forex.v1.Rates\x12J\n" +
+	"\x12GetHistoricalRates\x12#.forex.v1.GetHistoricalRatesRequest\x1a\x0f.forex.v1.Rates\x12>\n" +
+	"\aConvert\x12\x18.forex.v1.ConvertRequest\x1a\x19.forex.v1.ConvertResponse\x12S\n" +
+	"\x0eListCurrencies\x12\x1f.forex.v1.ListCurrenciesRequest\x1a .forex.v1.ListCurrenciesResponse\x12?\n" +
+	"\vGetCurrency\x12\x1c.forex.v1.GetCurrencyRequest\x1a\x12.forex.v1.Currency\x12>\n" +
+	"\vStreamRates\x12\x1c.forex.v1.StreamRatesRequest\x1a\x0f.forex.v1.Rates0\x01B8Z6github.com/kamaal111/forex-api/grpcapi/forexv1;forexv1b\x06proto3"
+
+var (
+	file_forex_v1_forex_proto_rawDescOnce sync.Once
+	file_forex_v1_forex_proto_rawDescData []byte
+)
+
+func file_forex_v1_forex_proto_rawDescGZIP() []byte {
+	file_forex_v1_forex_proto_rawDescOnce.Do(func() {
+		file_forex_v1_forex_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_forex_v1_forex_proto_rawDesc), len(file_forex_v1_forex_proto_rawDesc)))
+	})
+	return file_forex_v1_forex_proto_rawDescData
+}
+
+var file_forex_v1_forex_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_forex_v1_forex_proto_goTypes = []any{
+	(*GetLatestRatesRequest)(nil),     // 0: forex.v1.GetLatestRatesRequest
+	(*GetHistoricalRatesRequest)(nil), // 1: forex.v1.GetHistoricalRatesRequest
+	(*Rates)(nil),                     // 2: forex.v1.Rates
+	(*Freshness)(nil),                 // 3: forex.v1.Freshness
+	(*ConvertRequest)(nil),            // 4: forex.v1.ConvertRequest
+	(*ConvertResponse)(nil),           // 5: forex.v1.ConvertResponse
+	(*ListCurrenciesRequest)(nil),     // 6: forex.v1.ListCurrenciesRequest
+	(*ListCurrenciesResponse)(nil),    // 7: forex.v1.ListCurrenciesResponse
+	(*NamedCurrency)(nil),             // 8: forex.v1.NamedCurrency
+	(*GetCurrencyRequest)(nil),        // 9: forex.v1.GetCurrencyRequest
+	(*Currency)(nil),                  // 10: forex.v1.Currency
+	(*StreamRatesRequest)(nil),        // 11: forex.v1.StreamRatesRequest
+	nil,                               // 12: forex.v1.Rates.RatesEntry
+}
+var file_forex_v1_forex_proto_depIdxs = []int32{
+	12, // 0: forex.v1.Rates.rates:type_name -> forex.v1.Rates.RatesEntry
+	3,  // 1: forex.v1.Rates.freshness:type_name -> forex.v1.Freshness
+	8,  // 2: forex.v1.ListCurrenciesResponse.currencies:type_name -> forex.v1.NamedCurrency
+	0,  // 3: forex.v1.ForexService.GetLatestRates:input_type -> forex.v1.GetLatestRatesRequest
+	1,  // 4: forex.v1.ForexService.GetHistoricalRates:input_type -> forex.v1.GetHistoricalRatesRequest
+	4,  // 5: forex.v1.ForexService.Convert:input_type -> forex.v1.ConvertRequest
+	6,  // 6: forex.v1.ForexService.ListCurrencies:input_type -> forex.v1.ListCurrenciesRequest
+	9,  // 7: forex.v1.ForexService.GetCurrency:input_type -> forex.v1.GetCurrencyRequest
+	11, // 8: forex.v1.ForexService.StreamRates:input_type -> forex.v1.StreamRatesRequest
+	2,  // 9: forex.v1.ForexService.GetLatestRates:output_type -> forex.v1.Rates
+	2,  // 10: forex.v1.ForexService.GetHistoricalRates:output_type -> forex.v1.Rates
+	5,  // 11: forex.v1.ForexService.Convert:output_type -> forex.v1.ConvertResponse
+	7,  // 12: forex.v1.ForexService.ListCurrencies:output_type -> forex.v1.ListCurrenciesResponse
+	10, // 13: forex.v1.ForexService.GetCurrency:output_type -> forex.v1.Currency
+	2,  // 14: forex.v1.ForexService.StreamRates:output_type -> forex.v1.Rates
+	9,  // [9:15] is the sub-list for method output_type
+	3,  // [3:9] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_forex_v1_forex_proto_init() }
+func file_forex_v1_forex_proto_init() {
+	if File_forex_v1_forex_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_forex_v1_forex_proto_rawDesc), len(file_forex_v1_forex_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_forex_v1_forex_proto_goTypes,
+		DependencyIndexes: file_forex_v1_forex_proto_depIdxs,
+		MessageInfos:      file_forex_v1_forex_proto_msgTypes,
+	}.Build()
+	File_forex_v1_forex_proto = out.File
+	file_forex_v1_forex_proto_goTypes = nil
+	file_forex_v1_forex_proto_depIdxs = nil
+}
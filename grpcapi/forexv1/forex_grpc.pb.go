@@ -0,0 +1,335 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: forex/v1/forex.proto
+
+package forexv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ForexService_GetLatestRates_FullMethodName     = "/forex.v1.ForexService/GetLatestRates"
+	ForexService_GetHistoricalRates_FullMethodName = "/forex.v1.ForexService/GetHistoricalRates"
+	ForexService_Convert_FullMethodName            = "/forex.v1.ForexService/Convert"
+	ForexService_ListCurrencies_FullMethodName     = "/forex.v1.ForexService/ListCurrencies"
+	ForexService_GetCurrency_FullMethodName        = "/forex.v1.ForexService/GetCurrency"
+	ForexService_StreamRates_FullMethodName        = "/forex.v1.ForexService/StreamRates"
+)
+
+// ForexServiceClient is the client API for ForexService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ForexService serves the exchange rates and currencies of the HTTP API over gRPC.
+type ForexServiceClient interface {
+	// GetLatestRates returns the latest rates of a base currency.
+	GetLatestRates(ctx context.Context, in *GetLatestRatesRequest, opts ...grpc.CallOption) (*Rates, error)
+	// GetHistoricalRates returns the rates in effect on a date, which are the rates of the most
+	// recent publication on or before it.
+	GetHistoricalRates(ctx context.Context, in *GetHistoricalRatesRequest, opts ...grpc.CallOption) (*Rates, error)
+	// Convert converts an amount between two currencies at the latest or a historical rate.
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// ListCurrencies returns the currencies with rates, with localized names and signs.
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	// GetCurrency returns the ISO 4217 metadata of a currency.
+	GetCurrency(ctx context.Context, in *GetCurrencyRequest, opts ...grpc.CallOption) (*Currency, error)
+	// StreamRates sends the latest rates of the requested bases, then new rates whenever they
+	// are published, until the client cancels.
+	StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Rates], error)
+}
+
+type forexServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewForexServiceClient(cc grpc.ClientConnInterface) ForexServiceClient {
+	return &forexServiceClient{cc}
+}
+
+func (c *forexServiceClient) GetLatestRates(ctx context.Context, in *GetLatestRatesRequest, opts ...grpc.CallOption) (*Rates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rates)
+	err := c.cc.Invoke(ctx, ForexService_GetLatestRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forexServiceClient) GetHistoricalRates(ctx context.Context, in *GetHistoricalRatesRequest, opts ...grpc.CallOption) (*Rates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rates)
+	err := c.cc.Invoke(ctx, ForexService_GetHistoricalRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forexServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, ForexService_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forexServiceClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, ForexService_ListCurrencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forexServiceClient) GetCurrency(ctx context.Context, in *GetCurrencyRequest, opts ...grpc.CallOption) (*Currency, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Currency)
+	err := c.cc.Invoke(ctx, ForexService_GetCurrency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forexServiceClient) StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Rates], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ForexService_ServiceDesc.Streams[0], ForexService_StreamRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRatesRequest, Rates]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ForexService_StreamRatesClient = grpc.ServerStreamingClient[Rates]
+
+// ForexServiceServer is the server API for ForexService service.
+// All implementations must embed UnimplementedForexServiceServer
+// for forward compatibility.
+//
+// ForexService serves the exchange rates and currencies of the HTTP API over gRPC.
+type ForexServiceServer interface {
+	// GetLatestRates returns the latest rates of a base currency.
+	GetLatestRates(context.Context, *GetLatestRatesRequest) (*Rates, error)
+	// GetHistoricalRates returns the rates in effect on a date, which are the rates of the most
+	// recent publication on or before it.
+	GetHistoricalRates(context.Context, *GetHistoricalRatesRequest) (*Rates, error)
+	// Convert converts an amount between two currencies at the latest or a historical rate.
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// ListCurrencies returns the currencies with rates, with localized names and signs.
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	// GetCurrency returns the ISO 4217 metadata of a currency.
+	GetCurrency(context.Context, *GetCurrencyRequest) (*Currency, error)
+	// StreamRates sends the latest rates of the requested bases, then new rates whenever they
+	// are published, until the client cancels.
+	StreamRates(*StreamRatesRequest, grpc.ServerStreamingServer[Rates]) error
+	mustEmbedUnimplementedForexServiceServer()
+}
+
+// UnimplementedForexServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedForexServiceServer struct{}
+
+func (UnimplementedForexServiceServer) GetLatestRates(context.Context, *GetLatestRatesRequest) (*Rates, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLatestRates not implemented")
+}
+func (UnimplementedForexServiceServer) GetHistoricalRates(context.Context, *GetHistoricalRatesRequest) (*Rates, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistoricalRates not implemented")
+}
+func (UnimplementedForexServiceServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedForexServiceServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedForexServiceServer) GetCurrency(context.Context, *GetCurrencyRequest) (*Currency, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrency not implemented")
+}
+func (UnimplementedForexServiceServer) StreamRates(*StreamRatesRequest, grpc.ServerStreamingServer[Rates]) error {
+	return status.Error(codes.Unimplemented, "method StreamRates not implemented")
+}
+func (UnimplementedForexServiceServer) mustEmbedUnimplementedForexServiceServer() {}
+func (UnimplementedForexServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeForexServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ForexServiceServer will
+// result in compilation errors.
+type UnsafeForexServiceServer interface {
+	mustEmbedUnimplementedForexServiceServer()
+}
+
+func RegisterForexServiceServer(s grpc.ServiceRegistrar, srv ForexServiceServer) {
+	// If the following call panics, it indicates UnimplementedForexServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ForexService_ServiceDesc, srv)
+}
+
+func _ForexService_GetLatestRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForexServiceServer).GetLatestRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForexService_GetLatestRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForexServiceServer).GetLatestRates(ctx, req.(*GetLatestRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForexService_GetHistoricalRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoricalRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForexServiceServer).GetHistoricalRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForexService_GetHistoricalRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForexServiceServer).GetHistoricalRates(ctx, req.(*GetHistoricalRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForexService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForexServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForexService_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForexServiceServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForexService_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForexServiceServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForexService_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForexServiceServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForexService_GetCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForexServiceServer).GetCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForexService_GetCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForexServiceServer).GetCurrency(ctx, req.(*GetCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForexService_StreamRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ForexServiceServer).StreamRates(m, &grpc.GenericServerStream[StreamRatesRequest, Rates]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ForexService_StreamRatesServer = grpc.ServerStreamingServer[Rates]
+
+// ForexService_ServiceDesc is the grpc.ServiceDesc for ForexService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ForexService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "forex.v1.ForexService",
+	HandlerType: (*ForexServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLatestRates",
+			Handler:    _ForexService_GetLatestRates_Handler,
+		},
+		{
+			MethodName: "GetHistoricalRates",
+			Handler:    _ForexService_GetHistoricalRates_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _ForexService_Convert_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _ForexService_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "GetCurrency",
+			Handler:    _ForexService_GetCurrency_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRates",
+			Handler:       _ForexService_StreamRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "forex/v1/forex.proto",
+}
@@ -0,0 +1,119 @@
+package grpcapi
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/kamaal111/forex-api/grpcapi/forexv1"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// HealthCheckInterval is the time between two readiness checks reported by the health service.
+var HealthCheckInterval = 30 * time.Second
+
+// NewGRPCServer registers server, the gRPC health-checking protocol and server reflection on a
+// new gRPC server, with logging and panic recovery on every call. The health service reports
+// SERVING until ReportHealth updates it.
+func NewGRPCServer(server *Server, options ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	options = append(options,
+		grpc.ChainUnaryInterceptor(logUnary, recoverUnary),
+		grpc.ChainStreamInterceptor(logStream, recoverStream),
+	)
+	grpcServer := grpc.NewServer(options...)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(forexv1.ForexService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	forexv1.RegisterForexServiceServer(grpcServer, server)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+	return grpcServer, healthServer
+}
+
+// ReportHealth runs the readiness checks of the rates service every interval and reports the
+// outcome on healthServer, for the server as a whole and for the forex service, until ctx is
+// done. Each check is bounded by handlers.ReadinessTimeout like the HTTP readiness probe.
+func (s *Server) ReportHealth(ctx context.Context, healthServer *health.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if !s.ready(ctx) {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(forexv1.ForexService_ServiceDesc.ServiceName, servingStatus)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) ready(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, handlers.ReadinessTimeout)
+	defer cancel()
+
+	service, closeService, err := s.Open(ctx)
+	if err != nil {
+		log.Printf("gRPC readiness check failed: %v", err)
+		return false
+	}
+	defer closeService()
+
+	report := service.CheckReadiness()
+	if report.Status != handlers.StatusOK {
+		log.Printf("gRPC readiness check failed: %v", report.Checks)
+		return false
+	}
+	return true
+}
+
+func logUnary(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	response, err := handler(ctx, request)
+	log.Printf("%s %s in %s", status.Code(err), info.FullMethod, time.Since(start))
+	return response, err
+}
+
+func logStream(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(server, stream)
+	log.Printf("%s %s in %s", status.Code(err), info.FullMethod, time.Since(start))
+	return err
+}
+
+func recoverUnary(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = recoveredError(info.FullMethod, recovered)
+		}
+	}()
+	return handler(ctx, request)
+}
+
+func recoverStream(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = recoveredError(info.FullMethod, recovered)
+		}
+	}()
+	return handler(server, stream)
+}
+
+func recoveredError(method string, recovered any) error {
+	log.Printf("panic serving %s: %v\n%s", method, recovered, debug.Stack())
+	return status.Error(codes.Internal, "Internal server error")
+}
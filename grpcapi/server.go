@@ -0,0 +1,252 @@
+// Package grpcapi serves the rates and currencies of the HTTP API over gRPC, on top of the same
+// handlers.RatesService. The service is defined in proto/forex/v1/forex.proto and its Go code
+// is generated into forexv1.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kamaal111/forex-api/grpcapi/forexv1"
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/locales"
+)
+
+// Server implements forexv1.ForexServiceServer.
+type Server struct {
+	forexv1.UnimplementedForexServiceServer
+
+	// Open opens the rates service for a call, tests swap it out to serve from a fake
+	// repository.
+	Open func(ctx context.Context) (*handlers.RatesService, func(), error)
+	// Feed pushes the rate updates sent by StreamRates.
+	Feed *handlers.RateFeed
+}
+
+func NewServer(feed *handlers.RateFeed) *Server {
+	return &Server{Open: handlers.OpenRatesService, Feed: feed}
+}
+
+func (s *Server) GetLatestRates(ctx context.Context, request *forexv1.GetLatestRatesRequest) (*forexv1.Rates, error) {
+	service, closeService, err := s.Open(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	defer closeService()
+
+	record, err := service.GetLatestRate(request.GetBase(), strings.Join(request.GetSymbols(), ","))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if record == nil {
+		return nil, status.Error(codes.NotFound, "rates not found")
+	}
+	return toRates(record), nil
+}
+
+func (s *Server) GetHistoricalRates(ctx context.Context, request *forexv1.GetHistoricalRatesRequest) (*forexv1.Rates, error) {
+	if request.GetDate() == "" {
+		return nil, status.Error(codes.InvalidArgument, "date is required")
+	}
+
+	service, closeService, err := s.Open(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	defer closeService()
+
+	record, err := service.GetRateOnDate(request.GetBase(), strings.Join(request.GetSymbols(), ","), request.GetDate())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if record == nil {
+		return nil, status.Error(codes.NotFound, "rates not found")
+	}
+	return toRates(record), nil
+}
+
+func (s *Server) Convert(ctx context.Context, request *forexv1.ConvertRequest) (*forexv1.ConvertResponse, error) {
+	service, closeService, err := s.Open(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	defer closeService()
+
+	record, err := service.Convert(request.GetFrom(), request.GetTo(), request.GetAmount(), request.GetDate())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if record == nil {
+		return nil, status.Error(codes.NotFound, "rate not found")
+	}
+	return &forexv1.ConvertResponse{
+		From:   record.From,
+		To:     record.To,
+		Amount: record.Amount,
+		Rate:   record.Rate,
+		Result: record.Result,
+		Date:   record.Date,
+	}, nil
+}
+
+func (s *Server) ListCurrencies(ctx context.Context, request *forexv1.ListCurrenciesRequest) (*forexv1.ListCurrenciesResponse, error) {
+	service, closeService, err := s.Open(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	defer closeService()
+
+	record, err := service.GetLocalizedNamedSymbols(locales.Negotiate(request.GetLocale(), ""))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if record == nil {
+		return nil, status.Error(codes.NotFound, "symbols not found")
+	}
+
+	response := &forexv1.ListCurrenciesResponse{Date: record.Date, Locale: record.Locale}
+	for _, named := range record.Data {
+		response.Currencies = append(response.Currencies, &forexv1.NamedCurrency{
+			Symbol:        named.Symbol,
+			Name:          named.Name,
+			Sign:          named.Sign,
+			SignPlacement: named.SignPlacement,
+		})
+	}
+	return response, nil
+}
+
+func (s *Server) GetCurrency(ctx context.Context, request *forexv1.GetCurrencyRequest) (*forexv1.Currency, error) {
+	record := handlers.LookupCurrency(request.GetCode(), locales.Negotiate(request.GetLocale(), ""))
+	if record == nil {
+		return nil, status.Error(codes.NotFound, "currency not found")
+	}
+
+	return &forexv1.Currency{
+		Code:          record.Code,
+		Locale:        record.Locale,
+		Name:          record.Name,
+		Sign:          record.Sign,
+		SignPlacement: record.SignPlacement,
+		NumericCode:   record.NumericCode,
+		MinorUnits:    int32(record.MinorUnits),
+		Countries:     record.Countries,
+		Status:        record.Status,
+		Withdrawn:     record.Withdrawn,
+		ReplacedBy:    record.ReplacedBy,
+	}, nil
+}
+
+// StreamRates sends the latest rates of every requested base, then the rates of the shared
+// feed whenever a newer date is published, until the client cancels.
+func (s *Server) StreamRates(request *forexv1.StreamRatesRequest, stream forexv1.ForexService_StreamRatesServer) error {
+	var bases []string
+	for _, base := range request.GetBases() {
+		if normalized := handlers.NormalizeBase(base); !slices.Contains(bases, normalized) {
+			bases = append(bases, normalized)
+		}
+	}
+	if len(bases) == 0 {
+		bases = []string{handlers.NormalizeBase("")}
+	}
+	// The symbols apply to every base, so none of the bases is left out of them.
+	symbols := handlers.MakeSymbolsArray(strings.Join(request.GetSymbols(), ","), "")
+
+	subscription := s.Feed.Subscribe(bases...)
+	defer subscription.Close()
+
+	service, closeService, err := s.Open(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	var current []*handlers.ExchangeRateRecord
+	for _, base := range bases {
+		record, err := service.GetLatestRate(base, "")
+		if err != nil {
+			closeService()
+			return toStatus(err)
+		}
+		if record != nil {
+			current = append(current, record)
+		}
+	}
+	closeService()
+
+	sent := map[string]string{}
+	send := func(record *handlers.ExchangeRateRecord) error {
+		if record.Date <= sent[record.Base] {
+			return nil
+		}
+		if err := stream.Send(toRates(selectRates(record, symbols))); err != nil {
+			return err
+		}
+		sent[record.Base] = record.Date
+		return nil
+	}
+
+	for _, record := range current {
+		if err := send(record); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case update, ok := <-subscription.Updates():
+			if !ok {
+				return nil
+			}
+			if err := send(update.Record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// selectRates keeps the rates of symbols, all of them when symbols is empty.
+func selectRates(record *handlers.ExchangeRateRecord, symbols []string) *handlers.ExchangeRateRecord {
+	if len(symbols) == 0 {
+		return record
+	}
+
+	selected := *record
+	selected.Rates = make(map[string]float64, len(symbols))
+	for _, symbol := range symbols {
+		if rate, ok := record.Rates[symbol]; ok {
+			selected.Rates[symbol] = rate
+		}
+	}
+	return &selected
+}
+
+func toRates(record *handlers.ExchangeRateRecord) *forexv1.Rates {
+	rates := &forexv1.Rates{Base: record.Base, Date: record.Date, Rates: record.Rates}
+	if freshness := record.Freshness; freshness != nil {
+		rates.Freshness = &forexv1.Freshness{
+			ExpectedDate:       freshness.ExpectedDate,
+			MissedPublications: int32(freshness.MissedPublications),
+			StaleSeconds:       freshness.StaleSeconds,
+			Stale:              freshness.Stale,
+		}
+	}
+	return rates
+}
+
+// toStatus turns the errors of the rates service into gRPC statuses: bad input becomes
+// InvalidArgument and anything else Internal.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, handlers.ErrInvalidDate),
+		errors.Is(err, handlers.ErrInvalidDateRange),
+		errors.Is(err, handlers.ErrUnknownCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,266 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/kamaal111/forex-api/grpcapi/forexv1"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// fakeRepository serves latest rates whose date can be moved forward during a test.
+type fakeRepository struct {
+	mu   sync.Mutex
+	date string
+}
+
+func (r *fakeRepository) publish(date string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.date = date
+}
+
+func (r *fakeRepository) GetLatestRate(base string) (*handlers.ExchangeRateRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if base != "EUR" {
+		return nil, nil
+	}
+	return &handlers.ExchangeRateRecord{Base: base, Date: r.date, Rates: map[string]float64{"USD": 1.08, "GBP": 0.85}}, nil
+}
+
+func (r *fakeRepository) GetRateOnDate(base string, date string) (*handlers.ExchangeRateRecord, error) {
+	return &handlers.ExchangeRateRecord{Base: base, Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03, "GBP": 0.83}}, nil
+}
+
+func (r *fakeRepository) GetRatesBetween(base string, start string, end string) ([]handlers.ExchangeRateRecord, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) GetAllSymbols() (*handlers.SymbolsRecord, error) {
+	return &handlers.SymbolsRecord{Date: "2025-01-30", Symbols: []string{"EUR", "USD"}}, nil
+}
+
+// dialServer serves repository over an in-memory connection and returns clients of the forex
+// and health services.
+func dialServer(t *testing.T, repository *fakeRepository) (forexv1.ForexServiceClient, healthpb.HealthClient) {
+	t.Helper()
+
+	open := func(ctx context.Context) (*handlers.RatesService, func(), error) {
+		return handlers.NewRatesService(repository), func() {}, nil
+	}
+	feed := handlers.NewRateFeed(10 * time.Millisecond)
+	feed.Open = open
+	server := &Server{Open: open, Feed: feed}
+
+	grpcServer, _ := NewGRPCServer(server)
+	listener := bufconn.Listen(1 << 20)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	connection, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { connection.Close() })
+
+	return forexv1.NewForexServiceClient(connection), healthpb.NewHealthClient(connection)
+}
+
+func TestServer_GetLatestRates(t *testing.T) {
+	client, _ := dialServer(t, &fakeRepository{date: "2025-01-30"})
+
+	rates, err := client.GetLatestRates(context.Background(), &forexv1.GetLatestRatesRequest{Base: "eur", Symbols: []string{"USD"}})
+	if err != nil {
+		t.Fatalf("GetLatestRates() error = %v", err)
+	}
+	if rates.GetBase() != "EUR" || rates.GetDate() != "2025-01-30" || len(rates.GetRates()) != 1 || rates.GetRates()["USD"] != 1.08 {
+		t.Errorf("GetLatestRates() = %v", rates)
+	}
+	if rates.GetFreshness() == nil {
+		t.Error("GetLatestRates() freshness = nil, want the freshness of the rates")
+	}
+
+	_, err = client.GetLatestRates(context.Background(), &forexv1.GetLatestRatesRequest{Base: "USD"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetLatestRates() of a base without rates error = %v, want %s", err, codes.NotFound)
+	}
+}
+
+func TestServer_GetHistoricalRates(t *testing.T) {
+	client, _ := dialServer(t, &fakeRepository{date: "2025-01-30"})
+
+	tests := []struct {
+		name     string
+		request  *forexv1.GetHistoricalRatesRequest
+		wantCode codes.Code
+		wantDate string
+	}{
+		{name: "rates on date", request: &forexv1.GetHistoricalRatesRequest{Date: "2025-01-05"}, wantCode: codes.OK, wantDate: "2025-01-03"},
+		{name: "missing date", request: &forexv1.GetHistoricalRatesRequest{}, wantCode: codes.InvalidArgument},
+		{name: "invalid date", request: &forexv1.GetHistoricalRatesRequest{Date: "05/01/2025"}, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := client.GetHistoricalRates(context.Background(), tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetHistoricalRates() error = %v, want %s", err, tt.wantCode)
+			}
+			if rates.GetDate() != tt.wantDate {
+				t.Errorf("GetHistoricalRates() date = %q, want %q", rates.GetDate(), tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestServer_Convert(t *testing.T) {
+	client, _ := dialServer(t, &fakeRepository{date: "2025-01-30"})
+
+	response, err := client.Convert(context.Background(), &forexv1.ConvertRequest{From: "EUR", To: "GBP", Amount: 100})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if response.GetRate() != 0.85 || response.GetResult() != 85 || response.GetDate() != "2025-01-30" {
+		t.Errorf("Convert() = %v", response)
+	}
+
+	_, err = client.Convert(context.Background(), &forexv1.ConvertRequest{From: "EUR", To: "XYZ", Amount: 100})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Convert() to an unknown currency error = %v, want %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestServer_Currencies(t *testing.T) {
+	client, _ := dialServer(t, &fakeRepository{date: "2025-01-30"})
+
+	list, err := client.ListCurrencies(context.Background(), &forexv1.ListCurrenciesRequest{Locale: "de"})
+	if err != nil {
+		t.Fatalf("ListCurrencies() error = %v", err)
+	}
+	if list.GetLocale() != "de" || len(list.GetCurrencies()) != 2 || list.GetCurrencies()[1].GetName() != "US-Dollar" {
+		t.Errorf("ListCurrencies() = %v", list)
+	}
+
+	currency, err := client.GetCurrency(context.Background(), &forexv1.GetCurrencyRequest{Code: "jpy"})
+	if err != nil {
+		t.Fatalf("GetCurrency() error = %v", err)
+	}
+	if currency.GetCode() != "JPY" || currency.GetNumericCode() != "392" || currency.GetMinorUnits() != 0 {
+		t.Errorf("GetCurrency() = %v", currency)
+	}
+
+	_, err = client.GetCurrency(context.Background(), &forexv1.GetCurrencyRequest{Code: "XYZ"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetCurrency() of an unknown code error = %v, want %s", err, codes.NotFound)
+	}
+}
+
+func TestServer_StreamRates(t *testing.T) {
+	repository := &fakeRepository{date: "2025-01-30"}
+	client, _ := dialServer(t, repository)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamRates(ctx, &forexv1.StreamRatesRequest{Symbols: []string{"GBP"}})
+	if err != nil {
+		t.Fatalf("StreamRates() error = %v", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if first.GetDate() != "2025-01-30" || len(first.GetRates()) != 1 || first.GetRates()["GBP"] != 0.85 {
+		t.Errorf("first rates = %v, want the GBP rate of 2025-01-30", first)
+	}
+
+	repository.publish("2025-01-31")
+	update, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if update.GetDate() != "2025-01-31" {
+		t.Errorf("update date = %q, want %q", update.GetDate(), "2025-01-31")
+	}
+}
+
+func TestServer_Health(t *testing.T) {
+	_, client := dialServer(t, &fakeRepository{date: "2025-01-30"})
+
+	for _, service := range []string{"", forexv1.ForexService_ServiceDesc.ServiceName} {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		if response.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Check(%q) = %s, want %s", service, response.GetStatus(), healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+}
+
+func TestRecoverUnary(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: forexv1.ForexService_Convert_FullMethodName}
+	_, err := recoverUnary(context.Background(), nil, info, func(ctx context.Context, request any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("recoverUnary() error = %v, want %s", err, codes.Internal)
+	}
+}
+
+func TestServer_ReportHealth(t *testing.T) {
+	repository := &fakeRepository{date: "2025-01-30"}
+	server := &Server{Open: func(ctx context.Context) (*handlers.RatesService, func(), error) {
+		service := handlers.NewRatesService(repository)
+		service.Now = func() time.Time { return time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC) }
+		return service, func() {}, nil
+	}}
+	_, healthServer := NewGRPCServer(server)
+
+	// A cancelled context reports once and returns.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	server.ReportHealth(ctx, healthServer, time.Hour)
+
+	response, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if response.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Check() = %s, want %s for rates overdue for a month", response.GetStatus(), healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func TestServer_ReportHealth_Deadline(t *testing.T) {
+	var deadline time.Time
+	server := &Server{Open: func(ctx context.Context) (*handlers.RatesService, func(), error) {
+		deadline, _ = ctx.Deadline()
+		return nil, nil, context.DeadlineExceeded
+	}}
+	_, healthServer := NewGRPCServer(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	server.ReportHealth(ctx, healthServer, time.Hour)
+
+	if deadline.IsZero() || deadline.After(time.Now().Add(handlers.ReadinessTimeout)) {
+		t.Errorf("readiness check deadline = %v, want within %s of the check", deadline, handlers.ReadinessTimeout)
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type (
+	BatchQuery  = api.BatchQuery
+	BatchResult = api.BatchResult
+	BatchRecord = api.BatchRecord
+)
+
+const (
+	MaxBatchQueries = api.MaxBatchQueries
+	// batchConcurrency bounds how many queries of a batch hit the repository at once.
+	batchConcurrency  = 4
+	maxBatchBodyBytes = 1 << 20
+)
+
+// GetBatch runs every query concurrently, with at most batchConcurrency in flight, and
+// returns one result per query in the same order. A failing query doesn't fail the others.
+func (s *RatesService) GetBatch(queries []BatchQuery) *BatchRecord {
+	results := make([]BatchResult, len(queries))
+	semaphore := make(chan struct{}, batchConcurrency)
+
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = s.runBatchQuery(query)
+		}()
+	}
+	wg.Wait()
+
+	return &BatchRecord{Results: results}
+}
+
+func (s *RatesService) runBatchQuery(query BatchQuery) BatchResult {
+	result := BatchResult{Query: query}
+
+	record, err := s.GetRateOnDate(query.Base, strings.Join(query.Symbols, ","), query.Date)
+	switch {
+	case errors.Is(err, ErrInvalidDate):
+		result.Error = &utils.Error{Message: err.Error(), Status: http.StatusBadRequest}
+	case err != nil:
+		result.Error = &utils.Error{Message: err.Error(), Status: http.StatusInternalServerError}
+	case record == nil:
+		result.Error = &utils.Error{Message: "Rates not found", Status: http.StatusNotFound}
+	default:
+		result.Data = record
+	}
+	return result
+}
+
+// PostBatch handles requests for several rate queries in one round trip.
+//
+// @Summary      Get rates for several queries at once
+// @Description  Runs a list of rate queries concurrently and returns a result or an error for each of them, in request order.
+// @Description  Each query takes a base, optional symbols and an optional date; without a date the latest rates are returned.
+// @Tags         rates
+// @Accept       json
+// @Produce      json
+// @Param        queries  body      []api.BatchQuery  true  "Queries to run, at most 25"
+// @Success      200      {object}  api.BatchRecord
+// @Failure      400      {object}  api.Error
+// @Failure      405      {object}  api.Error
+// @Failure      500      {object}  api.Error
+// @Router       /v1/rates/batch [post]
+func PostBatch(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		utils.ErrorHandler(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var queries []BatchQuery
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxBatchBodyBytes))
+	if err := decoder.Decode(&queries); err != nil {
+		utils.ErrorHandler(writer, "body must be a JSON list of queries", http.StatusBadRequest)
+		return
+	}
+	if len(queries) == 0 {
+		utils.ErrorHandler(writer, "at least one query is required", http.StatusBadRequest)
+		return
+	}
+	if len(queries) > MaxBatchQueries {
+		utils.ErrorHandler(writer, fmt.Sprintf("at most %d queries are allowed", MaxBatchQueries), http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	utils.WriteJSON(writer, http.StatusOK, service.GetBatch(queries))
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRatesService_GetBatch(t *testing.T) {
+	mockRepo := &MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			if base == "GBP" {
+				return nil, nil
+			}
+			return &ExchangeRateRecord{Base: base, Date: "2025-12-05", Rates: map[string]float64{"USD": 1.09, "JPY": 168.2}}, nil
+		},
+		GetRateOnDateFunc: func(base string, date string) (*ExchangeRateRecord, error) {
+			if date < "2000-01-01" {
+				return nil, errors.New("database unavailable")
+			}
+			return &ExchangeRateRecord{Base: base, Date: date, Rates: map[string]float64{"USD": 1.05}}, nil
+		},
+	}
+
+	queries := []BatchQuery{
+		{Base: "EUR", Symbols: []string{"USD"}},
+		{Base: "GBP"},
+		{Base: "EUR", Date: "2025-11-20"},
+		{Base: "EUR", Date: "yesterday"},
+		{Base: "EUR", Date: "1999-01-04"},
+	}
+	wantStatus := []int{0, http.StatusNotFound, 0, http.StatusBadRequest, http.StatusInternalServerError}
+
+	got := NewRatesService(mockRepo).GetBatch(queries)
+
+	if len(got.Results) != len(queries) {
+		t.Fatalf("GetBatch() returned %d results, want %d", len(got.Results), len(queries))
+	}
+	for i, result := range got.Results {
+		if result.Query.Base != queries[i].Base || result.Query.Date != queries[i].Date {
+			t.Errorf("GetBatch() result %d query = %+v, want %+v", i, result.Query, queries[i])
+		}
+
+		if wantStatus[i] == 0 {
+			if result.Error != nil || result.Data == nil {
+				t.Errorf("GetBatch() result %d = %+v, want data", i, result)
+			}
+			continue
+		}
+		if result.Error == nil || result.Error.Status != wantStatus[i] {
+			t.Errorf("GetBatch() result %d error = %+v, want status %d", i, result.Error, wantStatus[i])
+		}
+	}
+
+	if rates := got.Results[0].Data.Rates; len(rates) != 1 || rates["USD"] != 1.09 {
+		t.Errorf("GetBatch() result 0 rates = %v, want only USD", rates)
+	}
+}
+
+func TestPostBatchHandler(t *testing.T) {
+	useMockRepository(t, &MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			return &ExchangeRateRecord{Base: base, Date: "2025-12-05", Rates: map[string]float64{"USD": 1.09}}, nil
+		},
+	})
+
+	tooMany := "[" + strings.Repeat(`{"base":"EUR"},`, MaxBatchQueries) + `{"base":"EUR"}]`
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		wantStatusCode int
+		wantResults    int
+	}{
+		{name: "runs every query", method: http.MethodPost, body: `[{"base":"EUR"},{"base":"USD","symbols":["EUR"]}]`, wantStatusCode: http.StatusOK, wantResults: 2},
+		{name: "rejects other methods", method: http.MethodGet, wantStatusCode: http.StatusMethodNotAllowed},
+		{name: "rejects malformed body", method: http.MethodPost, body: `{"base":"EUR"}`, wantStatusCode: http.StatusBadRequest},
+		{name: "rejects empty batch", method: http.MethodPost, body: `[]`, wantStatusCode: http.StatusBadRequest},
+		{name: "rejects too many queries", method: http.MethodPost, body: tooMany, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, BatchPath, strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+
+			PostBatch(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("PostBatch() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode == http.StatusMethodNotAllowed && recorder.Header().Get("Allow") != http.MethodPost {
+				t.Errorf("PostBatch() Allow = %q, want %q", recorder.Header().Get("Allow"), http.MethodPost)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var record BatchRecord
+			if err := json.Unmarshal(recorder.Body.Bytes(), &record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(record.Results) != tt.wantResults {
+				t.Errorf("PostBatch() results = %d, want %d", len(record.Results), tt.wantResults)
+			}
+		})
+	}
+}
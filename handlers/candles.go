@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type (
+	Candle        = api.Candle
+	CandlesRecord = api.CandlesRecord
+)
+
+const (
+	FormatJSON = api.FormatJSON
+	FormatCSV  = api.FormatCSV
+)
+
+var ErrInvalidFormat = errors.New("format must be one of: json, csv")
+
+// ResampleOHLC builds a series of open, high, low and close candles per interval from the
+// daily rates published from start to end, both YYYY-MM-DD. It returns nil when no rates were
+// published in the range.
+func (s *RatesService) ResampleOHLC(base string, symbols string, start string, end string, interval string) (*CandlesRecord, error) {
+	startDay, endDay, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	interval, err = parseInterval(interval, IntervalMonth, IntervalWeek, IntervalMonth, IntervalYear)
+	if err != nil {
+		return nil, err
+	}
+	// A yearly candle summarizes a year by itself, so only shorter intervals are limited.
+	if interval != IntervalYear {
+		if err := limitDateRange(startDay, endDay); err != nil {
+			return nil, err
+		}
+	}
+
+	normalizedBase := NormalizeBase(base)
+
+	records, err := s.Repository.GetRatesBetween(normalizedBase, startDay.Format(time.DateOnly), endDay.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	periods := groupByPeriod(records, interval, startDay, endDay)
+	if len(periods) == 0 {
+		return nil, nil
+	}
+
+	symbolsArray := MakeSymbolsArray(symbols, normalizedBase)
+	candles := map[string][]Candle{}
+	for _, period := range periods {
+		periodCandles := map[string]*Candle{}
+		for _, record := range period.Records {
+			for symbol, rate := range filterRates(&record, symbolsArray, nil).Rates {
+				candle, ok := periodCandles[symbol]
+				if !ok {
+					periodCandles[symbol] = &Candle{
+						Start: period.Start.Format(time.DateOnly),
+						End:   period.End.Format(time.DateOnly),
+						Open:  rate,
+						High:  rate,
+						Low:   rate,
+						Close: rate,
+					}
+					continue
+				}
+				candle.High = max(candle.High, rate)
+				candle.Low = min(candle.Low, rate)
+				candle.Close = rate
+			}
+		}
+
+		for symbol, candle := range periodCandles {
+			candles[symbol] = append(candles[symbol], *candle)
+		}
+	}
+
+	return &CandlesRecord{
+		Base:      normalizedBase,
+		StartDate: startDay.Format(time.DateOnly),
+		EndDate:   endDay.Format(time.DateOnly),
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
+}
+
+// negotiateFormat picks the response format from the format query parameter, falling back to
+// text/csv in the Accept header and then to JSON.
+func negotiateFormat(request *http.Request) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(request.URL.Query().Get("format"))); format {
+	case FormatJSON, FormatCSV:
+		return format, nil
+	case "":
+	default:
+		return "", ErrInvalidFormat
+	}
+
+	for accepted := range strings.SplitSeq(request.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == "text/csv" {
+			return FormatCSV, nil
+		}
+	}
+	return FormatJSON, nil
+}
+
+// writeCandlesCSV writes one row per candle, ordered by currency code and then by date.
+func writeCandlesCSV(writer http.ResponseWriter, record *CandlesRecord) {
+	symbols := make([]string, 0, len(record.Candles))
+	for symbol := range record.Candles {
+		symbols = append(symbols, symbol)
+	}
+	slices.Sort(symbols)
+
+	writer.Header().Set("content-type", "text/csv; charset=utf-8")
+	output := csv.NewWriter(writer)
+	output.Write([]string{"base", "symbol", "start", "end", "open", "high", "low", "close"})
+	for _, symbol := range symbols {
+		for _, candle := range record.Candles[symbol] {
+			output.Write([]string{
+				record.Base,
+				symbol,
+				candle.Start,
+				candle.End,
+				strconv.FormatFloat(candle.Open, 'f', -1, 64),
+				strconv.FormatFloat(candle.High, 'f', -1, 64),
+				strconv.FormatFloat(candle.Low, 'f', -1, 64),
+				strconv.FormatFloat(candle.Close, 'f', -1, 64),
+			})
+		}
+	}
+	output.Flush()
+}
+
+// GetCandles handles requests for OHLC candles of the rates over a period.
+//
+// @Summary      Get OHLC candles
+// @Description  Resamples the daily rates of a date range into open, high, low and close candles per week, month or year.
+// @Description  Weeks run from Monday to Sunday. The first and last candles are clipped to the requested range, and periods without publications are left out.
+// @Description  Responds with CSV, one row per candle, when format is csv or the Accept header asks for text/csv.
+// @Tags         rates
+// @Produce      json
+// @Produce      text/csv
+// @Param        start     query     string  true   "Start date (YYYY-MM-DD)"  Format(date)
+// @Param        end       query     string  true   "End date (YYYY-MM-DD); the range spans at most 366 days unless the interval is year"  Format(date)
+// @Param        base      query     string  false  "Base currency code; unsupported codes fall back to the default (default: EUR)"  minlength(3)  maxlength(3)
+// @Param        symbols   query     string  false  "Comma-separated list of target currency symbols"
+// @Param        interval  query     string  false  "Candle interval (default: month)"  Enums(week, month, year)
+// @Param        format    query     string  false  "Response format (default: json)"  Enums(json, csv)
+// @Success      200       {object}  api.CandlesRecord
+// @Failure      400       {object}  api.Error
+// @Failure      404       {object}  api.Error
+// @Failure      500       {object}  api.Error
+// @Router       /v1/rates/ohlc [get]
+func GetCandles(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	if query.Get("start") == "" || query.Get("end") == "" {
+		utils.ErrorHandler(writer, "start and end are required", http.StatusBadRequest)
+		return
+	}
+
+	format, err := negotiateFormat(request)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writer.Header().Add("Vary", "Accept")
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	record, err := service.ResampleOHLC(query.Get("base"), query.Get("symbols"), query.Get("start"), query.Get("end"), query.Get("interval"))
+	if errors.Is(err, ErrInvalidDate) || errors.Is(err, ErrInvalidDateRange) || errors.Is(err, ErrInvalidInterval) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if record == nil {
+		utils.ErrorHandler(writer, "Rates not found", http.StatusNotFound)
+		return
+	}
+
+	if format == FormatCSV {
+		writeCandlesCSV(writer, record)
+		return
+	}
+
+	output, err := json.Marshal(record)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(output)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRatesService_ResampleOHLC(t *testing.T) {
+	service := NewRatesService(newRangeRepository(januaryRates))
+
+	got, err := service.ResampleOHLC("EUR", "", "2025-01-01", "2025-02-28", IntervalMonth)
+	if err != nil {
+		t.Fatalf("ResampleOHLC() error = %v", err)
+	}
+
+	usd := got.Candles["USD"]
+	if len(usd) != 2 {
+		t.Fatalf("ResampleOHLC() returned %d USD candles, want 2", len(usd))
+	}
+
+	want := []Candle{
+		{Start: "2025-01-01", End: "2025-01-31", Open: 1.04, High: 1.08, Low: 1.03, Close: 1.08},
+		{Start: "2025-02-01", End: "2025-02-28", Open: 1.02, High: 1.02, Low: 1.02, Close: 1.02},
+	}
+	for i, candle := range usd {
+		if candle != want[i] {
+			t.Errorf("ResampleOHLC() USD candle %d = %+v, want %+v", i, candle, want[i])
+		}
+	}
+	if len(got.Candles["GBP"]) != 2 {
+		t.Errorf("ResampleOHLC() returned %d GBP candles, want 2", len(got.Candles["GBP"]))
+	}
+}
+
+func TestRatesService_ResampleOHLC_Yearly(t *testing.T) {
+	got, err := NewRatesService(newRangeRepository(januaryRates)).ResampleOHLC("EUR", "USD", "2025-01-01", "2025-12-31", IntervalYear)
+	if err != nil {
+		t.Fatalf("ResampleOHLC() error = %v", err)
+	}
+
+	want := Candle{Start: "2025-01-01", End: "2025-12-31", Open: 1.04, High: 1.08, Low: 1.02, Close: 1.02}
+	if len(got.Candles) != 1 || len(got.Candles["USD"]) != 1 || got.Candles["USD"][0] != want {
+		t.Errorf("ResampleOHLC() = %+v, want a single USD candle %+v", got.Candles, want)
+	}
+}
+
+func TestRatesService_ResampleOHLC_RangeLimit(t *testing.T) {
+	service := NewRatesService(newRangeRepository(januaryRates))
+
+	if _, err := service.ResampleOHLC("EUR", "USD", "2020-01-01", "2025-12-31", IntervalYear); err != nil {
+		t.Errorf("ResampleOHLC(year) over six years error = %v", err)
+	}
+	if _, err := service.ResampleOHLC("EUR", "USD", "2020-01-01", "2025-12-31", IntervalWeek); !errors.Is(err, ErrInvalidDateRange) {
+		t.Errorf("ResampleOHLC(week) over six years error = %v, want %v", err, ErrInvalidDateRange)
+	}
+}
+
+func TestRatesService_ResampleOHLC_InvalidInterval(t *testing.T) {
+	_, err := NewRatesService(newRangeRepository(januaryRates)).ResampleOHLC("EUR", "", "2025-01-01", "2025-12-31", "day")
+	if !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("ResampleOHLC() error = %v, want %v", err, ErrInvalidInterval)
+	}
+}
+
+func TestGetCandlesHandler(t *testing.T) {
+	useMockRepository(t, newRangeRepository(januaryRates))
+
+	tests := []struct {
+		name            string
+		query           string
+		accept          string
+		wantStatusCode  int
+		wantContentType string
+	}{
+		{name: "JSON by default", query: "?start=2025-01-01&end=2025-02-28", wantStatusCode: http.StatusOK, wantContentType: "application/json"},
+		{name: "CSV by format parameter", query: "?start=2025-01-01&end=2025-02-28&format=csv", wantStatusCode: http.StatusOK, wantContentType: "text/csv; charset=utf-8"},
+		{name: "CSV by Accept header", query: "?start=2025-01-01&end=2025-02-28", accept: "text/csv", wantStatusCode: http.StatusOK, wantContentType: "text/csv; charset=utf-8"},
+		{name: "format parameter overrides Accept", query: "?start=2025-01-01&end=2025-02-28&format=json", accept: "text/csv", wantStatusCode: http.StatusOK, wantContentType: "application/json"},
+		{name: "invalid format", query: "?start=2025-01-01&end=2025-02-28&format=xml", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid interval", query: "?start=2025-01-01&end=2025-02-28&interval=day", wantStatusCode: http.StatusBadRequest},
+		{name: "missing dates", query: "", wantStatusCode: http.StatusBadRequest},
+		{name: "no rates in range", query: "?start=2024-01-01&end=2024-12-31", wantStatusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, CandlesPath+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			recorder := httptest.NewRecorder()
+
+			GetCandles(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetCandles() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			if contentType := recorder.Header().Get("content-type"); contentType != tt.wantContentType {
+				t.Errorf("GetCandles() content-type = %q, want %q", contentType, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestGetCandlesHandler_CSV(t *testing.T) {
+	useMockRepository(t, newRangeRepository(januaryRates))
+
+	req := httptest.NewRequest(http.MethodGet, CandlesPath+"?start=2025-01-01&end=2025-02-28&format=csv", nil)
+	recorder := httptest.NewRecorder()
+
+	GetCandles(recorder, req)
+
+	want := strings.Join([]string{
+		"base,symbol,start,end,open,high,low,close",
+		"EUR,GBP,2025-01-01,2025-01-31,0.84,0.84,0.84,0.84",
+		"EUR,GBP,2025-02-01,2025-02-28,0.83,0.83,0.83,0.83",
+		"EUR,USD,2025-01-01,2025-01-31,1.04,1.08,1.03,1.08",
+		"EUR,USD,2025-02-01,2025-02-28,1.02,1.02,1.02,1.02",
+	}, "\n") + "\n"
+	if got := recorder.Body.String(); got != want {
+		t.Errorf("GetCandles() CSV =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetCandlesHandler_JSON(t *testing.T) {
+	useMockRepository(t, newRangeRepository(januaryRates))
+
+	req := httptest.NewRequest(http.MethodGet, CandlesPath+"?start=2025-01-01&end=2025-02-28&interval=week&symbols=USD", nil)
+	recorder := httptest.NewRecorder()
+
+	GetCandles(recorder, req)
+
+	var record CandlesRecord
+	if err := json.Unmarshal(recorder.Body.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if record.Interval != IntervalWeek || len(record.Candles["USD"]) != 2 {
+		t.Errorf("GetCandles() = %+v, want 2 weekly USD candles", record)
+	}
+}
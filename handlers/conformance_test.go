@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/docs"
+)
+
+// conformanceRepository serves the fixtures of the other handler tests, so every endpoint
+// has data to answer with.
+func conformanceRepository() *MockRatesRepository {
+	repo := newRangeRepository(januaryRates)
+	repo.GetRateOnDateFunc = newFluctuationRepository().GetRateOnDateFunc
+	repo.GetLatestRateFunc = func(base string) (*ExchangeRateRecord, error) {
+		if base != "EUR" {
+			return nil, nil
+		}
+		return &ExchangeRateRecord{Base: "EUR", Date: time.Now().Format(time.DateOnly), Rates: map[string]float64{"USD": 1.08, "GBP": 0.86}}, nil
+	}
+	repo.GetAllSymbolsFunc = func() (*SymbolsRecord, error) {
+		return &SymbolsRecord{Date: "2025-11-21", Symbols: []string{"EUR", "USD", "GBP"}}, nil
+	}
+	return repo
+}
+
+// conformanceMux routes the documented operations of this package like the routers do.
+func conformanceMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LatestPath, GetLatest)
+	mux.HandleFunc(HistoricalPath, GetHistorical)
+	mux.HandleFunc(SymbolsPath, GetSymbols)
+	mux.HandleFunc(BatchPath, PostBatch)
+	mux.HandleFunc(FluctuationPath, GetFluctuation)
+	mux.HandleFunc(StatsPath, GetStats)
+	mux.HandleFunc(CandlesPath, GetCandles)
+	mux.HandleFunc(CurrenciesPath, GetCurrencies)
+	mux.HandleFunc(CurrencyPath, GetCurrency)
+	mux.HandleFunc(FormatPath, GetFormat)
+	mux.HandleFunc(V2LatestPath, GetLatestV2)
+	mux.HandleFunc(V2SymbolsPath, GetSymbolsV2)
+	mux.HandleFunc(V2CurrenciesPath, GetCurrenciesV2)
+	mux.HandleFunc(GraphQLPath, ServeGraphQL)
+	mux.HandleFunc(OpenAPISpecPath, GetOpenAPISpec)
+	mux.HandleFunc(OpenAPI3Path, GetOpenAPI3Spec)
+	mux.HandleFunc(HealthPath, GetHealth)
+	mux.HandleFunc(ReadinessPath, GetReadiness)
+	mux.HandleFunc(VersionPath, GetVersion)
+	return mux
+}
+
+// TestSpecConformance runs every documented operation and fails when a response doesn't match
+// the spec, or when the documented query parameters reject a request the handler serves.
+func TestSpecConformance(t *testing.T) {
+	validator, err := docs.NewValidator()
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+	useMockRepository(t, conformanceRepository())
+	mux := conformanceMux()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+	}{
+		{name: "latest rates", method: http.MethodGet, target: LatestPath + "?base=EUR&symbols=USD"},
+		{name: "latest rates of a base without data", method: http.MethodGet, target: LatestPath + "?base=USD"},
+		{name: "historical rates", method: http.MethodGet, target: HistoricalPath + "?date=2025-03-01&symbols=USD"},
+		{name: "historical rates before the first rates", method: http.MethodGet, target: HistoricalPath + "?date=2024-01-01"},
+		{name: "historical rates with an invalid date", method: http.MethodGet, target: HistoricalPath + "?date=2025-13-01"},
+		{name: "symbols", method: http.MethodGet, target: SymbolsPath + "?sort=code"},
+		{name: "symbols with an invalid sort", method: http.MethodGet, target: SymbolsPath + "?sort=size"},
+		{name: "batch", method: http.MethodPost, target: BatchPath, body: `[{"base":"EUR"},{"base":"USD","date":"2025-03-01"},{"base":"EUR","date":"2025-13-01"}]`},
+		{name: "batch with a malformed body", method: http.MethodPost, target: BatchPath, body: `{`},
+		{name: "fluctuation", method: http.MethodGet, target: FluctuationPath + "?start=2025-01-04&end=2025-06-07&symbols=USD"},
+		{name: "fluctuation before the first rates", method: http.MethodGet, target: FluctuationPath + "?start=2024-01-01&end=2024-02-01"},
+		{name: "fluctuation without dates", method: http.MethodGet, target: FluctuationPath},
+		{name: "stats", method: http.MethodGet, target: StatsPath + "?start=2025-01-01&end=2025-02-28&interval=week"},
+		{name: "stats without rates", method: http.MethodGet, target: StatsPath + "?start=2024-01-01&end=2024-02-01"},
+		{name: "stats with an invalid interval", method: http.MethodGet, target: StatsPath + "?start=2025-01-01&end=2025-02-28&interval=day"},
+		{name: "candles", method: http.MethodGet, target: CandlesPath + "?start=2025-01-01&end=2025-02-28"},
+		{name: "candles as CSV", method: http.MethodGet, target: CandlesPath + "?start=2025-01-01&end=2025-02-28&format=csv"},
+		{name: "candles without rates", method: http.MethodGet, target: CandlesPath + "?start=2024-01-01&end=2024-02-01"},
+		{name: "candles with an invalid format", method: http.MethodGet, target: CandlesPath + "?start=2025-01-01&end=2025-02-28&format=xml"},
+		{name: "currencies", method: http.MethodGet, target: CurrenciesPath + "?sort=name&locale=de"},
+		{name: "currencies with an invalid sort", method: http.MethodGet, target: CurrenciesPath + "?sort=size"},
+		{name: "currency", method: http.MethodGet, target: "/v1/currencies/JPY?locale=ja"},
+		{name: "unknown currency", method: http.MethodGet, target: "/v1/currencies/XYZ"},
+		{name: "format", method: http.MethodGet, target: FormatPath + "?amount=1234.5&currency=EUR&locale=de"},
+		{name: "format without an amount", method: http.MethodGet, target: FormatPath + "?currency=EUR"},
+		{name: "v2 latest rates", method: http.MethodGet, target: V2LatestPath + "?base=EUR&symbols=USD"},
+		{name: "v2 latest rates of a base without data", method: http.MethodGet, target: V2LatestPath + "?base=USD"},
+		{name: "v2 symbols", method: http.MethodGet, target: V2SymbolsPath + "?sort=name&page=2&per_page=2"},
+		{name: "v2 symbols with an invalid page", method: http.MethodGet, target: V2SymbolsPath + "?page=0"},
+		{name: "v2 currencies", method: http.MethodGet, target: V2CurrenciesPath + "?sort=code&locale=de&per_page=1"},
+		{name: "v2 currencies past the last page", method: http.MethodGet, target: V2CurrenciesPath + "?page=5"},
+		{name: "GraphQL query", method: http.MethodPost, target: GraphQLPath, body: `{"query":"{ latest { date rates(symbols: [\"USD\"]) freshness { stale } } currencies { symbol } }"}`},
+		{name: "GraphQL query with an unknown field", method: http.MethodPost, target: GraphQLPath, body: `{"query":"{ latest { price } }"}`},
+		{name: "GraphQL query with a field error", method: http.MethodPost, target: GraphQLPath, body: `{"query":"{ historical(date: \"yesterday\") { date } }"}`},
+		{name: "Swagger 2.0 spec", method: http.MethodGet, target: OpenAPISpecPath},
+		{name: "OpenAPI 3.1 spec", method: http.MethodGet, target: OpenAPI3Path},
+		{name: "OpenAPI 3.1 spec in YAML", method: http.MethodGet, target: OpenAPI3Path + "?format=yaml"},
+		{name: "health", method: http.MethodGet, target: HealthPath},
+		{name: "readiness", method: http.MethodGet, target: ReadinessPath},
+		{name: "version", method: http.MethodGet, target: VersionPath},
+	}
+
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code < 400 {
+				if err := validator.ValidateQuery(req); err != nil {
+					t.Errorf("ValidateQuery() error = %v, but the handler answered %d", err, recorder.Code)
+				}
+			}
+			if err := validator.ValidateResponse(req, recorder.Code, recorder.Header(), recorder.Body.Bytes()); err != nil {
+				t.Errorf("ValidateResponse() for status %d error = %v", recorder.Code, err)
+			}
+		})
+
+		pattern := tt.target
+		if strings.HasPrefix(pattern, "/v1/currencies/") {
+			pattern = CurrencyPath
+		}
+		pattern, _, _ = strings.Cut(pattern, "?")
+		covered[tt.method+" "+pattern] = true
+	}
+
+	document, err := docs.OpenAPI3()
+	if err != nil {
+		t.Fatalf("OpenAPI3() error = %v", err)
+	}
+	for path, item := range document.Paths.Map() {
+		for method, operation := range item.Operations() {
+			// Webhooks and the admin API are served by their own packages and tested there, and
+			// the stream never ends, so its responses can't be validated.
+			if operation.Tags[0] == "webhooks" || operation.Tags[0] == "admin" || path == StreamPath {
+				continue
+			}
+			if !covered[method+" "+path] {
+				t.Errorf("%s %s is documented but not covered by TestSpecConformance", method, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import "strings"
+
+type ConversionRecord struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Amount float64 `json:"amount"`
+	Rate   float64 `json:"rate"`
+	Result float64 `json:"result"`
+	// Date is the publication date of the rate.
+	Date string `json:"date"`
+}
+
+// Convert converts amount from one currency to another at the rate in effect on date
+// (YYYY-MM-DD), or at the latest rate when date is empty. Unlike the rates, unsupported
+// currencies are rejected with ErrUnknownCurrency instead of falling back to the default. It
+// returns nil when there is no rate between the currencies.
+func (s *RatesService) Convert(from string, to string, amount float64, date string) (*ConversionRecord, error) {
+	from = strings.ToUpper(strings.TrimSpace(from))
+	to = strings.ToUpper(strings.TrimSpace(to))
+	if !Registry.Contains(from) || !Registry.Contains(to) {
+		return nil, ErrUnknownCurrency
+	}
+
+	record, err := s.GetRateOnDate(from, to, date)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	rate, ok := record.Rates[to]
+	if from == to {
+		rate, ok = 1, true
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &ConversionRecord{From: from, To: to, Amount: amount, Rate: rate, Result: amount * rate, Date: record.Date}, nil
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRatesService_Convert(t *testing.T) {
+	service := NewRatesService(&MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			return &ExchangeRateRecord{Base: base, Date: "2025-01-30", Rates: map[string]float64{"USD": 1.08, "EUR": 1}}, nil
+		},
+		GetRateOnDateFunc: func(base string, date string) (*ExchangeRateRecord, error) {
+			return &ExchangeRateRecord{Base: base, Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03}}, nil
+		},
+	})
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		date    string
+		want    *ConversionRecord
+		wantErr error
+	}{
+		{
+			name: "latest rate",
+			from: "eur",
+			to:   " USD ",
+			want: &ConversionRecord{From: "EUR", To: "USD", Amount: 10, Rate: 1.08, Result: 10.8, Date: "2025-01-30"},
+		},
+		{
+			name: "rate on date",
+			from: "EUR",
+			to:   "USD",
+			date: "2025-01-05",
+			want: &ConversionRecord{From: "EUR", To: "USD", Amount: 10, Rate: 1.03, Result: 10.3, Date: "2025-01-03"},
+		},
+		{
+			name: "same currency",
+			from: "EUR",
+			to:   "EUR",
+			want: &ConversionRecord{From: "EUR", To: "EUR", Amount: 10, Rate: 1, Result: 10, Date: "2025-01-30"},
+		},
+		{
+			name: "no rate between the currencies",
+			from: "EUR",
+			to:   "JPY",
+		},
+		{
+			name:    "unknown currency",
+			from:    "EUR",
+			to:      "XYZ",
+			wantErr: ErrUnknownCurrency,
+		},
+		{
+			name:    "invalid date",
+			from:    "EUR",
+			to:      "USD",
+			date:    "yesterday",
+			wantErr: ErrInvalidDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Convert(tt.from, tt.to, 10, tt.date)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Convert() error = %v, want %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
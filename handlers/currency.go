@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kamaal111/forex-api/utils"
+)
+
+// GetCurrency handles requests for the metadata of a single currency.
+//
+// @Summary      Get currency metadata
+// @Description  Returns the ISO 4217 metadata of a currency: numeric code, minor units, countries and whether it is active or withdrawn, including its replacement.
+// @Description  The name and sign placement are localized, falling back from the region to the language and finally to English.
+// @Tags         currencies
+// @Produce      json
+// @Param        code             path      string  true   "ISO 4217 currency code, e.g. JPY"
+// @Param        locale           query     string  false  "Locale of the currency name, e.g. de or nl-BE (overrides Accept-Language)"
+// @Param        Accept-Language  header    string  false  "Preferred locales of the currency name"
+// @Success      200              {object}  api.CurrencyRecord
+// @Failure      404              {object}  api.Error
+// @Router       /v1/currencies/{code} [get]
+func GetCurrency(writer http.ResponseWriter, request *http.Request) {
+	locale := negotiateLocale(writer, request)
+
+	record := LookupCurrency(request.PathValue("code"), locale)
+	if record == nil {
+		utils.ErrorHandler(writer, "currency not found", http.StatusNotFound)
+		return
+	}
+
+	output, err := json.Marshal(record)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(output)
+}
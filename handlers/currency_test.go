@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kamaal111/forex-api/locales"
+)
+
+func TestGetCurrencyHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		wantStatusCode  int
+		wantCode        string
+		wantMinorUnits  int
+		wantStatus      string
+		wantReplacedBy  string
+		wantNumericCode string
+	}{
+		{
+			name:            "returns active currency with zero minor units",
+			path:            "/v1/currencies/JPY",
+			wantStatusCode:  http.StatusOK,
+			wantCode:        "JPY",
+			wantMinorUnits:  0,
+			wantStatus:      CurrencyStatusActive,
+			wantNumericCode: "392",
+		},
+		{
+			name:            "returns withdrawn currency with its replacement",
+			path:            "/v1/currencies/CYP",
+			wantStatusCode:  http.StatusOK,
+			wantCode:        "CYP",
+			wantMinorUnits:  2,
+			wantStatus:      CurrencyStatusWithdrawn,
+			wantReplacedBy:  "EUR",
+			wantNumericCode: "196",
+		},
+		{
+			name:            "is case insensitive",
+			path:            "/v1/currencies/usd",
+			wantStatusCode:  http.StatusOK,
+			wantCode:        "USD",
+			wantMinorUnits:  2,
+			wantStatus:      CurrencyStatusActive,
+			wantNumericCode: "840",
+		},
+		{
+			name:           "returns 404 for unknown currency",
+			path:           "/v1/currencies/XYZ",
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(CurrencyPath, GetCurrency)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetCurrency() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var record CurrencyRecord
+			if err := json.NewDecoder(recorder.Body).Decode(&record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if record.Code != tt.wantCode {
+				t.Errorf("GetCurrency() code = %q, want %q", record.Code, tt.wantCode)
+			}
+			if record.MinorUnits != tt.wantMinorUnits {
+				t.Errorf("GetCurrency() minor_units = %d, want %d", record.MinorUnits, tt.wantMinorUnits)
+			}
+			if record.Status != tt.wantStatus {
+				t.Errorf("GetCurrency() status = %q, want %q", record.Status, tt.wantStatus)
+			}
+			if record.ReplacedBy != tt.wantReplacedBy {
+				t.Errorf("GetCurrency() replaced_by = %q, want %q", record.ReplacedBy, tt.wantReplacedBy)
+			}
+			if record.NumericCode != tt.wantNumericCode {
+				t.Errorf("GetCurrency() numeric_code = %q, want %q", record.NumericCode, tt.wantNumericCode)
+			}
+			if len(record.Countries) == 0 {
+				t.Error("GetCurrency() returned no countries")
+			}
+		})
+	}
+}
+
+func TestRegistryMetadata(t *testing.T) {
+	numericCodes := make(map[string]string)
+
+	for _, code := range Registry.Codes() {
+		info, _ := Registry.Lookup(code)
+		if len(info.NumericCode) != 3 {
+			t.Errorf("%s numeric code = %q, want 3 digits", code, info.NumericCode)
+		}
+		if other, ok := numericCodes[info.NumericCode]; ok {
+			t.Errorf("%s and %s share numeric code %s", code, other, info.NumericCode)
+		}
+		numericCodes[info.NumericCode] = code
+
+		if len(info.Countries) == 0 {
+			t.Errorf("%s has no countries", code)
+		}
+
+		if info.Active() {
+			if info.ReplacedBy != "" {
+				t.Errorf("%s is active but replaced by %s", code, info.ReplacedBy)
+			}
+			continue
+		}
+
+		replacement, ok := Registry.Lookup(info.ReplacedBy)
+		if !ok {
+			t.Errorf("%s is replaced by unknown currency %q", code, info.ReplacedBy)
+			continue
+		}
+		if !replacement.Active() {
+			t.Errorf("%s is replaced by withdrawn currency %s", code, info.ReplacedBy)
+		}
+	}
+}
+
+func TestGetCurrencyHandler_Localized(t *testing.T) {
+	tests := []struct {
+		name              string
+		path              string
+		acceptLanguage    string
+		wantLocale        string
+		wantName          string
+		wantSignPlacement string
+	}{
+		{name: "defaults to English", path: "/v1/currencies/USD", wantLocale: "en", wantName: "US Dollar", wantSignPlacement: "before"},
+		{name: "uses Accept-Language", path: "/v1/currencies/USD", acceptLanguage: "de-DE,de;q=0.9", wantLocale: "de", wantName: "US-Dollar", wantSignPlacement: "after"},
+		{name: "locale parameter overrides header", path: "/v1/currencies/JPY?locale=ja", acceptLanguage: "nl", wantLocale: "ja", wantName: "日本円", wantSignPlacement: "before"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(CurrencyPath, GetCurrency)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, req)
+
+			if got := recorder.Header().Get("Content-Language"); got != tt.wantLocale {
+				t.Errorf("GetCurrency() Content-Language = %q, want %q", got, tt.wantLocale)
+			}
+
+			var record CurrencyRecord
+			if err := json.NewDecoder(recorder.Body).Decode(&record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if record.Locale != tt.wantLocale {
+				t.Errorf("GetCurrency() locale = %q, want %q", record.Locale, tt.wantLocale)
+			}
+			if record.Name != tt.wantName {
+				t.Errorf("GetCurrency() name = %q, want %q", record.Name, tt.wantName)
+			}
+			if record.SignPlacement != tt.wantSignPlacement {
+				t.Errorf("GetCurrency() sign_placement = %q, want %q", record.SignPlacement, tt.wantSignPlacement)
+			}
+		})
+	}
+}
+
+func TestLocalesTranslateEveryCurrency(t *testing.T) {
+	for _, tag := range locales.Tags() {
+		if tag == locales.DefaultTag {
+			continue
+		}
+		locale, _ := locales.Lookup(tag)
+		for _, code := range Registry.Codes() {
+			if _, ok := locale.Names[code]; !ok {
+				t.Errorf("locale %s has no name for %s", tag, code)
+			}
+		}
+	}
+}
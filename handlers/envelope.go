@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 200
+)
+
+var (
+	ErrInvalidPage    = errors.New("page must be a positive integer")
+	ErrInvalidPerPage = fmt.Errorf("per_page must be an integer between 1 and %d", maxPerPage)
+)
+
+// Envelope is the shape of every v2 response: the requested data, metadata describing it and
+// the errors that kept it from being served. Like in JSON:API, data is left out when errors
+// isn't empty.
+type Envelope struct {
+	Data   any         `json:"data,omitempty"`
+	Meta   Meta        `json:"meta"`
+	Errors []api.Error `json:"errors"`
+}
+
+type Meta struct {
+	// Date is the publication date of the data.
+	Date   string `json:"date,omitempty"`
+	Locale string `json:"locale,omitempty"`
+	// Pagination is set on responses listing a page of a collection.
+	Pagination *Pagination `json:"pagination,omitempty"`
+	// Freshness is set on responses serving rates.
+	Freshness *api.Freshness `json:"freshness,omitempty"`
+}
+
+type Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// writeEnvelope writes data with its metadata as a successful v2 response.
+func writeEnvelope(writer http.ResponseWriter, data any, meta Meta) {
+	utils.WriteJSON(writer, http.StatusOK, Envelope{Data: data, Meta: meta, Errors: []api.Error{}})
+}
+
+// EnvelopeErrorHandler is the v2 counterpart of utils.ErrorHandler, it reports the error
+// inside an envelope.
+func EnvelopeErrorHandler(writer http.ResponseWriter, message string, code int) {
+	log.Printf("failure message: %s; code: %d", message, code)
+	utils.WriteJSON(writer, code, Envelope{Errors: []api.Error{{Message: message, Status: code}}})
+}
+
+// parsePagination reads the page and per_page query parameters, defaulting to the first page
+// of defaultPerPage items.
+func parsePagination(query url.Values) (page int, perPage int, err error) {
+	page, perPage = 1, defaultPerPage
+
+	if raw := query.Get("page"); raw != "" {
+		page, err = strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return 0, 0, ErrInvalidPage
+		}
+	}
+	if raw := query.Get("per_page"); raw != "" {
+		perPage, err = strconv.Atoi(raw)
+		if err != nil || perPage < 1 || perPage > maxPerPage {
+			return 0, 0, ErrInvalidPerPage
+		}
+	}
+	return page, perPage, nil
+}
+
+// paginate returns the items on the given page, which is empty past the last page.
+func paginate[T any](items []T, page int, perPage int) ([]T, *Pagination) {
+	pagination := &Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      len(items),
+		TotalPages: (len(items) + perPage - 1) / perPage,
+	}
+
+	if page > pagination.TotalPages {
+		return []T{}, pagination
+	}
+	start := (page - 1) * perPage
+	end := min(start+perPage, len(items))
+	return items[start:end:end], pagination
+}
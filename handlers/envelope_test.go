@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query       string
+		wantPage    int
+		wantPerPage int
+		wantErr     error
+	}{
+		{query: "", wantPage: 1, wantPerPage: defaultPerPage},
+		{query: "page=3&per_page=20", wantPage: 3, wantPerPage: 20},
+		{query: "per_page=200", wantPage: 1, wantPerPage: 200},
+		{query: "page=0", wantErr: ErrInvalidPage},
+		{query: "page=two", wantErr: ErrInvalidPage},
+		{query: "per_page=0", wantErr: ErrInvalidPerPage},
+		{query: "per_page=201", wantErr: ErrInvalidPerPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+
+			page, perPage, err := parsePagination(query)
+			if err != tt.wantErr {
+				t.Fatalf("parsePagination(%q) error = %v, want %v", tt.query, err, tt.wantErr)
+			}
+			if page != tt.wantPage || perPage != tt.wantPerPage {
+				t.Errorf("parsePagination(%q) = %d, %d, want %d, %d", tt.query, page, perPage, tt.wantPage, tt.wantPerPage)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []string{"EUR", "USD", "GBP", "JPY", "CHF"}
+
+	tests := []struct {
+		name           string
+		page           int
+		perPage        int
+		want           []string
+		wantTotalPages int
+	}{
+		{name: "first page", page: 1, perPage: 2, want: []string{"EUR", "USD"}, wantTotalPages: 3},
+		{name: "last partial page", page: 3, perPage: 2, want: []string{"CHF"}, wantTotalPages: 3},
+		{name: "everything on one page", page: 1, perPage: 50, want: items, wantTotalPages: 1},
+		{name: "past the last page", page: 4, perPage: 2, want: []string{}, wantTotalPages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pagination := paginate(items, tt.page, tt.perPage)
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("paginate() = %v, want %v", got, tt.want)
+			}
+			want := Pagination{Page: tt.page, PerPage: tt.perPage, Total: len(items), TotalPages: tt.wantTotalPages}
+			if *pagination != want {
+				t.Errorf("paginate() pagination = %+v, want %+v", *pagination, want)
+			}
+		})
+	}
+}
+
+func TestPaginate_DoesNotShareCapacity(t *testing.T) {
+	items := []string{"EUR", "USD", "GBP"}
+
+	page, _ := paginate(items, 1, 2)
+	_ = append(page, "JPY")
+
+	if items[2] != "GBP" {
+		t.Errorf("appending to a page overwrote the next item: %v", items)
+	}
+}
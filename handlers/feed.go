@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+// feedBufferSize is how many updates a subscription holds before further updates are dropped.
+const feedBufferSize = 16
+
+// RateUpdate announces that rates with a newer date have been published for a base.
+type RateUpdate struct {
+	Record *ExchangeRateRecord
+	// Previous is the record the update replaces, nil the first time the feed sees the base.
+	Previous *ExchangeRateRecord
+}
+
+// RateFeed polls the repository for the latest rates of the bases its subscribers watch and
+// pushes an update to them whenever a newer date appears. It only polls while it has
+// subscribers.
+type RateFeed struct {
+	// Interval is the time between two polls.
+	Interval time.Duration
+	// Open opens the rates service for a poll, the one of the handlers when nil.
+	Open func(ctx context.Context) (*RatesService, func(), error)
+
+	mu            sync.Mutex
+	subscriptions map[*FeedSubscription]struct{}
+	latest        map[string]*ExchangeRateRecord
+	cancel        context.CancelFunc
+	wakeup        chan struct{}
+}
+
+func NewRateFeed(interval time.Duration) *RateFeed {
+	return &RateFeed{
+		Interval:      interval,
+		subscriptions: map[*FeedSubscription]struct{}{},
+		latest:        map[string]*ExchangeRateRecord{},
+	}
+}
+
+// Feed is the rate feed shared by the streaming endpoint and the webhook dispatcher.
+var Feed = NewRateFeed(time.Minute)
+
+// FeedSubscription receives the updates of the bases it was created for.
+type FeedSubscription struct {
+	feed    *RateFeed
+	bases   []string
+	updates chan RateUpdate
+	once    sync.Once
+}
+
+// Updates returns the channel updates are delivered on. It is closed by Close.
+func (s *FeedSubscription) Updates() <-chan RateUpdate {
+	return s.updates
+}
+
+// Close stops the subscription. The feed stops polling once its last subscription is closed.
+func (s *FeedSubscription) Close() {
+	s.once.Do(func() {
+		s.feed.mu.Lock()
+		defer s.feed.mu.Unlock()
+
+		delete(s.feed.subscriptions, s)
+		close(s.updates)
+		if len(s.feed.subscriptions) == 0 && s.feed.cancel != nil {
+			s.feed.cancel()
+			s.feed.cancel = nil
+		}
+	})
+}
+
+// Subscribe starts watching bases and polls them right away.
+func (f *RateFeed) Subscribe(bases ...string) *FeedSubscription {
+	subscription := &FeedSubscription{feed: f, updates: make(chan RateUpdate, feedBufferSize)}
+	for _, base := range bases {
+		normalized := NormalizeBase(base)
+		if !slices.Contains(subscription.bases, normalized) {
+			subscription.bases = append(subscription.bases, normalized)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.subscriptions[subscription] = struct{}{}
+	if f.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		f.cancel = cancel
+		f.wakeup = make(chan struct{}, 1)
+		go f.run(ctx, f.wakeup)
+	}
+
+	select {
+	case f.wakeup <- struct{}{}:
+	default:
+	}
+	return subscription
+}
+
+func (f *RateFeed) run(ctx context.Context, wakeup <-chan struct{}) {
+	ticker := time.NewTicker(f.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wakeup:
+		}
+
+		if err := f.Poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("rate feed: %v", err)
+		}
+	}
+}
+
+// Poll fetches the latest rates of every watched base once and publishes the ones that are
+// newer than what the feed has seen before.
+func (f *RateFeed) Poll(ctx context.Context) error {
+	bases := f.watchedBases()
+	if len(bases) == 0 {
+		return nil
+	}
+
+	open := f.Open
+	if open == nil {
+		open = openRatesService
+	}
+	service, closeService, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	var problems []error
+	for _, base := range bases {
+		record, err := service.GetLatestRate(base, "")
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		if record != nil {
+			f.publish(base, record)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+func (f *RateFeed) watchedBases() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var bases []string
+	for subscription := range f.subscriptions {
+		for _, base := range subscription.bases {
+			if !slices.Contains(bases, base) {
+				bases = append(bases, base)
+			}
+		}
+	}
+	slices.Sort(bases)
+	return bases
+}
+
+func (f *RateFeed) publish(base string, record *ExchangeRateRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous := f.latest[base]
+	if previous != nil && record.Date <= previous.Date {
+		return
+	}
+	f.latest[base] = record
+
+	update := RateUpdate{Record: record, Previous: previous}
+	for subscription := range f.subscriptions {
+		if !slices.Contains(subscription.bases, base) {
+			continue
+		}
+		select {
+		case subscription.updates <- update:
+		default:
+			log.Printf("rate feed: dropped %s update of %s for a slow subscriber", base, record.Date)
+		}
+	}
+}
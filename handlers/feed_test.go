@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// changingRepository serves latest rates whose date can be moved forward during a test.
+type changingRepository struct {
+	MockRatesRepository
+
+	mu   sync.Mutex
+	date string
+}
+
+func newChangingRepository(date string) *changingRepository {
+	repo := &changingRepository{date: date}
+	repo.GetLatestRateFunc = func(base string) (*ExchangeRateRecord, error) {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		return &ExchangeRateRecord{Base: base, Date: repo.date, Rates: map[string]float64{"USD": 1.08, "GBP": 0.85}}, nil
+	}
+	return repo
+}
+
+func (r *changingRepository) publish(date string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.date = date
+}
+
+func receiveUpdate(t *testing.T, subscription *FeedSubscription) RateUpdate {
+	t.Helper()
+
+	select {
+	case update := <-subscription.Updates():
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+		return RateUpdate{}
+	}
+}
+
+func TestRateFeed_Poll(t *testing.T) {
+	repo := newChangingRepository("2025-12-04")
+	useMockRepository(t, repo)
+
+	feed := NewRateFeed(time.Hour)
+	subscription := feed.Subscribe("eur")
+	defer subscription.Close()
+
+	first := receiveUpdate(t, subscription)
+	if first.Record.Date != "2025-12-04" || first.Previous != nil {
+		t.Errorf("first update = %s with previous %v, want 2025-12-04 without previous", first.Record.Date, first.Previous)
+	}
+
+	if err := feed.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	select {
+	case update := <-subscription.Updates():
+		t.Errorf("Poll() published %s again", update.Record.Date)
+	default:
+	}
+
+	repo.publish("2025-12-05")
+	if err := feed.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	next := receiveUpdate(t, subscription)
+	if next.Record.Date != "2025-12-05" || next.Previous == nil || next.Previous.Date != "2025-12-04" {
+		t.Errorf("next update = %+v, want 2025-12-05 replacing 2025-12-04", next)
+	}
+}
+
+func TestRateFeed_OnlyNotifiesWatchedBases(t *testing.T) {
+	useMockRepository(t, newChangingRepository("2025-12-04"))
+
+	feed := NewRateFeed(time.Hour)
+	usd := feed.Subscribe("USD")
+	defer usd.Close()
+	gbp := feed.Subscribe("GBP")
+
+	if update := receiveUpdate(t, usd); update.Record.Base != "USD" {
+		t.Errorf("USD subscription received %s rates", update.Record.Base)
+	}
+	if update := receiveUpdate(t, gbp); update.Record.Base != "GBP" {
+		t.Errorf("GBP subscription received %s rates", update.Record.Base)
+	}
+
+	gbp.Close()
+	if _, ok := <-gbp.Updates(); ok {
+		t.Error("Close() left the updates channel open")
+	}
+	if bases := feed.watchedBases(); len(bases) != 1 || bases[0] != "USD" {
+		t.Errorf("watchedBases() = %v, want [USD]", bases)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type (
+	Fluctuation       = api.Fluctuation
+	FluctuationRecord = api.FluctuationRecord
+)
+
+// GetFluctuation compares the rates in effect on start with those in effect on end, both
+// YYYY-MM-DD. Only currencies published on both dates are compared. It returns nil when there
+// are no rates for either date.
+func (s *RatesService) GetFluctuation(base string, symbols string, start string, end string) (*FluctuationRecord, error) {
+	startDay, endDay, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedBase := NormalizeBase(base)
+	symbolsArray := MakeSymbolsArray(symbols, normalizedBase)
+
+	startRecord, err := s.Repository.GetRateOnDate(normalizedBase, startDay.Format(time.DateOnly))
+	if err != nil || startRecord == nil {
+		return nil, err
+	}
+	endRecord, err := s.Repository.GetRateOnDate(normalizedBase, endDay.Format(time.DateOnly))
+	if err != nil || endRecord == nil {
+		return nil, err
+	}
+
+	startRates := filterRates(startRecord, symbolsArray, nil).Rates
+	endRates := filterRates(endRecord, symbolsArray, nil).Rates
+
+	fluctuations := make(map[string]Fluctuation, len(endRates))
+	for symbol, endRate := range endRates {
+		startRate, ok := startRates[symbol]
+		if !ok {
+			continue
+		}
+
+		fluctuation := Fluctuation{StartRate: startRate, EndRate: endRate, Change: endRate - startRate}
+		if startRate != 0 {
+			fluctuation.ChangePercent = fluctuation.Change / startRate * 100
+		}
+		fluctuations[symbol] = fluctuation
+	}
+
+	return &FluctuationRecord{
+		Base:      endRecord.Base,
+		StartDate: startRecord.Date,
+		EndDate:   endRecord.Date,
+		Rates:     fluctuations,
+	}, nil
+}
+
+// GetFluctuation handles requests for the change of rates between two dates.
+//
+// @Summary      Get rate fluctuations between two dates
+// @Description  Compares the rates in effect on the start date with those in effect on the end date and reports the start rate, end rate, absolute change and percentage change of every currency.
+// @Description  When no rates were published on a requested date, the most recent publication before it is used.
+// @Tags         rates
+// @Produce      json
+// @Param        start    query     string  true   "Start date (YYYY-MM-DD)"  Format(date)
+// @Param        end      query     string  true   "End date (YYYY-MM-DD)"  Format(date)
+// @Param        base     query     string  false  "Base currency code; unsupported codes fall back to the default (default: EUR)"  minlength(3)  maxlength(3)
+// @Param        symbols  query     string  false  "Comma-separated list of target currency symbols"
+// @Success      200      {object}  api.FluctuationRecord
+// @Failure      400      {object}  api.Error
+// @Failure      404      {object}  api.Error
+// @Failure      500      {object}  api.Error
+// @Router       /v1/rates/fluctuation [get]
+func GetFluctuation(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	if query.Get("start") == "" || query.Get("end") == "" {
+		utils.ErrorHandler(writer, "start and end are required", http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	record, err := service.GetFluctuation(query.Get("base"), query.Get("symbols"), query.Get("start"), query.Get("end"))
+	if errors.Is(err, ErrInvalidDate) || errors.Is(err, ErrInvalidDateRange) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if record == nil {
+		utils.ErrorHandler(writer, "Rates not found", http.StatusNotFound)
+		return
+	}
+
+	output, err := json.Marshal(record)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(output)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newFluctuationRepository() *MockRatesRepository {
+	return &MockRatesRepository{
+		GetRateOnDateFunc: func(base string, date string) (*ExchangeRateRecord, error) {
+			switch {
+			case date < "2025-01-01":
+				return nil, nil
+			case date < "2025-06-01":
+				return &ExchangeRateRecord{Base: base, Date: "2025-01-02", Rates: map[string]float64{"USD": 1.05, "GBP": 0.84, "HRK": 7.5}}, nil
+			default:
+				return &ExchangeRateRecord{Base: base, Date: "2025-06-02", Rates: map[string]float64{"USD": 1.134, "GBP": 0.84, "JPY": 163.1}}, nil
+			}
+		},
+	}
+}
+
+func TestRatesService_GetFluctuation(t *testing.T) {
+	service := NewRatesService(newFluctuationRepository())
+
+	got, err := service.GetFluctuation("EUR", "", "2025-01-04", "2025-06-07")
+	if err != nil {
+		t.Fatalf("GetFluctuation() error = %v", err)
+	}
+
+	if got.StartDate != "2025-01-02" || got.EndDate != "2025-06-02" {
+		t.Errorf("GetFluctuation() dates = %s..%s, want 2025-01-02..2025-06-02", got.StartDate, got.EndDate)
+	}
+	if len(got.Rates) != 2 {
+		t.Errorf("GetFluctuation() compared %d currencies, want only the 2 published on both dates", len(got.Rates))
+	}
+
+	usd := got.Rates["USD"]
+	if usd.StartRate != 1.05 || usd.EndRate != 1.134 {
+		t.Errorf("GetFluctuation() USD rates = %v..%v, want 1.05..1.134", usd.StartRate, usd.EndRate)
+	}
+	if math.Abs(usd.Change-0.084) > 1e-9 {
+		t.Errorf("GetFluctuation() USD change = %v, want 0.084", usd.Change)
+	}
+	if math.Abs(usd.ChangePercent-8) > 1e-9 {
+		t.Errorf("GetFluctuation() USD change percent = %v, want 8", usd.ChangePercent)
+	}
+	if gbp := got.Rates["GBP"]; gbp.Change != 0 || gbp.ChangePercent != 0 {
+		t.Errorf("GetFluctuation() GBP = %+v, want no change", gbp)
+	}
+}
+
+func TestRatesService_GetFluctuation_Symbols(t *testing.T) {
+	got, err := NewRatesService(newFluctuationRepository()).GetFluctuation("EUR", "usd", "2025-01-04", "2025-06-07")
+	if err != nil {
+		t.Fatalf("GetFluctuation() error = %v", err)
+	}
+	if _, ok := got.Rates["USD"]; !ok || len(got.Rates) != 1 {
+		t.Errorf("GetFluctuation() rates = %v, want only USD", got.Rates)
+	}
+}
+
+func TestRatesService_GetFluctuation_LongRange(t *testing.T) {
+	// Only the rates of both ends are read, so the range isn't limited.
+	if _, err := NewRatesService(newFluctuationRepository()).GetFluctuation("EUR", "", "2020-01-04", "2025-06-07"); err != nil {
+		t.Errorf("GetFluctuation() over five years error = %v", err)
+	}
+}
+
+func TestRatesService_GetFluctuation_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr error
+	}{
+		{name: "invalid start", start: "2025/01/04", end: "2025-06-07", wantErr: ErrInvalidDate},
+		{name: "invalid end", start: "2025-01-04", end: "tomorrow", wantErr: ErrInvalidDate},
+		{name: "start after end", start: "2025-06-07", end: "2025-01-04", wantErr: ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRatesService(newFluctuationRepository()).GetFluctuation("EUR", "", tt.start, tt.end)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetFluctuation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetFluctuationHandler(t *testing.T) {
+	useMockRepository(t, newFluctuationRepository())
+
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+	}{
+		{name: "compares two dates", query: "?start=2025-01-04&end=2025-06-07&symbols=USD", wantStatusCode: http.StatusOK},
+		{name: "same start and end", query: "?start=2025-06-07&end=2025-06-07", wantStatusCode: http.StatusOK},
+		{name: "missing start", query: "?end=2025-06-07", wantStatusCode: http.StatusBadRequest},
+		{name: "missing end", query: "?start=2025-01-04", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid date", query: "?start=04-01-2025&end=2025-06-07", wantStatusCode: http.StatusBadRequest},
+		{name: "start after end", query: "?start=2025-06-07&end=2025-01-04", wantStatusCode: http.StatusBadRequest},
+		{name: "no rates before start", query: "?start=2024-06-07&end=2025-06-07", wantStatusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, FluctuationPath+tt.query, nil)
+			recorder := httptest.NewRecorder()
+
+			GetFluctuation(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetFluctuation() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var record FluctuationRecord
+			if err := json.Unmarshal(recorder.Body.Bytes(), &record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if record.Base != "EUR" || len(record.Rates) == 0 {
+				t.Errorf("GetFluctuation() = %+v, want EUR fluctuations", record)
+			}
+		})
+	}
+}
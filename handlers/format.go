@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/locales"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type FormattedAmountRecord = api.FormattedAmountRecord
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// FormatAmount renders an amount of a currency the way the locale writes it: rounded to the
+// currency's minor units, with the locale's separators and the sign on the locale's side.
+func FormatAmount(amount float64, currency string, locale *locales.Locale) (*FormattedAmountRecord, error) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	info, ok := Registry.Lookup(code)
+	if !ok {
+		return nil, ErrUnknownCurrency
+	}
+
+	number := locale.FormatNumber(math.Abs(amount), info.MinorUnits)
+	sign := info.Sign
+
+	spacing := ""
+	if locale.SignSpacing {
+		spacing = " "
+	}
+
+	var formatted string
+	if locale.SignPlacement == locales.SignAfter {
+		formatted = number + spacing + sign
+	} else {
+		formatted = sign + spacing + number
+	}
+	if amount < 0 && strings.ContainsAny(number, "123456789") {
+		formatted = "-" + formatted
+	}
+
+	return &FormattedAmountRecord{Amount: amount, Currency: code, Locale: locale.Tag, Formatted: formatted}, nil
+}
+
+// GetFormat handles requests to format an amount of money.
+//
+// @Summary      Format an amount of money
+// @Description  Formats an amount in a currency for a locale, using the currency's minor units and sign and the locale's separators and sign placement.
+// @Tags         currencies
+// @Produce      json
+// @Param        amount           query     number  true   "Amount to format, e.g. 1234.5"
+// @Param        currency         query     string  true   "ISO 4217 currency code, e.g. JPY"
+// @Param        locale           query     string  false  "Locale to format for, e.g. de or nl-BE (overrides Accept-Language)"
+// @Param        Accept-Language  header    string  false  "Preferred locales to format for"
+// @Success      200              {object}  api.FormattedAmountRecord
+// @Failure      400              {object}  api.Error
+// @Router       /v1/format [get]
+func GetFormat(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	rawAmount := query.Get("amount")
+	if rawAmount == "" {
+		utils.ErrorHandler(writer, "amount is required", http.StatusBadRequest)
+		return
+	}
+	amount, err := strconv.ParseFloat(rawAmount, 64)
+	if err != nil || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		utils.ErrorHandler(writer, "amount must be a number", http.StatusBadRequest)
+		return
+	}
+
+	locale := negotiateLocale(writer, request)
+
+	record, err := FormatAmount(amount, query.Get("currency"), locale)
+	if errors.Is(err, ErrUnknownCurrency) {
+		utils.ErrorHandler(writer, "currency must be a supported ISO 4217 code", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	output, err := json.Marshal(record)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(output)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kamaal111/forex-api/locales"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		locale   string
+		want     string
+	}{
+		{name: "US dollars in English", amount: 1234.5, currency: "USD", locale: "en", want: "$1,234.50"},
+		{name: "yen has no minor units", amount: 1234.5, currency: "JPY", locale: "en", want: "¥1,235"},
+		{name: "euros in German", amount: 1234567.891, currency: "EUR", locale: "de", want: "1.234.567,89 €"},
+		{name: "euros in Dutch", amount: 1234.5, currency: "EUR", locale: "nl", want: "€ 1.234,50"},
+		{name: "krona sign after the amount in German", amount: 99.9, currency: "SEK", locale: "de", want: "99,90 kr"},
+		{name: "yen in Japanese", amount: 1000000, currency: "JPY", locale: "ja", want: "¥1,000,000"},
+		{name: "negative amount", amount: -42.1, currency: "GBP", locale: "en", want: "-£42.10"},
+		{name: "negative amount rounding to zero", amount: -0.001, currency: "GBP", locale: "en", want: "£0.00"},
+		{name: "lowercase currency code", amount: 1, currency: "chf", locale: "de", want: "1,00 Fr"},
+		{name: "huge amount", amount: -1e20, currency: "USD", locale: "en", want: "-$100,000,000,000,000,000,000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, ok := locales.Lookup(tt.locale)
+			if !ok {
+				t.Fatalf("unknown locale %q", tt.locale)
+			}
+
+			got, err := FormatAmount(tt.amount, tt.currency, locale)
+			if err != nil {
+				t.Fatalf("FormatAmount() error = %v", err)
+			}
+			if got.Formatted != tt.want {
+				t.Errorf("FormatAmount(%v, %q, %q) = %q, want %q", tt.amount, tt.currency, tt.locale, got.Formatted, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAmount_UnknownCurrency(t *testing.T) {
+	_, err := FormatAmount(1, "XYZ", locales.Default())
+	if err != ErrUnknownCurrency {
+		t.Errorf("FormatAmount() error = %v, want %v", err, ErrUnknownCurrency)
+	}
+}
+
+func TestGetFormatHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		wantStatusCode int
+		wantFormatted  string
+	}{
+		{name: "formats with locale parameter", query: "?amount=1234.5&currency=EUR&locale=de", wantStatusCode: http.StatusOK, wantFormatted: "1.234,50 €"},
+		{name: "formats with Accept-Language", query: "?amount=1234.5&currency=EUR", acceptLanguage: "nl-NL", wantStatusCode: http.StatusOK, wantFormatted: "€ 1.234,50"},
+		{name: "missing amount", query: "?currency=EUR", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid amount", query: "?amount=abc&currency=EUR", wantStatusCode: http.StatusBadRequest},
+		{name: "unknown currency", query: "?amount=1&currency=XYZ", wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, FormatPath+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			recorder := httptest.NewRecorder()
+
+			GetFormat(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetFormat() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var record FormattedAmountRecord
+			if err := json.NewDecoder(recorder.Body).Decode(&record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if record.Formatted != tt.wantFormatted {
+				t.Errorf("GetFormat() formatted = %q, want %q", record.Formatted, tt.wantFormatted)
+			}
+		})
+	}
+}
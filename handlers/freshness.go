@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+)
+
+type Freshness = api.Freshness
+
+const (
+	// publicationHour is the hour, in Central European Time, by which the daily reference
+	// rates are published on TARGET business days.
+	publicationHour = 16
+
+	DataStalenessHeader    = "X-Data-Staleness"
+	DataExpectedDateHeader = "X-Data-Expected-Date"
+)
+
+var publicationLocation = func() *time.Location {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		return time.FixedZone("CET", 60*60)
+	}
+	return location
+}()
+
+// ComputeFreshness compares a record date in YYYY-MM-DD format against the publication
+// calendar at now. It returns nil when the date can't be parsed.
+func ComputeFreshness(recordDate string, now time.Time) *Freshness {
+	date, err := time.ParseInLocation(time.DateOnly, recordDate, publicationLocation)
+	if err != nil {
+		return nil
+	}
+
+	expected := ExpectedPublicationDate(now)
+	freshness := &Freshness{ExpectedDate: expected.Format(time.DateOnly)}
+
+	var firstMissed time.Time
+	for day := date.AddDate(0, 0, 1); !day.After(expected); day = day.AddDate(0, 0, 1) {
+		if !IsPublicationDay(day) {
+			continue
+		}
+		if freshness.MissedPublications == 0 {
+			firstMissed = day
+		}
+		freshness.MissedPublications++
+	}
+
+	if freshness.MissedPublications > 0 {
+		freshness.Stale = true
+		freshness.StaleSeconds = int64(now.Sub(publicationTime(firstMissed)).Seconds())
+	}
+	return freshness
+}
+
+// ExpectedPublicationDate returns the date of the latest publication that is due at now.
+func ExpectedPublicationDate(now time.Time) time.Time {
+	local := now.In(publicationLocation)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, publicationLocation)
+	if local.Before(publicationTime(day)) {
+		day = day.AddDate(0, 0, -1)
+	}
+	for !IsPublicationDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// IsPublicationDay reports whether rates are published on date, that is whether it is a
+// TARGET business day: not a weekend, New Year's Day, Good Friday, Easter Monday, Labour Day
+// or either Christmas holiday.
+func IsPublicationDay(date time.Time) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+
+	month, day := date.Month(), date.Day()
+	switch {
+	case month == time.January && day == 1,
+		month == time.May && day == 1,
+		month == time.December && (day == 25 || day == 26):
+		return false
+	}
+
+	easter := easterSunday(date.Year())
+	for _, holiday := range []time.Time{easter.AddDate(0, 0, -2), easter.AddDate(0, 0, 1)} {
+		if holiday.Month() == month && holiday.Day() == day {
+			return false
+		}
+	}
+	return true
+}
+
+func publicationTime(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), publicationHour, 0, 0, 0, publicationLocation)
+}
+
+// easterSunday computes the date of Easter Sunday in the Gregorian calendar using the
+// anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, publicationLocation)
+}
+
+func setFreshnessHeaders(writer http.ResponseWriter, freshness *Freshness) {
+	if freshness == nil {
+		return
+	}
+	writer.Header().Set(DataStalenessHeader, strconv.FormatInt(freshness.StaleSeconds, 10))
+	writer.Header().Set(DataExpectedDateHeader, freshness.ExpectedDate)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("failed to parse time %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestIsPublicationDay(t *testing.T) {
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{date: "2025-12-05", want: true},
+		{date: "2025-12-06", want: false},
+		{date: "2025-12-07", want: false},
+		{date: "2025-01-01", want: false},
+		{date: "2025-04-18", want: false},
+		{date: "2025-04-21", want: false},
+		{date: "2025-04-22", want: true},
+		{date: "2025-05-01", want: false},
+		{date: "2025-12-24", want: true},
+		{date: "2025-12-25", want: false},
+		{date: "2025-12-26", want: false},
+		{date: "2024-03-29", want: false},
+		{date: "2024-04-01", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, err := time.Parse(time.DateOnly, tt.date)
+			if err != nil {
+				t.Fatalf("failed to parse date: %v", err)
+			}
+			if got := IsPublicationDay(date); got != tt.want {
+				t.Errorf("IsPublicationDay(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpectedPublicationDate(t *testing.T) {
+	tests := []struct {
+		name string
+		now  string
+		want string
+	}{
+		{name: "after publication on a business day", now: "2025-12-05T16:30:00+01:00", want: "2025-12-05"},
+		{name: "before publication on a business day", now: "2025-12-05T09:00:00+01:00", want: "2025-12-04"},
+		{name: "on a weekend", now: "2025-12-07T12:00:00+01:00", want: "2025-12-05"},
+		{name: "monday morning", now: "2025-12-08T08:00:00+01:00", want: "2025-12-05"},
+		{name: "over easter", now: "2025-04-21T18:00:00+02:00", want: "2025-04-17"},
+		{name: "converts from UTC", now: "2025-12-05T15:30:00Z", want: "2025-12-05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpectedPublicationDate(mustParseTime(t, tt.now)).Format(time.DateOnly)
+			if got != tt.want {
+				t.Errorf("ExpectedPublicationDate(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeFreshness(t *testing.T) {
+	tests := []struct {
+		name       string
+		recordDate string
+		now        string
+		wantNil    bool
+		wantMissed int
+		wantStale  time.Duration
+	}{
+		{
+			name:       "up to date",
+			recordDate: "2025-12-05",
+			now:        "2025-12-05T17:00:00+01:00",
+			wantMissed: 0,
+		},
+		{
+			name:       "friday rates are fresh over the weekend",
+			recordDate: "2025-12-05",
+			now:        "2025-12-07T12:00:00+01:00",
+			wantMissed: 0,
+		},
+		{
+			name:       "one missed publication",
+			recordDate: "2025-12-04",
+			now:        "2025-12-05T18:00:00+01:00",
+			wantMissed: 1,
+			wantStale:  2 * time.Hour,
+		},
+		{
+			name:       "several missed publications across a weekend",
+			recordDate: "2025-12-03",
+			now:        "2025-12-08T16:00:00+01:00",
+			wantMissed: 3,
+			wantStale:  4 * 24 * time.Hour,
+		},
+		{
+			name:       "invalid date",
+			recordDate: "not-a-date",
+			now:        "2025-12-05T17:00:00+01:00",
+			wantNil:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeFreshness(tt.recordDate, mustParseTime(t, tt.now))
+
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("ComputeFreshness() = %+v, want nil", got)
+				}
+				return
+			}
+
+			if got == nil {
+				t.Fatal("ComputeFreshness() returned nil")
+			}
+			if got.MissedPublications != tt.wantMissed {
+				t.Errorf("ComputeFreshness() missed = %d, want %d", got.MissedPublications, tt.wantMissed)
+			}
+			if got.Stale != (tt.wantMissed > 0) {
+				t.Errorf("ComputeFreshness() stale = %v, want %v", got.Stale, tt.wantMissed > 0)
+			}
+			if got.StaleFor() != tt.wantStale {
+				t.Errorf("ComputeFreshness() stale for = %s, want %s", got.StaleFor(), tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestSetFreshnessHeaders(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	setFreshnessHeaders(recorder, &Freshness{ExpectedDate: "2025-12-05", MissedPublications: 1, StaleSeconds: 7200, Stale: true})
+
+	if got := recorder.Header().Get(DataStalenessHeader); got != "7200" {
+		t.Errorf("%s = %q, want %q", DataStalenessHeader, got, "7200")
+	}
+	if got := recorder.Header().Get(DataExpectedDateHeader); got != "2025-12-05" {
+		t.Errorf("%s = %q, want %q", DataExpectedDateHeader, got, "2025-12-05")
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type (
+	GraphQLRequest  = api.GraphQLRequest
+	GraphQLLocation = api.GraphQLLocation
+	GraphQLError    = api.GraphQLError
+)
+
+const maxGraphQLBodyBytes = 1 << 20
+
+var ErrMissingGraphQLQuery = errors.New("query is required")
+
+// GraphQLResponse follows the GraphQL spec: data is left out when the query couldn't run, and
+// a query that ran can still report errors about some of its fields.
+type GraphQLResponse struct {
+	Data   any                `json:"data,omitempty"`
+	Errors []api.GraphQLError `json:"errors,omitempty"`
+}
+
+// ServeGraphQL handles GraphQL queries over the rates service.
+//
+// @Summary      Run a GraphQL query
+// @Description  Runs a query against the GraphQL schema, which exposes the latest and historical rates, time series and currencies.
+// @Description  Queries are also accepted with GET, in the query, operationName and variables query parameters.
+// @Description  Queries nested too deeply or estimated too expensive are rejected with 400, and the repository calls of a query run in one batch.
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        request  body      api.GraphQLRequest  true  "GraphQL query"
+// @Success      200      {object}  GraphQLResponse
+// @Failure      400      {object}  GraphQLResponse
+// @Failure      405      {object}  GraphQLResponse
+// @Failure      500      {object}  GraphQLResponse
+// @Router       /graphql [post]
+func ServeGraphQL(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodPost {
+		writer.Header().Set("Allow", "GET, POST")
+		writeGraphQLError(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	graphQLRequest, err := parseGraphQLRequest(writer, request)
+	if err != nil {
+		writeGraphQLError(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		writeGraphQLError(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	scope := newGraphQLScope(service, request.Header.Get("Accept-Language"))
+	status, response := executeGraphQL(context.WithValue(request.Context(), graphQLScopeKey{}, scope), graphQLRequest)
+	utils.WriteJSON(writer, status, response)
+}
+
+// parseGraphQLRequest reads a query from the JSON body of a POST or the query parameters of
+// a GET.
+func parseGraphQLRequest(writer http.ResponseWriter, request *http.Request) (*GraphQLRequest, error) {
+	var graphQLRequest GraphQLRequest
+	if request.Method == http.MethodPost {
+		decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxGraphQLBodyBytes))
+		if err := decoder.Decode(&graphQLRequest); err != nil {
+			return nil, errors.New("body must be a JSON GraphQL request")
+		}
+	} else {
+		query := request.URL.Query()
+		graphQLRequest.Query = query.Get("query")
+		graphQLRequest.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &graphQLRequest.Variables); err != nil {
+				return nil, errors.New("variables must be a JSON object")
+			}
+		}
+	}
+
+	if graphQLRequest.Query == "" {
+		return nil, ErrMissingGraphQLQuery
+	}
+	return &graphQLRequest, nil
+}
+
+// executeGraphQL parses, validates and runs a query. Queries that can't run are answered with
+// 400, queries that ran with 200 even when some of their fields failed.
+func executeGraphQL(ctx context.Context, graphQLRequest *GraphQLRequest) (int, GraphQLResponse) {
+	document, err := parseGraphQLQuery(graphQLRequest.Query)
+	if err != nil {
+		return http.StatusBadRequest, GraphQLResponse{Errors: toGraphQLErrors(gqlerrors.FormatErrors(err))}
+	}
+
+	validation := graphql.ValidateDocument(&graphQLSchema, document, graphql.SpecifiedRules)
+	if !validation.IsValid {
+		return http.StatusBadRequest, GraphQLResponse{Errors: toGraphQLErrors(validation.Errors)}
+	}
+	if err := checkQueryLimits(document, graphQLRequest.OperationName, graphQLRequest.Variables); err != nil {
+		return http.StatusBadRequest, GraphQLResponse{Errors: []GraphQLError{{Message: err.Error()}}}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        graphQLSchema,
+		AST:           document,
+		OperationName: graphQLRequest.OperationName,
+		Args:          graphQLRequest.Variables,
+		Context:       ctx,
+	})
+	return http.StatusOK, GraphQLResponse{Data: result.Data, Errors: toGraphQLErrors(result.Errors)}
+}
+
+func parseGraphQLQuery(query string) (*ast.Document, error) {
+	return parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"}),
+	})
+}
+
+func toGraphQLErrors(formatted []gqlerrors.FormattedError) []GraphQLError {
+	if len(formatted) == 0 {
+		return nil
+	}
+
+	graphQLErrors := make([]GraphQLError, 0, len(formatted))
+	for _, err := range formatted {
+		graphQLError := GraphQLError{Message: err.Message, Path: err.Path}
+		for _, location := range err.Locations {
+			graphQLError.Locations = append(graphQLError.Locations, GraphQLLocation{Line: location.Line, Column: location.Column})
+		}
+		graphQLErrors = append(graphQLErrors, graphQLError)
+	}
+	return graphQLErrors
+}
+
+func writeGraphQLError(writer http.ResponseWriter, message string, code int) {
+	log.Printf("failure message: %s; code: %d", message, code)
+	utils.WriteJSON(writer, code, GraphQLResponse{Errors: []GraphQLError{{Message: message}}})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxGraphQLCalls caps the distinct repository calls of a GraphQL request, as MaxBatchQueries
+// caps the queries of a batch.
+const maxGraphQLCalls = MaxBatchQueries
+
+var ErrTooManyGraphQLCalls = fmt.Errorf("a query may make at most %d distinct repository calls", maxGraphQLCalls)
+
+// batchingRepository sits in front of the repository during a GraphQL request. Resolvers
+// queue the calls they are going to make, and the first time a result is needed every queued
+// call runs, concurrently with at most batchConcurrency in flight. Each distinct call runs
+// once per request, however many fields ask for it, and calls past maxGraphQLCalls fail with
+// ErrTooManyGraphQLCalls.
+type batchingRepository struct {
+	repository RatesRepository
+	semaphore  chan struct{}
+
+	mu      sync.Mutex
+	calls   map[string]*batchedCall
+	pending []*batchedCall
+}
+
+type batchedCall struct {
+	fetch func() (any, error)
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newBatchingRepository(repository RatesRepository) *batchingRepository {
+	return &batchingRepository{
+		repository: repository,
+		semaphore:  make(chan struct{}, batchConcurrency),
+		calls:      map[string]*batchedCall{},
+	}
+}
+
+// queue registers a call without running it. It's a no-op when the call is already known.
+func (r *batchingRepository) queue(key string, fetch func() (any, error)) *batchedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if call, ok := r.calls[key]; ok {
+		return call
+	}
+	if len(r.calls) >= maxGraphQLCalls {
+		call := &batchedCall{done: make(chan struct{}), err: ErrTooManyGraphQLCalls}
+		close(call.done)
+		return call
+	}
+	call := &batchedCall{fetch: fetch, done: make(chan struct{})}
+	r.calls[key] = call
+	r.pending = append(r.pending, call)
+	return call
+}
+
+// load queues the call if needed, runs every pending call and waits for the result of this one.
+func (r *batchingRepository) load(key string, fetch func() (any, error)) (any, error) {
+	call := r.queue(key, fetch)
+
+	r.mu.Lock()
+	batch := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	for _, pending := range batch {
+		go func() {
+			defer close(pending.done)
+			r.semaphore <- struct{}{}
+			defer func() { <-r.semaphore }()
+			pending.value, pending.err = pending.fetch()
+		}()
+	}
+
+	<-call.done
+	return call.value, call.err
+}
+
+// The calls below return the key and fetch function of each repository call, ready to be
+// queued or loaded.
+
+func (r *batchingRepository) latestRate(base string) (string, func() (any, error)) {
+	return "latest " + base, func() (any, error) { return r.repository.GetLatestRate(base) }
+}
+
+func (r *batchingRepository) rateOnDate(base string, date string) (string, func() (any, error)) {
+	return strings.Join([]string{"on", base, date}, " "), func() (any, error) { return r.repository.GetRateOnDate(base, date) }
+}
+
+func (r *batchingRepository) ratesBetween(base string, start string, end string) (string, func() (any, error)) {
+	return strings.Join([]string{"between", base, start, end}, " "), func() (any, error) {
+		return r.repository.GetRatesBetween(base, start, end)
+	}
+}
+
+func (r *batchingRepository) allSymbols() (string, func() (any, error)) {
+	return "symbols", func() (any, error) { return r.repository.GetAllSymbols() }
+}
+
+func (r *batchingRepository) GetLatestRate(base string) (*ExchangeRateRecord, error) {
+	value, err := r.load(r.latestRate(base))
+	record, _ := value.(*ExchangeRateRecord)
+	return record, err
+}
+
+func (r *batchingRepository) GetRateOnDate(base string, date string) (*ExchangeRateRecord, error) {
+	value, err := r.load(r.rateOnDate(base, date))
+	record, _ := value.(*ExchangeRateRecord)
+	return record, err
+}
+
+func (r *batchingRepository) GetRatesBetween(base string, start string, end string) ([]ExchangeRateRecord, error) {
+	value, err := r.load(r.ratesBetween(base, start, end))
+	records, _ := value.([]ExchangeRateRecord)
+	return records, err
+}
+
+func (r *batchingRepository) GetAllSymbols() (*SymbolsRecord, error) {
+	value, err := r.load(r.allSymbols())
+	record, _ := value.(*SymbolsRecord)
+	return record, err
+}
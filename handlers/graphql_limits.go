@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	// GraphQLMaxDepth is how deeply the selections of a GraphQL query may nest.
+	GraphQLMaxDepth = 6
+	// GraphQLMaxComplexity caps the estimated cost of a GraphQL query, see measureQuery.
+	GraphQLMaxComplexity = 1000
+)
+
+// graphQLListFields are the fields returning lists of objects. Their selections are counted
+// graphQLListSize times, as an estimate of how many items they return. The selections of
+// timeSeries are counted once per publication day of its range instead.
+var graphQLListFields = map[string]bool{"currencies": true}
+
+const graphQLListSize = 20
+
+// checkQueryLimits rejects the operation when it's nested deeper than GraphQLMaxDepth or
+// costs more than GraphQLMaxComplexity. The document must have been validated.
+func checkQueryLimits(document *ast.Document, operationName string, variables map[string]any) error {
+	depth, complexity := measureQuery(document, operationName, variables)
+	if depth > GraphQLMaxDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", depth, GraphQLMaxDepth)
+	}
+	if complexity > GraphQLMaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", complexity, GraphQLMaxComplexity)
+	}
+	return nil
+}
+
+// measureQuery returns the depth and complexity of the operation that runs for operationName.
+// Every field costs 1 plus the cost of its selections. Introspection fields are free, so
+// tools can always load the schema.
+func measureQuery(document *ast.Document, operationName string, variables map[string]any) (depth int, complexity int) {
+	measure := &queryMeasure{
+		fragments: map[string]*ast.FragmentDefinition{},
+		measured:  map[string]selectionMeasure{},
+		variables: map[string]any{},
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			measure.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		return 0, 0
+	}
+
+	for _, definition := range operation.VariableDefinitions {
+		if value, ok := definition.DefaultValue.(*ast.StringValue); ok {
+			measure.variables[definition.Variable.Name.Value] = value.Value
+		}
+	}
+	for name, value := range variables {
+		measure.variables[name] = value
+	}
+
+	result := measure.selections(operation.SelectionSet)
+	return result.depth, result.complexity
+}
+
+type selectionMeasure struct {
+	depth      int
+	complexity int
+}
+
+type queryMeasure struct {
+	fragments map[string]*ast.FragmentDefinition
+	// measured memoizes fragments, which a query can spread many times over.
+	measured  map[string]selectionMeasure
+	variables map[string]any
+}
+
+func (m *queryMeasure) selections(set *ast.SelectionSet) selectionMeasure {
+	var result selectionMeasure
+	if set == nil {
+		return result
+	}
+
+	for _, selection := range set.Selections {
+		var measured selectionMeasure
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			children := m.selections(selection.SelectionSet)
+			switch {
+			case selection.Name.Value == "timeSeries":
+				children.complexity *= m.publicationDays(selection)
+			case graphQLListFields[selection.Name.Value]:
+				children.complexity *= graphQLListSize
+			}
+			measured = selectionMeasure{depth: children.depth + 1, complexity: children.complexity + 1}
+		case *ast.InlineFragment:
+			measured = m.selections(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			measured = m.fragment(selection.Name.Value)
+		}
+
+		result.depth = max(result.depth, measured.depth)
+		result.complexity += measured.complexity
+	}
+	return result
+}
+
+func (m *queryMeasure) fragment(name string) selectionMeasure {
+	if measured, ok := m.measured[name]; ok {
+		return measured
+	}
+
+	var measured selectionMeasure
+	if fragment, ok := m.fragments[name]; ok {
+		measured = m.selections(fragment.SelectionSet)
+	}
+	m.measured[name] = measured
+	return measured
+}
+
+// publicationDays counts the publication days in the range of a timeSeries field, which bound
+// how many records it returns. Ranges that don't parse or are too long count as 1, as the
+// field fails without loading anything.
+func (m *queryMeasure) publicationDays(field *ast.Field) int {
+	startDay, endDay, err := parseDateRange(m.stringArgument(field, "start"), m.stringArgument(field, "end"))
+	if err != nil || limitDateRange(startDay, endDay) != nil {
+		return 1
+	}
+
+	days := 0
+	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		if IsPublicationDay(day) {
+			days++
+		}
+	}
+	return max(days, 1)
+}
+
+// stringArgument returns the value of a string argument, given inline or in a variable.
+func (m *queryMeasure) stringArgument(field *ast.Field, name string) string {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != name {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.StringValue:
+			return value.Value
+		case *ast.Variable:
+			variable, _ := m.variables[value.Name.Value].(string)
+			return variable
+		}
+	}
+	return ""
+}
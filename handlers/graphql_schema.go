@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/kamaal111/forex-api/locales"
+)
+
+// graphQLScope holds what the resolvers of one GraphQL request share.
+type graphQLScope struct {
+	// service reads through repository, so the calls of every resolver are batched.
+	service        *RatesService
+	repository     *batchingRepository
+	acceptLanguage string
+}
+
+type graphQLScopeKey struct{}
+
+func newGraphQLScope(service *RatesService, acceptLanguage string) *graphQLScope {
+	repository := newBatchingRepository(service.Repository)
+	return &graphQLScope{
+		service:        &RatesService{Repository: repository, Now: service.Now},
+		repository:     repository,
+		acceptLanguage: acceptLanguage,
+	}
+}
+
+func graphQLScopeFrom(ctx context.Context) *graphQLScope {
+	return ctx.Value(graphQLScopeKey{}).(*graphQLScope)
+}
+
+var rateMapType = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "RateMap",
+	Description: "Exchange rates keyed by ISO 4217 currency code.",
+	Serialize:   func(value any) any { return value },
+})
+
+var freshnessType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Freshness",
+	Description: "How far rates lag behind the publication calendar.",
+	Fields: graphql.Fields{
+		"expectedDate":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"missedPublications": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"staleSeconds":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"stale":              &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+	},
+})
+
+var ratesType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Rates",
+	Description: "The rates of a base currency published on a date.",
+	Fields: graphql.Fields{
+		"base": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"date": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"rates": &graphql.Field{
+			Type: graphql.NewNonNull(rateMapType),
+			Args: graphql.FieldConfigArgument{
+				"symbols": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Target currency symbols, all of them when left out",
+				},
+			},
+			Resolve: resolveRates,
+		},
+		"freshness": &graphql.Field{Type: freshnessType, Description: "Only set on the latest and historical rates"},
+	},
+})
+
+var currencyType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Currency",
+	Description: "An available currency with its localized name and sign.",
+	Fields: graphql.Fields{
+		"symbol":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"sign":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"signPlacement": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var currencyMetadataType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "CurrencyMetadata",
+	Description: "The ISO 4217 metadata of a currency.",
+	Fields: graphql.Fields{
+		"code":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"locale":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"sign":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"signPlacement": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"numericCode":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"minorUnits":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"countries":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+		"status":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"withdrawn":     &graphql.Field{Type: graphql.String, Description: "Year and month (YYYY-MM) the currency was withdrawn"},
+		"replacedBy":    &graphql.Field{Type: graphql.String},
+	},
+})
+
+var baseArgument = &graphql.ArgumentConfig{
+	Type:        graphql.String,
+	Description: "Base currency code; unsupported codes fall back to the default (default: EUR)",
+}
+
+var localeArgument = &graphql.ArgumentConfig{
+	Type:        graphql.String,
+	Description: "Locale of the currency names, e.g. de or nl-BE (overrides Accept-Language)",
+}
+
+var queryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"latest": &graphql.Field{
+			Type:        ratesType,
+			Description: "The latest rates, null when there are none.",
+			Args:        graphql.FieldConfigArgument{"base": baseArgument},
+			Resolve:     resolveLatest,
+		},
+		"historical": &graphql.Field{
+			Type:        ratesType,
+			Description: "The rates in effect on a date, null when there are none.",
+			Args: graphql.FieldConfigArgument{
+				"date": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Date as YYYY-MM-DD"},
+				"base": baseArgument,
+			},
+			Resolve: resolveHistorical,
+		},
+		"timeSeries": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(ratesType))),
+			Description: "The rates published between two dates inclusive, spanning at most 366 days, oldest first.",
+			Args: graphql.FieldConfigArgument{
+				"start": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Start date as YYYY-MM-DD"},
+				"end":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "End date as YYYY-MM-DD"},
+				"base":  baseArgument,
+			},
+			Resolve: resolveTimeSeries,
+		},
+		"currencies": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(currencyType))),
+			Description: "The currencies with rates, with names and signs.",
+			Args:        graphql.FieldConfigArgument{"locale": localeArgument},
+			Resolve:     resolveCurrencies,
+		},
+		"currency": &graphql.Field{
+			Type:        currencyMetadataType,
+			Description: "The metadata of a currency, null when the code is unknown.",
+			Args: graphql.FieldConfigArgument{
+				"code":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "ISO 4217 currency code"},
+				"locale": localeArgument,
+			},
+			Resolve: resolveCurrency,
+		},
+	},
+})
+
+// graphQLSchema is built once, a failure is a programming error.
+var graphQLSchema = func() graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}()
+
+// The root resolvers queue their repository calls and return thunks, which graphql-go only
+// calls once every root field has been resolved, so all the calls of a query run in one batch.
+
+func resolveLatest(p graphql.ResolveParams) (any, error) {
+	scope := graphQLScopeFrom(p.Context)
+	base, _ := p.Args["base"].(string)
+
+	scope.repository.queue(scope.repository.latestRate(NormalizeBase(base)))
+	return func() (any, error) {
+		return scope.service.GetLatestRate(base, "")
+	}, nil
+}
+
+func resolveHistorical(p graphql.ResolveParams) (any, error) {
+	scope := graphQLScopeFrom(p.Context)
+	base, _ := p.Args["base"].(string)
+	date, _ := p.Args["date"].(string)
+
+	day, err := parseDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	scope.repository.queue(scope.repository.rateOnDate(NormalizeBase(base), day.Format(time.DateOnly)))
+	return func() (any, error) {
+		return scope.service.GetRateOnDate(base, "", date)
+	}, nil
+}
+
+func resolveTimeSeries(p graphql.ResolveParams) (any, error) {
+	scope := graphQLScopeFrom(p.Context)
+	base, _ := p.Args["base"].(string)
+	start, _ := p.Args["start"].(string)
+	end, _ := p.Args["end"].(string)
+
+	startDay, endDay, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := limitDateRange(startDay, endDay); err != nil {
+		return nil, err
+	}
+
+	scope.repository.queue(scope.repository.ratesBetween(NormalizeBase(base), startDay.Format(time.DateOnly), endDay.Format(time.DateOnly)))
+	return func() (any, error) {
+		records, err := scope.service.GetTimeSeries(base, "", start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		series := make([]*ExchangeRateRecord, len(records))
+		for i := range records {
+			series[i] = &records[i]
+		}
+		return series, nil
+	}, nil
+}
+
+func resolveCurrencies(p graphql.ResolveParams) (any, error) {
+	scope := graphQLScopeFrom(p.Context)
+	locale, _ := p.Args["locale"].(string)
+
+	scope.repository.queue(scope.repository.allSymbols())
+	return func() (any, error) {
+		record, err := scope.service.GetLocalizedNamedSymbols(locales.Negotiate(locale, scope.acceptLanguage))
+		if err != nil || record == nil {
+			return []NamedSymbol{}, err
+		}
+		return record.Data, nil
+	}, nil
+}
+
+func resolveCurrency(p graphql.ResolveParams) (any, error) {
+	scope := graphQLScopeFrom(p.Context)
+	code, _ := p.Args["code"].(string)
+	locale, _ := p.Args["locale"].(string)
+
+	return LookupCurrency(code, locales.Negotiate(locale, scope.acceptLanguage)), nil
+}
+
+func resolveRates(p graphql.ResolveParams) (any, error) {
+	record := p.Source.(*ExchangeRateRecord)
+
+	var symbols []string
+	if requested, ok := p.Args["symbols"].([]any); ok {
+		for _, symbol := range requested {
+			symbols = append(symbols, symbol.(string))
+		}
+	}
+
+	return filterRates(record, MakeSymbolsArray(strings.Join(symbols, ","), record.Base), nil).Rates, nil
+}
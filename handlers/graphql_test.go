@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func graphQLRepository() *MockRatesRepository {
+	repo := conformanceRepository()
+	repo.GetRateOnDateFunc = func(base string, date string) (*ExchangeRateRecord, error) {
+		return &ExchangeRateRecord{Base: base, Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03, "JPY": 162.5}}, nil
+	}
+	return repo
+}
+
+func serveGraphQL(t *testing.T, request *http.Request) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	ServeGraphQL(recorder, request)
+
+	var response map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response %q: %v", recorder.Body.String(), err)
+	}
+	return recorder, response
+}
+
+func postGraphQL(query string) *http.Request {
+	body, _ := json.Marshal(GraphQLRequest{Query: query})
+	return httptest.NewRequest(http.MethodPost, GraphQLPath, strings.NewReader(string(body)))
+}
+
+func TestServeGraphQL(t *testing.T) {
+	useMockRepository(t, graphQLRepository())
+
+	tests := []struct {
+		name           string
+		request        *http.Request
+		wantStatusCode int
+		wantData       string
+		wantError      string
+	}{
+		{
+			name:           "latest rates of some symbols",
+			request:        postGraphQL(`{ latest(base: "EUR") { base rates(symbols: ["USD"]) } }`),
+			wantStatusCode: http.StatusOK,
+			wantData:       `{"latest":{"base":"EUR","rates":{"USD":1.08}}}`,
+		},
+		{
+			name:           "latest rates of a base without data",
+			request:        postGraphQL(`{ latest(base: "USD") { date } }`),
+			wantStatusCode: http.StatusOK,
+			wantData:       `{"latest":null}`,
+		},
+		{
+			name:           "historical rates",
+			request:        postGraphQL(`{ historical(date: "2025-01-05", base: "usd") { base date rates(symbols: ["JPY"]) freshness { missedPublications } } }`),
+			wantStatusCode: http.StatusOK,
+			wantData:       `{"historical":{"base":"USD","date":"2025-01-03","freshness":{"missedPublications":0},"rates":{"JPY":162.5}}}`,
+		},
+		{
+			name:           "time series",
+			request:        postGraphQL(`{ timeSeries(start: "2025-01-29", end: "2025-02-02") { date rates(symbols: ["USD"]) } }`),
+			wantStatusCode: http.StatusOK,
+			wantData:       `{"timeSeries":[{"date":"2025-01-29","rates":{"USD":1.03}},{"date":"2025-01-30","rates":{"USD":1.08}}]}`,
+		},
+		{
+			name:           "currencies and currency metadata in one query",
+			request:        postGraphQL(`{ currencies(locale: "de") { symbol name } currency(code: "JPY") { numericCode minorUnits } }`),
+			wantStatusCode: http.StatusOK,
+			wantData:       `{"currencies":[{"name":"Euro","symbol":"EUR"},{"name":"US-Dollar","symbol":"USD"},{"name":"Britisches Pfund","symbol":"GBP"}],"currency":{"minorUnits":0,"numericCode":"392"}}`,
+		},
+		{
+			name: "GET with variables",
+			request: httptest.NewRequest(http.MethodGet, GraphQLPath+"?"+url.Values{
+				"query":     {`query Currency($code: String!) { currency(code: $code) { code status } }`},
+				"variables": {`{"code":"CYP"}`},
+			}.Encode(), nil),
+			wantStatusCode: http.StatusOK,
+			wantData:       `{"currency":{"code":"CYP","status":"withdrawn"}}`,
+		},
+		{
+			name:           "field error",
+			request:        postGraphQL(`{ timeSeries(start: "2025-02-01", end: "2025-01-01") { date } }`),
+			wantStatusCode: http.StatusOK,
+			wantError:      ErrInvalidDateRange.Error(),
+		},
+		{
+			name:           "range too long",
+			request:        postGraphQL(`{ timeSeries(start: "2023-01-01", end: "2025-01-01") { date } }`),
+			wantStatusCode: http.StatusOK,
+			wantError:      "at most 366 are allowed",
+		},
+		{
+			name:           "syntax error",
+			request:        postGraphQL(`{ latest { date }`),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "Syntax Error",
+		},
+		{
+			name:           "unknown field",
+			request:        postGraphQL(`{ latest { price } }`),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `Cannot query field "price" on type "Rates".`,
+		},
+		{
+			name:           "missing query",
+			request:        httptest.NewRequest(http.MethodGet, GraphQLPath, nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      ErrMissingGraphQLQuery.Error(),
+		},
+		{
+			name:           "malformed body",
+			request:        httptest.NewRequest(http.MethodPost, GraphQLPath, strings.NewReader("{")),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "body must be a JSON GraphQL request",
+		},
+		{
+			name:           "unsupported method",
+			request:        httptest.NewRequest(http.MethodDelete, GraphQLPath, nil),
+			wantStatusCode: http.StatusMethodNotAllowed,
+			wantError:      "Method not allowed",
+		},
+		{
+			name:           "too complex",
+			request:        postGraphQL(`{ ` + strings.Repeat(`a: timeSeries(start: "2025-01-01", end: "2025-01-31") { date base rates } `, 20) + `}`),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "query complexity 1340 exceeds the maximum of 1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, response := serveGraphQL(t, tt.request)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Errorf("ServeGraphQL() status = %d, want %d: %s", recorder.Code, tt.wantStatusCode, recorder.Body.String())
+			}
+			if tt.wantData != "" {
+				data, _ := json.Marshal(response["data"])
+				if string(data) != tt.wantData {
+					t.Errorf("ServeGraphQL() data = %s, want %s", data, tt.wantData)
+				}
+			}
+
+			errors, _ := response["errors"].([]any)
+			if tt.wantError == "" {
+				if len(errors) != 0 {
+					t.Errorf("ServeGraphQL() errors = %v, want none", errors)
+				}
+				return
+			}
+			if len(errors) == 0 || !strings.Contains(errors[0].(map[string]any)["message"].(string), tt.wantError) {
+				t.Errorf("ServeGraphQL() errors = %v, want one containing %q", errors, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestServeGraphQL_MaxDepth(t *testing.T) {
+	useMockRepository(t, graphQLRepository())
+	original := GraphQLMaxDepth
+	GraphQLMaxDepth = 2
+	t.Cleanup(func() { GraphQLMaxDepth = original })
+
+	recorder, _ := serveGraphQL(t, postGraphQL(`{ latest { freshness { stale } } }`))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("ServeGraphQL() status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+
+	recorder, _ = serveGraphQL(t, postGraphQL(`{ latest { date } }`))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("ServeGraphQL() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+}
+
+func TestServeGraphQL_BatchesRepositoryCalls(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	inFlight, maxInFlight := 0, 0
+	record := func(call string) func() {
+		mu.Lock()
+		calls[call]++
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		// Hold the call long enough for the rest of its batch to start.
+		time.Sleep(20 * time.Millisecond)
+		return func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}
+	}
+
+	useMockRepository(t, &MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			defer record("latest " + base)()
+			return &ExchangeRateRecord{Base: base, Date: "2025-01-03", Rates: map[string]float64{"EUR": 1}}, nil
+		},
+		GetAllSymbolsFunc: func() (*SymbolsRecord, error) {
+			defer record("symbols")()
+			return &SymbolsRecord{Date: "2025-01-03", Symbols: []string{"EUR", "USD"}}, nil
+		},
+	})
+
+	recorder, _ := serveGraphQL(t, postGraphQL(`{
+		usd: latest(base: "USD") { date }
+		sameUSD: latest(base: "usd") { rates }
+		gbp: latest(base: "GBP") { date }
+		currencies { symbol }
+		german: currencies(locale: "de") { name }
+	}`))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("ServeGraphQL() status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	want := map[string]int{"latest USD": 1, "latest GBP": 1, "symbols": 1}
+	for call, count := range want {
+		if calls[call] != count {
+			t.Errorf("repository calls = %v, want %v", calls, want)
+			break
+		}
+	}
+	if maxInFlight != len(want) {
+		t.Errorf("at most %d repository calls ran at once, want the %d of the batch", maxInFlight, len(want))
+	}
+}
+
+func TestServeGraphQL_LimitsRepositoryCalls(t *testing.T) {
+	var mu sync.Mutex
+	calls, inFlight, maxInFlight := 0, 0, 0
+	useMockRepository(t, &MockRatesRepository{
+		GetRateOnDateFunc: func(base string, date string) (*ExchangeRateRecord, error) {
+			mu.Lock()
+			calls++
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return &ExchangeRateRecord{Base: base, Date: date, Rates: map[string]float64{"USD": 1}}, nil
+		},
+	})
+
+	var query strings.Builder
+	query.WriteString("{ ")
+	for day := range maxGraphQLCalls + 2 {
+		fmt.Fprintf(&query, `d%d: historical(date: "%s") { date } `, day, time.Date(2025, 1, day+1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly))
+	}
+	query.WriteString("}")
+
+	recorder, response := serveGraphQL(t, postGraphQL(query.String()))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("ServeGraphQL() status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if calls != maxGraphQLCalls {
+		t.Errorf("repository calls = %d, want %d", calls, maxGraphQLCalls)
+	}
+	if maxInFlight > batchConcurrency {
+		t.Errorf("at most %d repository calls ran at once, want at most %d", maxInFlight, batchConcurrency)
+	}
+	errors, _ := response["errors"].([]any)
+	if len(errors) != 2 || !strings.Contains(errors[0].(map[string]any)["message"].(string), ErrTooManyGraphQLCalls.Error()) {
+		t.Errorf("ServeGraphQL() errors = %v, want 2 for the calls past the cap", errors)
+	}
+}
+
+func TestMeasureQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		operationName  string
+		variables      map[string]any
+		wantDepth      int
+		wantComplexity int
+	}{
+		{name: "flat", query: `{ latest { date rates } }`, wantDepth: 2, wantComplexity: 3},
+		{name: "nested", query: `{ latest { freshness { stale } } }`, wantDepth: 3, wantComplexity: 3},
+		{name: "list", query: `{ currencies { symbol name } }`, wantDepth: 2, wantComplexity: 41},
+		{name: "fragments", query: `{ a: latest { ...rates } b: latest { ... on Rates { ...rates } } } fragment rates on Rates { date rates }`, wantDepth: 2, wantComplexity: 6},
+		{name: "introspection is free", query: `{ __schema { types { name } } latest { __typename date } }`, wantDepth: 2, wantComplexity: 2},
+		{name: "selected operation", query: `query A { latest { date } } query B { currencies { symbol } }`, operationName: "B", wantDepth: 2, wantComplexity: 21},
+		{name: "time series by publication day", query: `{ timeSeries(start: "2025-01-06", end: "2025-01-12") { date rates } }`, wantDepth: 2, wantComplexity: 11},
+		{name: "time series skips holidays", query: `{ timeSeries(start: "2024-12-23", end: "2025-01-01") { date } }`, wantDepth: 2, wantComplexity: 6},
+		{name: "time series from variables", query: `query($start: String!, $end: String = "2025-12-31") { timeSeries(start: $start, end: $end) { date } }`, variables: map[string]any{"start": "2025-01-01"}, wantDepth: 2, wantComplexity: 256},
+		{name: "invalid time series range", query: `{ timeSeries(start: "2025-01-10", end: "2025-01-06") { date } }`, wantDepth: 2, wantComplexity: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := parseGraphQLQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseGraphQLQuery() error = %v", err)
+			}
+
+			depth, complexity := measureQuery(document, tt.operationName, tt.variables)
+			if depth != tt.wantDepth || complexity != tt.wantComplexity {
+				t.Errorf("measureQuery() = %d, %d, want %d, %d", depth, complexity, tt.wantDepth, tt.wantComplexity)
+			}
+		})
+	}
+}
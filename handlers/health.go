@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+	"github.com/kamaal111/forex-api/version"
+)
+
+type (
+	HealthRecord    = api.HealthRecord
+	ReadinessCheck  = api.ReadinessCheck
+	ReadinessRecord = api.ReadinessRecord
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+
+	// readinessBase is the base currency probed by readiness checks, it is always published.
+	readinessBase = "EUR"
+)
+
+// ReadinessTimeout bounds how long a readiness probe waits for the repository, so a hanging
+// database fails the probe instead of outlasting it.
+var ReadinessTimeout = 2 * time.Second
+
+// MaxDataAge is how long the latest rates may lag behind the publication calendar before
+// the service reports itself as not ready.
+var MaxDataAge = 24 * time.Hour
+
+// CheckReadiness verifies that the repository is reachable and that the latest rates have
+// not been overdue for longer than MaxDataAge.
+func (s *RatesService) CheckReadiness() *ReadinessRecord {
+	report := &ReadinessRecord{Status: StatusOK, Checks: map[string]ReadinessCheck{}}
+	fail := func(name string, message string) {
+		report.Status = StatusUnavailable
+		report.Checks[name] = ReadinessCheck{Status: StatusUnavailable, Message: message}
+	}
+
+	record, err := s.Repository.GetLatestRate(readinessBase)
+	if err != nil {
+		fail("repository", err.Error())
+		fail("freshness", "repository unreachable")
+		return report
+	}
+	report.Checks["repository"] = ReadinessCheck{Status: StatusOK}
+
+	if record == nil {
+		fail("freshness", "no rates stored")
+		return report
+	}
+
+	freshness := ComputeFreshness(record.Date, s.Now())
+	if freshness == nil {
+		fail("freshness", fmt.Sprintf("invalid rates date %q", record.Date))
+		return report
+	}
+
+	if freshness.StaleFor() > MaxDataAge {
+		fail("freshness", fmt.Sprintf(
+			"latest rates from %s missed %d publication(s), expected %s",
+			record.Date, freshness.MissedPublications, freshness.ExpectedDate,
+		))
+		return report
+	}
+	report.Checks["freshness"] = ReadinessCheck{Status: StatusOK, Message: fmt.Sprintf("latest rates from %s", record.Date)}
+
+	return report
+}
+
+// GetHealth handles liveness probes.
+//
+// @Summary      Liveness probe
+// @Description  Reports that the process is up. Does not touch the database.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  api.HealthRecord
+// @Router       /healthz [get]
+func GetHealth(writer http.ResponseWriter, request *http.Request) {
+	utils.WriteJSON(writer, http.StatusOK, HealthRecord{Status: StatusOK})
+}
+
+// GetReadiness handles readiness probes.
+//
+// @Summary      Readiness probe
+// @Description  Reports whether the database is reachable and the latest rates are not overdue according to the publication calendar.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  api.ReadinessRecord
+// @Failure      503  {object}  api.ReadinessRecord
+// @Router       /readyz [get]
+func GetReadiness(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), ReadinessTimeout)
+	defer cancel()
+
+	service, closeService, err := openRatesService(ctx)
+	if err != nil {
+		utils.WriteJSON(writer, http.StatusServiceUnavailable, ReadinessRecord{
+			Status: StatusUnavailable,
+			Checks: map[string]ReadinessCheck{
+				"repository": {Status: StatusUnavailable, Message: err.Error()},
+			},
+		})
+		return
+	}
+	defer closeService()
+
+	report := service.CheckReadiness()
+
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	utils.WriteJSON(writer, status, report)
+}
+
+// GetVersion handles requests for the build information.
+//
+// @Summary      Get build information
+// @Description  Returns the commit, build time and Go version of the running binary.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  version.Info
+// @Router       /version [get]
+func GetVersion(writer http.ResponseWriter, request *http.Request) {
+	utils.WriteJSON(writer, http.StatusOK, version.Get())
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/version"
+)
+
+// useMockRepository makes handlers that open a RatesService serve from repo for the rest of the test.
+func useMockRepository(t *testing.T, repo RatesRepository) {
+	t.Helper()
+
+	original := openRatesService
+	openRatesService = func(ctx context.Context) (*RatesService, func(), error) {
+		return NewRatesService(repo), func() {}, nil
+	}
+	t.Cleanup(func() { openRatesService = original })
+}
+
+func TestGetHealthHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, HealthPath, nil)
+	recorder := httptest.NewRecorder()
+
+	GetHealth(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("GetHealth() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var record HealthRecord
+	if err := json.NewDecoder(recorder.Body).Decode(&record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if record.Status != StatusOK {
+		t.Errorf("GetHealth() status in body = %q, want %q", record.Status, StatusOK)
+	}
+}
+
+func TestGetVersionHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, VersionPath, nil)
+	recorder := httptest.NewRecorder()
+
+	GetVersion(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("GetVersion() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var info version.Info
+	if err := json.NewDecoder(recorder.Body).Decode(&info); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if info.GoVersion == "" {
+		t.Error("GetVersion() returned an empty go_version")
+	}
+}
+
+func TestGetReadinessHandler(t *testing.T) {
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	tests := []struct {
+		name           string
+		mockRecord     *ExchangeRateRecord
+		mockErr        error
+		wantStatusCode int
+		wantFreshness  string
+	}{
+		{
+			name:           "ready when rates are fresh",
+			mockRecord:     &ExchangeRateRecord{Base: "EUR", Date: today},
+			wantStatusCode: http.StatusOK,
+			wantFreshness:  StatusOK,
+		},
+		{
+			name:           "not ready when rates are too old",
+			mockRecord:     &ExchangeRateRecord{Base: "EUR", Date: "2020-01-02"},
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantFreshness:  StatusUnavailable,
+		},
+		{
+			name:           "not ready when no rates are stored",
+			mockRecord:     nil,
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantFreshness:  StatusUnavailable,
+		},
+		{
+			name:           "not ready when the repository fails",
+			mockErr:        errors.New("database error"),
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantFreshness:  StatusUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useMockRepository(t, &MockRatesRepository{
+				GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+					return tt.mockRecord, tt.mockErr
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, ReadinessPath, nil)
+			recorder := httptest.NewRecorder()
+
+			GetReadiness(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Errorf("GetReadiness() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+
+			var record ReadinessRecord
+			if err := json.NewDecoder(recorder.Body).Decode(&record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if got := record.Checks["freshness"].Status; got != tt.wantFreshness {
+				t.Errorf("GetReadiness() freshness check = %q, want %q", got, tt.wantFreshness)
+			}
+		})
+	}
+}
+
+func TestGetReadinessHandler_Timeout(t *testing.T) {
+	original := openRatesService
+	t.Cleanup(func() { openRatesService = original })
+
+	var deadline time.Time
+	openRatesService = func(ctx context.Context) (*RatesService, func(), error) {
+		deadline, _ = ctx.Deadline()
+		return nil, nil, errors.New("database unreachable")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, ReadinessPath, nil)
+	recorder := httptest.NewRecorder()
+
+	GetReadiness(recorder, req)
+
+	if deadline.IsZero() || deadline.After(time.Now().Add(ReadinessTimeout)) {
+		t.Errorf("GetReadiness() queried the repository with deadline %s, want at most %s after the request", deadline, ReadinessTimeout)
+	}
+}
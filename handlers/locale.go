@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kamaal111/forex-api/locales"
+)
+
+// negotiateLocale picks the response locale from the locale query parameter or the
+// Accept-Language header and advertises it on the response.
+func negotiateLocale(writer http.ResponseWriter, request *http.Request) *locales.Locale {
+	locale := locales.Negotiate(request.URL.Query().Get("locale"), request.Header.Get("Accept-Language"))
+
+	writer.Header().Set("Content-Language", locale.Tag)
+	writer.Header().Add("Vary", "Accept-Language")
+	return locale
+}
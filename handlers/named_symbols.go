@@ -1,11 +1,9 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 
-	"github.com/kamaal111/forex-api/database"
 	"github.com/kamaal111/forex-api/utils"
 )
 
@@ -13,25 +11,34 @@ import (
 //
 // @Summary      Get currencies with names and signs
 // @Description  Returns all available currencies with their human-readable names and currency signs.
+// @Description  Names and sign placement are localized, falling back from the region to the language and finally to English.
 // @Tags         currencies
 // @Produce      json
-// @Success      200  {object}  CurrenciesRecord
-// @Failure      404  {object}  utils.Error
-// @Failure      500  {object}  utils.Error
+// @Param        sort             query     string  false  "Order of the currencies, by code or localized name (default: stored order)"  Enums(code, name)
+// @Param        locale           query     string  false  "Locale of the currency names, e.g. de or nl-BE (overrides Accept-Language)"
+// @Param        Accept-Language  header    string  false  "Preferred locales of the currency names"
+// @Success      200              {object}  api.CurrenciesRecord
+// @Failure      400              {object}  api.Error
+// @Failure      404              {object}  api.Error
+// @Failure      500              {object}  api.Error
 // @Router       /v1/currencies [get]
 func GetCurrencies(writer http.ResponseWriter, request *http.Request) {
-	ctx := context.Background()
-	client, err := database.CreateClient(ctx)
+	sortBy, err := parseSort(request.URL.Query().Get("sort"))
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
 	if err != nil {
 		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer client.Close()
+	defer closeService()
 
-	repo := NewFirestoreRatesRepository(ctx, client)
-	service := NewRatesService(repo)
+	locale := negotiateLocale(writer, request)
 
-	record, err := service.GetAllNamedSymbols()
+	record, err := service.GetLocalizedNamedSymbols(locale)
 	if err != nil {
 		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
 		return
@@ -41,6 +48,8 @@ func GetCurrencies(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	SortNamedSymbols(record.Data, sortBy, record.Locale)
+
 	output, err := json.Marshal(record)
 	if err != nil {
 		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
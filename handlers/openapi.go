@@ -1,11 +1,28 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+	swaggerfiles "github.com/swaggo/files/v2"
 
 	forexdocs "github.com/kamaal111/forex-api/docs"
+	"github.com/kamaal111/forex-api/utils"
 )
 
+const FormatYAML = "yaml"
+
+var ErrInvalidSpecFormat = errors.New("format must be one of: json, yaml")
+
+// OpenAPIServers lists the server URLs written into the OpenAPI 3.1 document. When empty, the
+// document points to the scheme and host it was requested from.
+var OpenAPIServers []string
+
 // GetOpenAPISpec serves the OpenAPI specification in YAML format.
 //
 // @Summary      Download OpenAPI spec
@@ -18,3 +35,119 @@ func GetOpenAPISpec(writer http.ResponseWriter, request *http.Request) {
 	writer.Header().Set("Content-Type", "application/x-yaml")
 	writer.Write(forexdocs.SwaggerYAML)
 }
+
+// GetOpenAPI3Spec serves the OpenAPI 3.1 specification.
+//
+// @Summary      Download OpenAPI 3.1 spec
+// @Description  Returns the OpenAPI 3.1 specification for this API, in JSON by default or in YAML when requested through the format parameter or an Accept header of application/yaml.
+// @Description  Its servers are the configured server URLs, or the host the specification was requested from.
+// @Tags         openapi
+// @Produce      json
+// @Produce      application/yaml
+// @Param        format  query     string  false  "Response format (default: json)"  Enums(json, yaml)
+// @Success      200     {object}  object  "OpenAPI 3.1 spec"
+// @Failure      400     {object}  api.Error
+// @Failure      500     {object}  api.Error
+// @Router       /openapi.json [get]
+func GetOpenAPI3Spec(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Add("Vary", "Accept")
+
+	format, err := negotiateSpecFormat(request)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	shared, err := forexdocs.OpenAPI3()
+	if err != nil {
+		utils.ErrorHandler(writer, "Failed to load OpenAPI spec", http.StatusInternalServerError)
+		return
+	}
+
+	document := *shared
+	document.Servers = openAPIServers(request)
+
+	output, err := json.Marshal(&document)
+	if err != nil {
+		utils.ErrorHandler(writer, "Failed to encode OpenAPI spec", http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "application/json"
+	if format == FormatYAML {
+		if output, err = yaml.JSONToYAML(output); err != nil {
+			utils.ErrorHandler(writer, "Failed to encode OpenAPI spec", http.StatusInternalServerError)
+			return
+		}
+		contentType = "application/yaml"
+	}
+
+	writer.Header().Set("content-type", contentType)
+	writer.Write(output)
+}
+
+// negotiateSpecFormat picks the format of the OpenAPI 3.1 document from the format query
+// parameter, falling back to YAML in the Accept header and then to JSON.
+func negotiateSpecFormat(request *http.Request) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(request.URL.Query().Get("format"))); format {
+	case FormatJSON, FormatYAML:
+		return format, nil
+	case "":
+	default:
+		return "", ErrInvalidSpecFormat
+	}
+
+	for accepted := range strings.SplitSeq(request.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && (mediaType == "application/yaml" || mediaType == "application/x-yaml") {
+			return FormatYAML, nil
+		}
+	}
+	return FormatJSON, nil
+}
+
+// openAPIServers returns the configured servers, or the one the request was sent to. Behind a
+// proxy terminating TLS, X-Forwarded-Proto tells the scheme the client used.
+func openAPIServers(request *http.Request) openapi3.Servers {
+	if len(OpenAPIServers) > 0 {
+		servers := make(openapi3.Servers, 0, len(OpenAPIServers))
+		for _, server := range OpenAPIServers {
+			servers = append(servers, &openapi3.Server{URL: server})
+		}
+		return servers
+	}
+
+	scheme := "http"
+	if request.TLS != nil || strings.EqualFold(request.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return openapi3.Servers{{URL: scheme + "://" + request.Host}}
+}
+
+// docsInitializer configures the docs UI to load the OpenAPI 3.1 document, relative to the
+// page so the UI keeps working behind a path prefix.
+const docsInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "../openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+var docsFiles = http.StripPrefix(DocsPath, http.FileServerFS(swaggerfiles.FS))
+
+// GetDocs serves the interactive API documentation. The UI is embedded in the binary, so it
+// works without access to a CDN.
+func GetDocs(writer http.ResponseWriter, request *http.Request) {
+	if strings.TrimPrefix(request.URL.Path, DocsPath) == "swagger-initializer.js" {
+		writer.Header().Set("content-type", "text/javascript; charset=utf-8")
+		writer.Write([]byte(docsInitializer))
+		return
+	}
+
+	docsFiles.ServeHTTP(writer, request)
+}
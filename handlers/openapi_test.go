@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func TestGetOpenAPISpecHandler(t *testing.T) {
@@ -31,3 +34,93 @@ func TestGetOpenAPISpecHandler(t *testing.T) {
 		t.Error("GetOpenAPISpec() response does not appear to be a valid OpenAPI spec")
 	}
 }
+
+func TestGetOpenAPI3SpecHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		accept          string
+		forwardedProto  string
+		servers         []string
+		wantStatusCode  int
+		wantContentType string
+		wantServer      string
+	}{
+		{name: "defaults to JSON for the requested host", target: OpenAPI3Path, wantStatusCode: http.StatusOK, wantContentType: "application/json", wantServer: "http://forex.example.com"},
+		{name: "honours the forwarded scheme", target: OpenAPI3Path, forwardedProto: "https", wantStatusCode: http.StatusOK, wantContentType: "application/json", wantServer: "https://forex.example.com"},
+		{name: "uses the configured servers", target: OpenAPI3Path, servers: []string{"https://api.example.com"}, wantStatusCode: http.StatusOK, wantContentType: "application/json", wantServer: "https://api.example.com"},
+		{name: "YAML through the format parameter", target: OpenAPI3Path + "?format=yaml", wantStatusCode: http.StatusOK, wantContentType: "application/yaml", wantServer: "http://forex.example.com"},
+		{name: "YAML through the Accept header", target: OpenAPI3Path, accept: "application/yaml", wantStatusCode: http.StatusOK, wantContentType: "application/yaml", wantServer: "http://forex.example.com"},
+		{name: "rejects unknown formats", target: OpenAPI3Path + "?format=xml", wantStatusCode: http.StatusBadRequest, wantContentType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := OpenAPIServers
+			OpenAPIServers = tt.servers
+			t.Cleanup(func() { OpenAPIServers = previous })
+
+			req := httptest.NewRequest(http.MethodGet, "http://forex.example.com"+tt.target, nil)
+			req.Header.Set("Accept", tt.accept)
+			req.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+			recorder := httptest.NewRecorder()
+
+			GetOpenAPI3Spec(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetOpenAPI3Spec() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != tt.wantContentType {
+				t.Errorf("GetOpenAPI3Spec() Content-Type = %q, want %q", contentType, tt.wantContentType)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			document, err := openapi3.NewLoader().LoadFromData(recorder.Body.Bytes())
+			if err != nil {
+				t.Fatalf("failed to load the document: %v", err)
+			}
+			if err := document.Validate(context.Background()); err != nil {
+				t.Errorf("GetOpenAPI3Spec() document is invalid: %v", err)
+			}
+			if document.OpenAPI != "3.1.0" {
+				t.Errorf("GetOpenAPI3Spec() version = %q, want %q", document.OpenAPI, "3.1.0")
+			}
+			if len(document.Servers) != 1 || document.Servers[0].URL != tt.wantServer {
+				t.Errorf("GetOpenAPI3Spec() servers = %v, want [%s]", document.Servers, tt.wantServer)
+			}
+			if document.Paths.Find(LatestPath) == nil {
+				t.Errorf("GetOpenAPI3Spec() paths lack %s", LatestPath)
+			}
+		})
+	}
+}
+
+func TestGetDocs(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantContains string
+	}{
+		{name: "serves the UI page", path: DocsPath, wantContains: "swagger-initializer.js"},
+		{name: "points the UI to the OpenAPI 3.1 spec", path: DocsPath + "swagger-initializer.js", wantContains: `url: "../openapi.json"`},
+		{name: "serves embedded assets", path: DocsPath + "swagger-ui.css", wantContains: ".swagger-ui"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			GetDocs(recorder, req)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("GetDocs() status = %d, want %d", recorder.Code, http.StatusOK)
+			}
+			if !strings.Contains(recorder.Body.String(), tt.wantContains) {
+				t.Errorf("GetDocs() body lacks %q", tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxDateRangeDays is the most days a date range read day by day may span, both ends
+// included. It bounds how many documents a single request reads.
+const MaxDateRangeDays = 366
+
+const (
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+	IntervalYear  = "year"
+)
+
+var (
+	ErrInvalidInterval  = errors.New("invalid interval")
+	ErrInvalidDateRange = errors.New("invalid date range")
+)
+
+// parseInterval validates an interval query parameter against the intervals an endpoint
+// supports. An empty value selects fallback.
+func parseInterval(raw string, fallback string, allowed ...string) (string, error) {
+	interval := strings.ToLower(strings.TrimSpace(raw))
+	if interval == "" {
+		return fallback, nil
+	}
+	for _, candidate := range allowed {
+		if interval == candidate {
+			return interval, nil
+		}
+	}
+	return "", fmt.Errorf("%w: must be one of: %s", ErrInvalidInterval, strings.Join(allowed, ", "))
+}
+
+// parseDateRange parses the start and end of an inclusive YYYY-MM-DD date range.
+func parseDateRange(start string, end string) (time.Time, time.Time, error) {
+	startDay, err := parseDate(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endDay, err := parseDate(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if startDay.After(endDay) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: start must not be after end", ErrInvalidDateRange)
+	}
+	return startDay, endDay, nil
+}
+
+// limitDateRange fails when the inclusive range from startDay to endDay spans more than
+// MaxDateRangeDays.
+func limitDateRange(startDay time.Time, endDay time.Time) error {
+	if days := calendarDays(startDay, endDay); days > MaxDateRangeDays {
+		return fmt.Errorf("%w: it spans %d days, at most %d are allowed", ErrInvalidDateRange, days, MaxDateRangeDays)
+	}
+	return nil
+}
+
+// calendarDays counts the days from startDay to endDay, both included. It compares the dates
+// in UTC, where days never gain or lose an hour to daylight saving time.
+func calendarDays(startDay time.Time, endDay time.Time) int {
+	start := time.Date(startDay.Year(), startDay.Month(), startDay.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(endDay.Year(), endDay.Month(), endDay.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start)/(24*time.Hour)) + 1
+}
+
+// periodBounds returns the first and last day of the interval containing day. Weeks run from
+// Monday to Sunday as in ISO 8601.
+func periodBounds(day time.Time, interval string) (time.Time, time.Time) {
+	switch interval {
+	case IntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		start := time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 0, 6)
+	case IntervalYear:
+		start := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(1, 0, -1)
+	default:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, -1)
+	}
+}
+
+// ratePeriod holds the records published within one interval of a date range.
+type ratePeriod struct {
+	// Start and End are the bounds of the interval, clipped to the requested range.
+	Start   time.Time
+	End     time.Time
+	Records []ExchangeRateRecord
+}
+
+// groupByPeriod splits records sorted by date into consecutive intervals within the range
+// from rangeStart to rangeEnd. Intervals without records are left out.
+func groupByPeriod(records []ExchangeRateRecord, interval string, rangeStart time.Time, rangeEnd time.Time) []ratePeriod {
+	var periods []ratePeriod
+	for _, record := range records {
+		day, err := parseDate(record.Date)
+		if err != nil || day.Before(rangeStart) || day.After(rangeEnd) {
+			continue
+		}
+
+		if len(periods) > 0 && !day.After(periods[len(periods)-1].End) {
+			current := &periods[len(periods)-1]
+			current.Records = append(current.Records, record)
+			continue
+		}
+
+		start, end := periodBounds(day, interval)
+		if start.Before(rangeStart) {
+			start = rangeStart
+		}
+		if end.After(rangeEnd) {
+			end = rangeEnd
+		}
+		periods = append(periods, ratePeriod{Start: start, End: end, Records: []ExchangeRateRecord{record}})
+	}
+	return periods
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty uses fallback", raw: "", want: IntervalMonth},
+		{name: "week", raw: "week", want: IntervalWeek},
+		{name: "case insensitive", raw: " Month ", want: IntervalMonth},
+		{name: "not allowed", raw: "day", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInterval(tt.raw, IntervalMonth, IntervalWeek, IntervalMonth)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInterval) {
+					t.Errorf("parseInterval(%q) error = %v, want %v", tt.raw, err, ErrInvalidInterval)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("parseInterval(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitDateRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{name: "single day", start: "2025-01-01", end: "2025-01-01"},
+		{name: "leap year", start: "2024-01-01", end: "2024-12-31"},
+		{name: "one day too long", start: "2025-01-01", end: "2026-01-02", wantErr: true},
+		{name: "across daylight saving changes", start: "2025-03-30", end: "2026-03-30"},
+		// The range loses an hour to daylight saving time, which must not hide its last day.
+		{name: "too long across daylight saving changes", start: "2025-03-29", end: "2026-03-30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startDay, endDay, err := parseDateRange(tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("parseDateRange(%s, %s) error = %v", tt.start, tt.end, err)
+			}
+			err = limitDateRange(startDay, endDay)
+			if tt.wantErr != errors.Is(err, ErrInvalidDateRange) {
+				t.Errorf("limitDateRange(%s, %s) error = %v, wantErr %v", tt.start, tt.end, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPeriodBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		day       string
+		interval  string
+		wantStart string
+		wantEnd   string
+	}{
+		{name: "week from a Wednesday", day: "2025-01-15", interval: IntervalWeek, wantStart: "2025-01-13", wantEnd: "2025-01-19"},
+		{name: "week from a Sunday", day: "2025-01-19", interval: IntervalWeek, wantStart: "2025-01-13", wantEnd: "2025-01-19"},
+		{name: "week across a year", day: "2025-01-01", interval: IntervalWeek, wantStart: "2024-12-30", wantEnd: "2025-01-05"},
+		{name: "month", day: "2025-02-14", interval: IntervalMonth, wantStart: "2025-02-01", wantEnd: "2025-02-28"},
+		{name: "month in a leap year", day: "2024-02-14", interval: IntervalMonth, wantStart: "2024-02-01", wantEnd: "2024-02-29"},
+		{name: "year", day: "2024-07-04", interval: IntervalYear, wantStart: "2024-01-01", wantEnd: "2024-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, _ := parseDate(tt.day)
+			start, end := periodBounds(day, tt.interval)
+			if start.Format(time.DateOnly) != tt.wantStart || end.Format(time.DateOnly) != tt.wantEnd {
+				t.Errorf("periodBounds(%s, %s) = %s..%s, want %s..%s", tt.day, tt.interval,
+					start.Format(time.DateOnly), end.Format(time.DateOnly), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestGroupByPeriod(t *testing.T) {
+	records := []ExchangeRateRecord{
+		{Date: "2025-01-30"}, {Date: "2025-01-31"}, {Date: "2025-02-03"}, {Date: "2025-03-03"},
+	}
+	rangeStart, _ := parseDate("2025-01-20")
+	rangeEnd, _ := parseDate("2025-03-10")
+
+	periods := groupByPeriod(records, IntervalMonth, rangeStart, rangeEnd)
+
+	want := []struct {
+		start, end string
+		records    int
+	}{
+		{start: "2025-01-20", end: "2025-01-31", records: 2},
+		{start: "2025-02-01", end: "2025-02-28", records: 1},
+		{start: "2025-03-01", end: "2025-03-10", records: 1},
+	}
+	if len(periods) != len(want) {
+		t.Fatalf("groupByPeriod() returned %d periods, want %d", len(periods), len(want))
+	}
+	for i, period := range periods {
+		start, end := period.Start.Format(time.DateOnly), period.End.Format(time.DateOnly)
+		if start != want[i].start || end != want[i].end || len(period.Records) != want[i].records {
+			t.Errorf("groupByPeriod() period %d = %s..%s with %d records, want %s..%s with %d",
+				i, start, end, len(period.Records), want[i].start, want[i].end, want[i].records)
+		}
+	}
+}
@@ -18,6 +18,39 @@ type FirestoreRatesRepository struct {
 	ctx    context.Context
 }
 
+// sharedClient is the Firestore client set by UseFirestoreClient.
+var sharedClient *firestore.Client
+
+// UseFirestoreClient makes every request query Firestore through client instead of opening a
+// connection of its own. The caller owns client and closes it once the server stops; it must
+// be called before serving.
+func UseFirestoreClient(client *firestore.Client) {
+	sharedClient = client
+}
+
+// openRatesService returns a service backed by Firestore, together with a function that
+// releases it. It connects to Firestore unless UseFirestoreClient was called. Tests swap it
+// out to serve from a mock repository.
+var openRatesService = func(ctx context.Context) (*RatesService, func(), error) {
+	if sharedClient != nil {
+		return NewRatesService(NewFirestoreRatesRepository(ctx, sharedClient)), func() {}, nil
+	}
+
+	client, err := database.CreateClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	service := NewRatesService(NewFirestoreRatesRepository(ctx, client))
+	return service, func() { client.Close() }, nil
+}
+
+// OpenRatesService opens a service the way the handlers do, for the APIs served by other
+// packages. The caller must call the returned function once done with the service.
+func OpenRatesService(ctx context.Context) (*RatesService, func(), error) {
+	return openRatesService(ctx)
+}
+
 func NewFirestoreRatesRepository(ctx context.Context, client *firestore.Client) *FirestoreRatesRepository {
 	return &FirestoreRatesRepository{client: client, ctx: ctx}
 }
@@ -54,6 +87,60 @@ func (r *FirestoreRatesRepository) GetLatestRate(base string) (*ExchangeRateReco
 	return &record, nil
 }
 
+func (r *FirestoreRatesRepository) GetRateOnDate(base string, date string) (*ExchangeRateRecord, error) {
+	documents := r.client.Collection("exchange_rates").
+		Where("base", "==", base).
+		Where("date", "<=", date).
+		OrderBy("date", firestore.Desc).
+		Limit(1).
+		Documents(r.ctx)
+	defer documents.Stop()
+
+	document, err := documents.Next()
+	if errors.Is(err, iterator.Done) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record ExchangeRateRecord
+	if err := document.DataTo(&record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *FirestoreRatesRepository) GetRatesBetween(base string, start string, end string) ([]ExchangeRateRecord, error) {
+	documents := r.client.Collection("exchange_rates").
+		Where("base", "==", base).
+		Where("date", ">=", start).
+		Where("date", "<=", end).
+		OrderBy("date", firestore.Asc).
+		Documents(r.ctx)
+	defer documents.Stop()
+
+	var records []ExchangeRateRecord
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var record ExchangeRateRecord
+		if err := document.DataTo(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
 func (r *FirestoreRatesRepository) GetAllSymbols() (*SymbolsRecord, error) {
 	documents := r.client.Collection("symbols").
 		OrderBy("date", firestore.Desc).
@@ -83,25 +170,24 @@ var ErrRatesNotFound = errors.New("rates not found")
 //
 // @Summary      Get latest exchange rates
 // @Description  Get the latest currency exchange rates, optionally filtered by base currency and target symbols.
+// @Description  The X-Data-Staleness header reports, in seconds, how long the rates have been overdue according to the publication calendar.
 // @Tags         rates
 // @Produce      json
-// @Param        base     query     string  false  "Base currency code (default: EUR)"
+// @Param        base     query     string  false  "Base currency code; unsupported codes fall back to the default (default: EUR)"  minlength(3)  maxlength(3)
 // @Param        symbols  query     string  false  "Comma-separated list of target currency symbols"
-// @Success      200      {object}  ExchangeRateRecord
-// @Failure      404      {object}  utils.Error
-// @Failure      500      {object}  utils.Error
+// @Success      200      {object}  api.ExchangeRateRecord
+// @Header       200      {integer}  X-Data-Staleness      "Seconds the rates have been overdue, 0 when up to date"
+// @Header       200      {string}   X-Data-Expected-Date  "Date of the latest publication that should be available"
+// @Failure      404      {object}  api.Error
+// @Failure      500      {object}  api.Error
 // @Router       /v1/rates/latest [get]
 func GetLatest(writer http.ResponseWriter, request *http.Request) {
-	ctx := context.Background()
-	client, err := database.CreateClient(ctx)
+	service, closeService, err := openRatesService(request.Context())
 	if err != nil {
 		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer client.Close()
-
-	repo := NewFirestoreRatesRepository(ctx, client)
-	service := NewRatesService(repo)
+	defer closeService()
 
 	base := request.URL.Query().Get("base")
 	symbols := request.URL.Query().Get("symbols")
@@ -123,6 +209,64 @@ func GetLatest(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	setFreshnessHeaders(writer, record.Freshness)
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(output)
+}
+
+// GetHistorical handles requests for the exchange rates in effect on a date.
+//
+// @Summary      Get historical exchange rates
+// @Description  Get the currency exchange rates in effect on a date, which are those of the most recent publication on or before it.
+// @Description  The freshness of the rates is judged against the publication calendar as it stood at the end of the requested day.
+// @Tags         rates
+// @Produce      json
+// @Param        date     query     string  true   "Date (YYYY-MM-DD)"  Format(date)
+// @Param        base     query     string  false  "Base currency code; unsupported codes fall back to the default (default: EUR)"  minlength(3)  maxlength(3)
+// @Param        symbols  query     string  false  "Comma-separated list of target currency symbols"
+// @Success      200      {object}  api.ExchangeRateRecord
+// @Header       200      {integer}  X-Data-Staleness      "Seconds the rates had been overdue on the date, 0 when up to date"
+// @Header       200      {string}   X-Data-Expected-Date  "Date of the latest publication that should have been available on the date"
+// @Failure      400      {object}  api.Error
+// @Failure      404      {object}  api.Error
+// @Failure      500      {object}  api.Error
+// @Router       /v1/rates/historical [get]
+func GetHistorical(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	if query.Get("date") == "" {
+		utils.ErrorHandler(writer, "date is required", http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	record, err := service.GetRateOnDate(query.Get("base"), query.Get("symbols"), query.Get("date"))
+	if errors.Is(err, ErrInvalidDate) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if record == nil {
+		utils.ErrorHandler(writer, "Rates not found", http.StatusNotFound)
+		return
+	}
+
+	output, err := json.Marshal(record)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	setFreshnessHeaders(writer, record.Freshness)
 	writer.Header().Set("content-type", "application/json")
 	writer.Write(output)
 }
@@ -33,6 +33,7 @@ func TestableHandler(repo RatesRepository) http.HandlerFunc {
 			return
 		}
 
+		setFreshnessHeaders(writer, record.Freshness)
 		writer.Header().Set("content-type", "application/json")
 		writer.Write(output)
 	}
@@ -143,6 +144,56 @@ func TestGetLatestHandler(t *testing.T) {
 	}
 }
 
+func TestGetHistoricalHandler(t *testing.T) {
+	useMockRepository(t, &MockRatesRepository{
+		GetRateOnDateFunc: func(base string, date string) (*ExchangeRateRecord, error) {
+			if base != "EUR" {
+				return nil, nil
+			}
+			return &ExchangeRateRecord{Base: "EUR", Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03, "GBP": 0.83}}, nil
+		},
+	})
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		wantStatusCode int
+		wantRatesCount int
+	}{
+		{name: "rates in effect on the date", queryParams: "?date=2025-01-05&symbols=USD", wantStatusCode: http.StatusOK, wantRatesCount: 1},
+		{name: "missing date", queryParams: "", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid date", queryParams: "?date=05-01-2025", wantStatusCode: http.StatusBadRequest},
+		{name: "no rates for the base", queryParams: "?date=2025-01-05&base=USD", wantStatusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, HistoricalPath+tt.queryParams, nil)
+			recorder := httptest.NewRecorder()
+
+			GetHistorical(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetHistorical() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var response ExchangeRateRecord
+			if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Date != "2025-01-03" || len(response.Rates) != tt.wantRatesCount {
+				t.Errorf("GetHistorical() = %+v, want %d rates of 2025-01-03", response, tt.wantRatesCount)
+			}
+			if recorder.Header().Get("X-Data-Expected-Date") == "" {
+				t.Error("GetHistorical() didn't set the freshness headers")
+			}
+		})
+	}
+}
+
 func TestGetLatestHandler_SymbolsFiltering(t *testing.T) {
 	sampleRecord := &ExchangeRateRecord{
 		Base: "EUR",
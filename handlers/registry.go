@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+//go:embed data/currencies.json
+var defaultCurrencies []byte
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// CurrencySource provides currency definitions to a CurrencyRegistry.
+type CurrencySource interface {
+	LoadCurrencies(ctx context.Context) ([]CurrencyInfo, error)
+}
+
+// EmbeddedCurrencySource serves the currency definitions compiled into the binary.
+type EmbeddedCurrencySource struct{}
+
+func (EmbeddedCurrencySource) LoadCurrencies(ctx context.Context) ([]CurrencyInfo, error) {
+	return decodeCurrencies(defaultCurrencies)
+}
+
+// FileCurrencySource reads currency definitions from a JSON file in the same format as the
+// embedded defaults.
+type FileCurrencySource struct {
+	Path string
+}
+
+func (s FileCurrencySource) LoadCurrencies(ctx context.Context) ([]CurrencyInfo, error) {
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCurrencies(content)
+}
+
+// FirestoreCurrencySource reads currency definitions from a Firestore collection holding one
+// document per currency, through the client the server shares.
+type FirestoreCurrencySource struct {
+	Client     *firestore.Client
+	Collection string
+}
+
+func (s FirestoreCurrencySource) LoadCurrencies(ctx context.Context) ([]CurrencyInfo, error) {
+	documents := s.Client.Collection(s.Collection).Documents(ctx)
+	defer documents.Stop()
+
+	var currencies []CurrencyInfo
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var info CurrencyInfo
+		if err := document.DataTo(&info); err != nil {
+			return nil, fmt.Errorf("currency %s: %w", document.Ref.ID, err)
+		}
+		if info.Code == "" {
+			info.Code = document.Ref.ID
+		}
+		currencies = append(currencies, info)
+	}
+	return currencies, nil
+}
+
+func decodeCurrencies(content []byte) ([]CurrencyInfo, error) {
+	var currencies []CurrencyInfo
+	if err := json.Unmarshal(content, &currencies); err != nil {
+		return nil, err
+	}
+	return currencies, nil
+}
+
+// CurrencyRegistry holds the currencies the API knows about. It is assembled from a chain
+// of sources where later sources add currencies or override earlier definitions, and can be
+// reloaded at runtime without a restart.
+type CurrencyRegistry struct {
+	sources []CurrencySource
+
+	mu         sync.RWMutex
+	currencies map[string]CurrencyInfo
+	codes      []string
+}
+
+func NewCurrencyRegistry(sources ...CurrencySource) *CurrencyRegistry {
+	return &CurrencyRegistry{sources: sources, currencies: map[string]CurrencyInfo{}}
+}
+
+// Registry is the live currency registry consulted by the handlers.
+var Registry = func() *CurrencyRegistry {
+	registry := NewCurrencyRegistry(EmbeddedCurrencySource{})
+	if err := registry.Reload(context.Background()); err != nil {
+		panic(fmt.Sprintf("invalid embedded currency registry: %v", err))
+	}
+	return registry
+}()
+
+// SetSources replaces the sources the registry reloads from. The change takes effect on the
+// next Reload.
+func (r *CurrencyRegistry) SetSources(sources ...CurrencySource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = sources
+}
+
+// Reload rebuilds the registry from its sources. The current currencies are kept when any
+// source fails or the result is invalid.
+func (r *CurrencyRegistry) Reload(ctx context.Context) error {
+	r.mu.RLock()
+	sources := r.sources
+	r.mu.RUnlock()
+
+	currencies := map[string]CurrencyInfo{}
+	var codes []string
+	for _, source := range sources {
+		loaded, err := source.LoadCurrencies(ctx)
+		if err != nil {
+			return err
+		}
+		for _, info := range loaded {
+			if _, ok := currencies[info.Code]; !ok {
+				codes = append(codes, info.Code)
+			}
+			currencies[info.Code] = info
+		}
+	}
+
+	if err := validateCurrencies(currencies); err != nil {
+		return err
+	}
+	slices.Sort(codes)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.currencies = currencies
+	r.codes = codes
+	return nil
+}
+
+// Lookup returns the definition of a currency code.
+func (r *CurrencyRegistry) Lookup(code string) (CurrencyInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.currencies[code]
+	return info, ok
+}
+
+// Contains reports whether a currency code is known.
+func (r *CurrencyRegistry) Contains(code string) bool {
+	_, ok := r.Lookup(code)
+	return ok
+}
+
+// Codes returns the known currency codes in alphabetical order.
+func (r *CurrencyRegistry) Codes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	codes := make([]string, len(r.codes))
+	copy(codes, r.codes)
+	return codes
+}
+
+func validateCurrencies(currencies map[string]CurrencyInfo) error {
+	if len(currencies) == 0 {
+		return errors.New("currency registry is empty")
+	}
+
+	var problems []error
+	for code, info := range currencies {
+		if !currencyCodePattern.MatchString(code) {
+			problems = append(problems, fmt.Errorf("invalid currency code %q", code))
+		}
+		if info.Name == "" {
+			problems = append(problems, fmt.Errorf("%s: missing name", code))
+		}
+		if info.MinorUnits < 0 {
+			problems = append(problems, fmt.Errorf("%s: negative minor units", code))
+		}
+		if info.ReplacedBy != "" {
+			if _, ok := currencies[info.ReplacedBy]; !ok {
+				problems = append(problems, fmt.Errorf("%s: replaced by unknown currency %s", code, info.ReplacedBy))
+			}
+		}
+	}
+	return errors.Join(problems...)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type staticCurrencySource struct {
+	currencies []CurrencyInfo
+	err        error
+}
+
+func (s staticCurrencySource) LoadCurrencies(ctx context.Context) ([]CurrencyInfo, error) {
+	return s.currencies, s.err
+}
+
+func TestCurrencyRegistry_EmbeddedDefaults(t *testing.T) {
+	registry := NewCurrencyRegistry(EmbeddedCurrencySource{})
+	if err := registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	info, ok := registry.Lookup("JPY")
+	if !ok {
+		t.Fatal("Lookup(JPY) not found")
+	}
+	if info.MinorUnits != 0 {
+		t.Errorf("JPY minor units = %d, want 0", info.MinorUnits)
+	}
+	if len(registry.Codes()) != len(Registry.Codes()) {
+		t.Errorf("Codes() returned %d currencies, want %d", len(registry.Codes()), len(Registry.Codes()))
+	}
+}
+
+func TestCurrencyRegistry_FileOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "currencies.json")
+	override := `[
+		{"code": "USD", "name": "United States Dollar", "sign": "US$", "numeric_code": "840", "minor_units": 2, "countries": ["US"]},
+		{"code": "XAU", "name": "Gold", "sign": "XAU", "numeric_code": "959", "minor_units": 0, "countries": []}
+	]`
+	if err := os.WriteFile(path, []byte(override), 0o600); err != nil {
+		t.Fatalf("failed to write override: %v", err)
+	}
+
+	registry := NewCurrencyRegistry(EmbeddedCurrencySource{}, FileCurrencySource{Path: path})
+	if err := registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if info, _ := registry.Lookup("USD"); info.Sign != "US$" {
+		t.Errorf("USD sign = %q, want overridden %q", info.Sign, "US$")
+	}
+	if !registry.Contains("XAU") {
+		t.Error("override did not add XAU")
+	}
+	if !registry.Contains("EUR") {
+		t.Error("override dropped embedded EUR")
+	}
+}
+
+func TestCurrencyRegistry_ReloadKeepsCurrentOnFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		source CurrencySource
+	}{
+		{name: "source error", source: staticCurrencySource{err: errors.New("unreachable")}},
+		{name: "missing file", source: FileCurrencySource{Path: filepath.Join(t.TempDir(), "missing.json")}},
+		{name: "invalid code", source: staticCurrencySource{currencies: []CurrencyInfo{{Code: "usd", Name: "Lowercase"}}}},
+		{name: "unknown replacement", source: staticCurrencySource{currencies: []CurrencyInfo{{Code: "ABC", Name: "Old", ReplacedBy: "XYZ"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewCurrencyRegistry(EmbeddedCurrencySource{})
+			if err := registry.Reload(context.Background()); err != nil {
+				t.Fatalf("Reload() error = %v", err)
+			}
+			before := len(registry.Codes())
+
+			registry.SetSources(EmbeddedCurrencySource{}, tt.source)
+			if err := registry.Reload(context.Background()); err == nil {
+				t.Fatal("Reload() error = nil, want error")
+			}
+
+			if got := len(registry.Codes()); got != before {
+				t.Errorf("Codes() after failed reload = %d currencies, want %d", got, before)
+			}
+		})
+	}
+}
+
+func TestCurrencyRegistry_LiveLookups(t *testing.T) {
+	original := Registry
+	t.Cleanup(func() { Registry = original })
+
+	Registry = NewCurrencyRegistry(staticCurrencySource{currencies: []CurrencyInfo{
+		{Code: "EUR", Name: "Euro", Sign: "€", MinorUnits: 2},
+		{Code: "XAU", Name: "Gold", Sign: "XAU"},
+	}})
+	if err := Registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if got := NormalizeBase("xau"); got != "XAU" {
+		t.Errorf("NormalizeBase(xau) = %q, want %q", got, "XAU")
+	}
+	if got := NormalizeBase("USD"); got != "EUR" {
+		t.Errorf("NormalizeBase(USD) = %q, want %q after USD left the registry", got, "EUR")
+	}
+
+	symbols := MakeSymbolsArray("XAU,USD", "EUR")
+	if len(symbols) != 1 || symbols[0] != "XAU" {
+		t.Errorf("MakeSymbolsArray(XAU,USD) = %v, want [XAU]", symbols)
+	}
+}
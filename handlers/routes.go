@@ -1,8 +1,33 @@
 package handlers
 
+import "github.com/kamaal111/forex-api/api"
+
 const (
-	LatestPath      = "/v1/rates/latest"
-	SymbolsPath     = "/v1/rates/symbols"
-	CurrenciesPath  = "/v1/currencies"
-	OpenAPISpecPath = "/openapi.yaml"
+	LatestPath       = api.LatestPath
+	HistoricalPath   = api.HistoricalPath
+	SymbolsPath      = api.SymbolsPath
+	BatchPath        = api.BatchPath
+	FluctuationPath  = api.FluctuationPath
+	StatsPath        = api.StatsPath
+	CandlesPath      = api.CandlesPath
+	StreamPath       = api.StreamPath
+	CurrenciesPath   = api.CurrenciesPath
+	CurrencyPath     = api.CurrencyPath
+	FormatPath       = api.FormatPath
+	WebhooksPath     = api.WebhooksPath
+	WebhookPath      = api.WebhookPath
+	AdminRatesPath   = api.AdminRatesPath
+	AdminSymbolsPath = api.AdminSymbolsPath
+	AdminAuditPath   = api.AdminAuditPath
+	V2LatestPath     = api.V2LatestPath
+	V2SymbolsPath    = api.V2SymbolsPath
+	V2CurrenciesPath = api.V2CurrenciesPath
+	GraphQLPath      = api.GraphQLPath
+	OpenAPISpecPath  = api.OpenAPISpecPath
+	OpenAPI3Path     = api.OpenAPI3Path
+	DocsPath         = api.DocsPath
+	DebugVarsPath    = api.DebugVarsPath
+	HealthPath       = api.HealthPath
+	ReadinessPath    = api.ReadinessPath
+	VersionPath      = api.VersionPath
 )
@@ -1,49 +1,70 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
-	"github.com/kamaal111/forex-api/utils"
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/locales"
 )
 
-type ExchangeRateRecord struct {
-	Base  string             `json:"base"`
-	Date  string             `json:"date"`
-	Rates map[string]float64 `json:"rates"`
-}
+type (
+	CurrencyRecord   = api.CurrencyRecord
+	NamedSymbol      = api.NamedSymbol
+	CurrenciesRecord = api.CurrenciesRecord
+)
 
-type SymbolsRecord struct {
-	Date    string   `json:"date" firestore:"date"`
-	Symbols []string `json:"symbols" firestore:"symbols"`
-}
+type (
+	ExchangeRateRecord = api.ExchangeRateRecord
+	SymbolsRecord      = api.SymbolsRecord
+)
 
+var ErrInvalidDate = errors.New("dates must be formatted as YYYY-MM-DD")
+
+// CurrencyInfo holds the ISO 4217 metadata of a currency.
 type CurrencyInfo struct {
-	Name string
-	Sign string
+	Code        string `json:"code" firestore:"code"`
+	Name        string `json:"name" firestore:"name"`
+	Sign        string `json:"sign" firestore:"sign"`
+	NumericCode string `json:"numeric_code" firestore:"numeric_code"`
+	// MinorUnits is the number of decimal places used by the currency.
+	MinorUnits int `json:"minor_units" firestore:"minor_units"`
+	// Countries lists the ISO 3166-1 alpha-2 codes of the countries using the currency.
+	Countries []string `json:"countries" firestore:"countries"`
+	// Withdrawn is the year and month (YYYY-MM) the currency was withdrawn, empty while active.
+	Withdrawn  string `json:"withdrawn,omitempty" firestore:"withdrawn,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty" firestore:"replaced_by,omitempty"`
 }
 
-type NamedSymbol struct {
-	Symbol string `json:"symbol"`
-	Name   string `json:"name"`
-	Sign   string `json:"sign"`
+func (c CurrencyInfo) Active() bool {
+	return c.Withdrawn == ""
 }
 
-type CurrenciesRecord struct {
-	Date string        `json:"date"`
-	Data []NamedSymbol `json:"data"`
-}
+const (
+	CurrencyStatusActive    = "active"
+	CurrencyStatusWithdrawn = "withdrawn"
+)
 
 type RatesRepository interface {
 	GetLatestRate(base string) (*ExchangeRateRecord, error)
+	// GetRateOnDate returns the most recent record for base published on or before date.
+	GetRateOnDate(base string, date string) (*ExchangeRateRecord, error)
+	// GetRatesBetween returns the records for base published from start to end inclusive,
+	// oldest first.
+	GetRatesBetween(base string, start string, end string) ([]ExchangeRateRecord, error)
 	GetAllSymbols() (*SymbolsRecord, error)
 }
 
 type RatesService struct {
 	Repository RatesRepository
+	// Now reports the current time, it is used to judge the freshness of served records.
+	Now func() time.Time
 }
 
 func NewRatesService(repo RatesRepository) *RatesService {
-	return &RatesService{Repository: repo}
+	return &RatesService{Repository: repo, Now: time.Now}
 }
 
 func (s *RatesService) GetLatestRate(base string, symbols string) (*ExchangeRateRecord, error) {
@@ -58,22 +79,85 @@ func (s *RatesService) GetLatestRate(base string, symbols string) (*ExchangeRate
 		return nil, nil
 	}
 
+	freshness := ComputeFreshness(record.Date, s.Now())
+	return filterRates(record, MakeSymbolsArray(symbols, normalizedBase), freshness), nil
+}
+
+// GetRateOnDate returns the rates that were in effect on date (YYYY-MM-DD), which are the
+// rates of the most recent publication on or before it. An empty date returns the latest rates.
+func (s *RatesService) GetRateOnDate(base string, symbols string, date string) (*ExchangeRateRecord, error) {
+	if date == "" {
+		return s.GetLatestRate(base, symbols)
+	}
+
+	day, err := parseDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedBase := NormalizeBase(base)
+
+	record, err := s.Repository.GetRateOnDate(normalizedBase, day.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return nil, nil
+	}
+
+	// Judge the record against the calendar as it stood at the end of the requested day.
+	now := s.Now()
+	if endOfDay := day.AddDate(0, 0, 1); endOfDay.Before(now) {
+		now = endOfDay
+	}
+	freshness := ComputeFreshness(record.Date, now)
+	return filterRates(record, MakeSymbolsArray(symbols, normalizedBase), freshness), nil
+}
+
+// GetTimeSeries returns the rates published from start to end (YYYY-MM-DD) inclusive, oldest
+// first.
+func (s *RatesService) GetTimeSeries(base string, symbols string, start string, end string) ([]ExchangeRateRecord, error) {
+	startDay, endDay, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := limitDateRange(startDay, endDay); err != nil {
+		return nil, err
+	}
+
+	normalizedBase := NormalizeBase(base)
+
+	records, err := s.Repository.GetRatesBetween(normalizedBase, startDay.Format(time.DateOnly), endDay.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
 	symbolsArray := MakeSymbolsArray(symbols, normalizedBase)
+	series := make([]ExchangeRateRecord, 0, len(records))
+	for _, record := range records {
+		series = append(series, *filterRates(&record, symbolsArray, nil))
+	}
+	return series, nil
+}
+
+func filterRates(record *ExchangeRateRecord, symbolsArray []string, freshness *Freshness) *ExchangeRateRecord {
 	if len(symbolsArray) > 0 {
 		filteredRecord := &ExchangeRateRecord{
-			Base:  record.Base,
-			Date:  record.Date,
-			Rates: make(map[string]float64),
+			Base:      record.Base,
+			Date:      record.Date,
+			Rates:     make(map[string]float64),
+			Freshness: freshness,
 		}
 		for _, symbol := range symbolsArray {
 			if rate, ok := record.Rates[symbol]; ok {
 				filteredRecord.Rates[symbol] = rate
 			}
 		}
-		return filteredRecord, nil
+		return filteredRecord
 	}
 
-	return record, nil
+	return &ExchangeRateRecord{Base: record.Base, Date: record.Date, Rates: record.Rates, Freshness: freshness}
 }
 
 func (s *RatesService) GetAllSymbols() (*SymbolsRecord, error) {
@@ -81,6 +165,12 @@ func (s *RatesService) GetAllSymbols() (*SymbolsRecord, error) {
 }
 
 func (s *RatesService) GetAllNamedSymbols() (*CurrenciesRecord, error) {
+	return s.GetLocalizedNamedSymbols(locales.Default())
+}
+
+// GetLocalizedNamedSymbols returns the available currencies with names and sign placement
+// in the given locale.
+func (s *RatesService) GetLocalizedNamedSymbols(locale *locales.Locale) (*CurrenciesRecord, error) {
 	record, err := s.Repository.GetAllSymbols()
 	if err != nil {
 		return nil, err
@@ -91,17 +181,60 @@ func (s *RatesService) GetAllNamedSymbols() (*CurrenciesRecord, error) {
 
 	named := make([]NamedSymbol, 0, len(record.Symbols))
 	for _, symbol := range record.Symbols {
-		if info, ok := CurrencyNames[symbol]; ok {
-			named = append(named, NamedSymbol{Symbol: symbol, Name: info.Name, Sign: info.Sign})
+		if info, ok := Registry.Lookup(symbol); ok {
+			named = append(named, NamedSymbol{
+				Symbol:        symbol,
+				Name:          locale.CurrencyName(symbol, info.Name),
+				Sign:          info.Sign,
+				SignPlacement: locale.SignPlacement,
+			})
 		}
 	}
 
-	return &CurrenciesRecord{Date: record.Date, Data: named}, nil
+	return &CurrenciesRecord{Date: record.Date, Locale: locale.Tag, Data: named}, nil
+}
+
+// LookupCurrency returns the ISO 4217 metadata of a currency with its name in the given
+// locale, or nil when the code is unknown.
+func LookupCurrency(code string, locale *locales.Locale) *CurrencyRecord {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	info, ok := Registry.Lookup(normalized)
+	if !ok {
+		return nil
+	}
+
+	status := CurrencyStatusActive
+	if !info.Active() {
+		status = CurrencyStatusWithdrawn
+	}
+
+	return &CurrencyRecord{
+		Code:          normalized,
+		Locale:        locale.Tag,
+		Name:          locale.CurrencyName(normalized, info.Name),
+		Sign:          info.Sign,
+		SignPlacement: locale.SignPlacement,
+		NumericCode:   info.NumericCode,
+		MinorUnits:    info.MinorUnits,
+		Countries:     info.Countries,
+		Status:        status,
+		Withdrawn:     info.Withdrawn,
+		ReplacedBy:    info.ReplacedBy,
+	}
+}
+
+// parseDate parses a YYYY-MM-DD date in the publication time zone.
+func parseDate(raw string) (time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(raw), publicationLocation)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, raw)
+	}
+	return day, nil
 }
 
 func NormalizeBase(base string) string {
 	normalized := strings.ToUpper(strings.TrimSpace(base))
-	if !utils.ArrayContains(Currencies, normalized) {
+	if !Registry.Contains(normalized) {
 		return "EUR"
 	}
 	return normalized
@@ -116,62 +249,9 @@ func MakeSymbolsArray(raw string, base string) []string {
 	var symbolsArray []string
 	for item := range strings.SplitSeq(symbols, ",") {
 		trimmed := strings.TrimSpace(item)
-		if trimmed != base && utils.ArrayContains(Currencies, trimmed) {
+		if trimmed != base && Registry.Contains(trimmed) {
 			symbolsArray = append(symbolsArray, trimmed)
 		}
 	}
 	return symbolsArray
 }
-
-var CurrencyNames = map[string]CurrencyInfo{
-	"EUR": {Name: "Euro", Sign: "€"},
-	"USD": {Name: "US Dollar", Sign: "$"},
-	"JPY": {Name: "Japanese Yen", Sign: "¥"},
-	"BGN": {Name: "Bulgarian Lev", Sign: "лв"},
-	"CYP": {Name: "Cypriot Pound", Sign: "£"},
-	"CZK": {Name: "Czech Koruna", Sign: "Kč"},
-	"DKK": {Name: "Danish Krone", Sign: "kr"},
-	"EEK": {Name: "Estonian Kroon", Sign: "kr"},
-	"GBP": {Name: "British Pound Sterling", Sign: "£"},
-	"HUF": {Name: "Hungarian Forint", Sign: "Ft"},
-	"LTL": {Name: "Lithuanian Litas", Sign: "Lt"},
-	"LVL": {Name: "Latvian Lats", Sign: "Ls"},
-	"MTL": {Name: "Maltese Lira", Sign: "₤"},
-	"PLN": {Name: "Polish Zloty", Sign: "zł"},
-	"ROL": {Name: "Romanian Leu (old)", Sign: "lei"},
-	"RON": {Name: "Romanian Leu", Sign: "lei"},
-	"SEK": {Name: "Swedish Krona", Sign: "kr"},
-	"SIT": {Name: "Slovenian Tolar", Sign: "SIT"},
-	"SKK": {Name: "Slovak Koruna", Sign: "Sk"},
-	"CHF": {Name: "Swiss Franc", Sign: "Fr"},
-	"ISK": {Name: "Icelandic Krona", Sign: "kr"},
-	"ILS": {Name: "Israeli New Shekel", Sign: "₪"},
-	"NOK": {Name: "Norwegian Krone", Sign: "kr"},
-	"HRK": {Name: "Croatian Kuna", Sign: "kn"},
-	"RUB": {Name: "Russian Ruble", Sign: "₽"},
-	"TRL": {Name: "Turkish Lira (old)", Sign: "₤"},
-	"TRY": {Name: "Turkish Lira", Sign: "₺"},
-	"AUD": {Name: "Australian Dollar", Sign: "$"},
-	"BRL": {Name: "Brazilian Real", Sign: "R$"},
-	"CAD": {Name: "Canadian Dollar", Sign: "$"},
-	"CNY": {Name: "Chinese Yuan", Sign: "¥"},
-	"HKD": {Name: "Hong Kong Dollar", Sign: "$"},
-	"IDR": {Name: "Indonesian Rupiah", Sign: "Rp"},
-	"INR": {Name: "Indian Rupee", Sign: "₹"},
-	"KRW": {Name: "South Korean Won", Sign: "₩"},
-	"MXN": {Name: "Mexican Peso", Sign: "$"},
-	"MYR": {Name: "Malaysian Ringgit", Sign: "RM"},
-	"NZD": {Name: "New Zealand Dollar", Sign: "$"},
-	"PHP": {Name: "Philippine Peso", Sign: "₱"},
-	"SGD": {Name: "Singapore Dollar", Sign: "$"},
-	"THB": {Name: "Thai Baht", Sign: "฿"},
-	"ZAR": {Name: "South African Rand", Sign: "R"},
-}
-
-var Currencies = func() []string {
-	keys := make([]string, 0, len(CurrencyNames))
-	for k := range CurrencyNames {
-		keys = append(keys, k)
-	}
-	return keys
-}()
@@ -3,11 +3,14 @@ package handlers
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 type MockRatesRepository struct {
-	GetLatestRateFunc func(base string) (*ExchangeRateRecord, error)
-	GetAllSymbolsFunc func() (*SymbolsRecord, error)
+	GetLatestRateFunc   func(base string) (*ExchangeRateRecord, error)
+	GetRateOnDateFunc   func(base string, date string) (*ExchangeRateRecord, error)
+	GetRatesBetweenFunc func(base string, start string, end string) ([]ExchangeRateRecord, error)
+	GetAllSymbolsFunc   func() (*SymbolsRecord, error)
 }
 
 func (m *MockRatesRepository) GetLatestRate(base string) (*ExchangeRateRecord, error) {
@@ -17,6 +20,20 @@ func (m *MockRatesRepository) GetLatestRate(base string) (*ExchangeRateRecord, e
 	return nil, nil
 }
 
+func (m *MockRatesRepository) GetRateOnDate(base string, date string) (*ExchangeRateRecord, error) {
+	if m.GetRateOnDateFunc != nil {
+		return m.GetRateOnDateFunc(base, date)
+	}
+	return nil, nil
+}
+
+func (m *MockRatesRepository) GetRatesBetween(base string, start string, end string) ([]ExchangeRateRecord, error) {
+	if m.GetRatesBetweenFunc != nil {
+		return m.GetRatesBetweenFunc(base, start, end)
+	}
+	return nil, nil
+}
+
 func (m *MockRatesRepository) GetAllSymbols() (*SymbolsRecord, error) {
 	if m.GetAllSymbolsFunc != nil {
 		return m.GetAllSymbolsFunc()
@@ -212,15 +229,15 @@ func TestRatesService_GetLatestRate(t *testing.T) {
 		wantRates      map[string]float64
 	}{
 		{
-			name:        "successful fetch with no symbol filter returns all rates",
-			base:        "EUR",
-			symbols:     "",
-			mockRecord:  sampleRecord,
-			mockErr:     nil,
-			wantErr:     false,
-			wantNil:     false,
-			wantBase:    "EUR",
-			wantRates:   map[string]float64{"USD": 1.08, "GBP": 0.86, "JPY": 161.5, "CHF": 0.94},
+			name:       "successful fetch with no symbol filter returns all rates",
+			base:       "EUR",
+			symbols:    "",
+			mockRecord: sampleRecord,
+			mockErr:    nil,
+			wantErr:    false,
+			wantNil:    false,
+			wantBase:   "EUR",
+			wantRates:  map[string]float64{"USD": 1.08, "GBP": 0.86, "JPY": 161.5, "CHF": 0.94},
 		},
 		{
 			name:       "successful fetch with single symbol filter",
@@ -294,15 +311,15 @@ func TestRatesService_GetLatestRate(t *testing.T) {
 			wantBase:   "EUR",
 		},
 		{
-			name:        "wildcard symbol returns all rates",
-			base:        "EUR",
-			symbols:     "*",
-			mockRecord:  sampleRecord,
-			mockErr:     nil,
-			wantErr:     false,
-			wantNil:     false,
-			wantBase:    "EUR",
-			wantRates:   map[string]float64{"USD": 1.08, "GBP": 0.86, "JPY": 161.5, "CHF": 0.94},
+			name:       "wildcard symbol returns all rates",
+			base:       "EUR",
+			symbols:    "*",
+			mockRecord: sampleRecord,
+			mockErr:    nil,
+			wantErr:    false,
+			wantNil:    false,
+			wantBase:   "EUR",
+			wantRates:  map[string]float64{"USD": 1.08, "GBP": 0.86, "JPY": 161.5, "CHF": 0.94},
 		},
 	}
 
@@ -363,7 +380,7 @@ func TestCurrenciesContainsExpected(t *testing.T) {
 
 	for _, currency := range expectedCurrencies {
 		found := false
-		for _, c := range Currencies {
+		for _, c := range Registry.Codes() {
 			if c == currency {
 				found = true
 				break
@@ -377,10 +394,84 @@ func TestCurrenciesContainsExpected(t *testing.T) {
 
 func TestCurrenciesNoDuplicates(t *testing.T) {
 	seen := make(map[string]bool)
-	for _, currency := range Currencies {
+	for _, currency := range Registry.Codes() {
 		if seen[currency] {
 			t.Errorf("Currencies list contains duplicate: %s", currency)
 		}
 		seen[currency] = true
 	}
 }
+
+func TestRatesService_GetLatestRate_Freshness(t *testing.T) {
+	mockRepo := &MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			return &ExchangeRateRecord{Base: "EUR", Date: "2025-12-04", Rates: map[string]float64{"USD": 1.08}}, nil
+		},
+	}
+
+	service := NewRatesService(mockRepo)
+	service.Now = func() time.Time {
+		return time.Date(2025, time.December, 5, 17, 0, 0, 0, time.UTC)
+	}
+
+	for _, symbols := range []string{"", "USD"} {
+		got, err := service.GetLatestRate("EUR", symbols)
+		if err != nil {
+			t.Fatalf("GetLatestRate() error = %v", err)
+		}
+
+		if got.Freshness == nil {
+			t.Fatalf("GetLatestRate(%q) freshness = nil", symbols)
+		}
+		if got.Freshness.ExpectedDate != "2025-12-05" {
+			t.Errorf("GetLatestRate(%q) expected date = %q, want %q", symbols, got.Freshness.ExpectedDate, "2025-12-05")
+		}
+		if got.Freshness.MissedPublications != 1 {
+			t.Errorf("GetLatestRate(%q) missed publications = %d, want 1", symbols, got.Freshness.MissedPublications)
+		}
+	}
+}
+
+func TestRatesService_GetRateOnDate(t *testing.T) {
+	var gotDate string
+	mockRepo := &MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			return &ExchangeRateRecord{Base: base, Date: "2025-12-05", Rates: map[string]float64{"USD": 1.09}}, nil
+		},
+		GetRateOnDateFunc: func(base string, date string) (*ExchangeRateRecord, error) {
+			gotDate = date
+			return &ExchangeRateRecord{Base: base, Date: "2025-11-28", Rates: map[string]float64{"USD": 1.05, "GBP": 0.87}}, nil
+		},
+	}
+
+	service := NewRatesService(mockRepo)
+	service.Now = func() time.Time {
+		return time.Date(2025, time.December, 5, 17, 0, 0, 0, time.UTC)
+	}
+
+	got, err := service.GetRateOnDate("EUR", "USD", "2025-11-29")
+	if err != nil {
+		t.Fatalf("GetRateOnDate() error = %v", err)
+	}
+	if gotDate != "2025-11-29" {
+		t.Errorf("GetRateOnDate() repository date = %q, want %q", gotDate, "2025-11-29")
+	}
+	if got.Date != "2025-11-28" || len(got.Rates) != 1 || got.Rates["USD"] != 1.05 {
+		t.Errorf("GetRateOnDate() = %+v, want USD rate of 2025-11-28", got)
+	}
+	if got.Freshness == nil || got.Freshness.Stale {
+		t.Errorf("GetRateOnDate() freshness = %+v, want not stale on a weekend", got.Freshness)
+	}
+
+	latest, err := service.GetRateOnDate("EUR", "", "")
+	if err != nil {
+		t.Fatalf("GetRateOnDate() without date error = %v", err)
+	}
+	if latest.Date != "2025-12-05" {
+		t.Errorf("GetRateOnDate() without date = %q, want latest %q", latest.Date, "2025-12-05")
+	}
+
+	if _, err := service.GetRateOnDate("EUR", "", "05-12-2025"); !errors.Is(err, ErrInvalidDate) {
+		t.Errorf("GetRateOnDate() invalid date error = %v, want %v", err, ErrInvalidDate)
+	}
+}
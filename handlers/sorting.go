@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+const (
+	SortByCode = "code"
+	SortByName = "name"
+)
+
+var ErrInvalidSort = errors.New("sort must be one of: code, name")
+
+// parseSort validates the sort query parameter. An empty value keeps the stored order.
+func parseSort(raw string) (string, error) {
+	sortBy := strings.ToLower(strings.TrimSpace(raw))
+	switch sortBy {
+	case "", SortByCode, SortByName:
+		return sortBy, nil
+	}
+	return "", ErrInvalidSort
+}
+
+// SortSymbols orders currency codes in place, by code or by their registry name, which is
+// collated as English. Codes sharing a name are ordered by code so the result is always the
+// same.
+func SortSymbols(symbols []string, sortBy string) {
+	switch sortBy {
+	case SortByCode:
+		slices.Sort(symbols)
+	case SortByName:
+		collator := collate.New(language.English)
+		slices.SortFunc(symbols, func(a, b string) int {
+			infoA, _ := Registry.Lookup(a)
+			infoB, _ := Registry.Lookup(b)
+			return cmp.Or(collator.CompareString(infoA.Name, infoB.Name), strings.Compare(a, b))
+		})
+	}
+}
+
+// SortNamedSymbols orders named currencies in place, by code or by their name collated with
+// the rules of locale, a BCP 47 tag. Currencies sharing a name are ordered by code.
+func SortNamedSymbols(named []NamedSymbol, sortBy string, locale string) {
+	switch sortBy {
+	case SortByCode:
+		slices.SortFunc(named, func(a, b NamedSymbol) int {
+			return strings.Compare(a.Symbol, b.Symbol)
+		})
+	case SortByName:
+		collator := collate.New(language.Make(locale))
+		slices.SortFunc(named, func(a, b NamedSymbol) int {
+			return cmp.Or(collator.CompareString(a.Name, b.Name), strings.Compare(a.Symbol, b.Symbol))
+		})
+	}
+}
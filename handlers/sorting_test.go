@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "", want: ""},
+		{raw: "code", want: SortByCode},
+		{raw: " Name ", want: SortByName},
+		{raw: "rate", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseSort(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSort(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSort(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortSymbols(t *testing.T) {
+	tests := []struct {
+		name   string
+		sortBy string
+		want   []string
+	}{
+		{name: "keeps stored order", sortBy: "", want: []string{"USD", "EUR", "GBP", "CHF"}},
+		{name: "by code", sortBy: SortByCode, want: []string{"CHF", "EUR", "GBP", "USD"}},
+		{name: "by name", sortBy: SortByName, want: []string{"GBP", "EUR", "CHF", "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbols := []string{"USD", "EUR", "GBP", "CHF"}
+			SortSymbols(symbols, tt.sortBy)
+			if !slices.Equal(symbols, tt.want) {
+				t.Errorf("SortSymbols(%q) = %v, want %v", tt.sortBy, symbols, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortNamedSymbols(t *testing.T) {
+	named := []NamedSymbol{
+		{Symbol: "USD", Name: "US-Dollar"},
+		{Symbol: "CHF", Name: "Schweizer Franken"},
+		{Symbol: "EUR", Name: "Euro"},
+	}
+
+	SortNamedSymbols(named, SortByName, "de")
+
+	var got []string
+	for _, symbol := range named {
+		got = append(got, symbol.Symbol)
+	}
+	if want := []string{"EUR", "CHF", "USD"}; !slices.Equal(got, want) {
+		t.Errorf("SortNamedSymbols(name) = %v, want %v", got, want)
+	}
+}
+
+func TestSortNamedSymbolsCollatesByLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		// German sorts Ä with A, Swedish after Z, and a bytewise sort would put it last too.
+		{locale: "de", want: []string{"EGP", "AUD", "ZAR"}},
+		{locale: "sv", want: []string{"AUD", "ZAR", "EGP"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			named := []NamedSymbol{
+				{Symbol: "ZAR", Name: "Südafrikanischer Rand"},
+				{Symbol: "AUD", Name: "Australischer Dollar"},
+				{Symbol: "EGP", Name: "Ägyptisches Pfund"},
+			}
+
+			SortNamedSymbols(named, SortByName, tt.locale)
+
+			var got []string
+			for _, symbol := range named {
+				got = append(got, symbol.Symbol)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortNamedSymbols(name, %q) = %v, want %v", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortNamedSymbolsBreaksTiesByCode(t *testing.T) {
+	named := []NamedSymbol{
+		{Symbol: "USD", Name: "Dollar"},
+		{Symbol: "AUD", Name: "Dollar"},
+	}
+
+	SortNamedSymbols(named, SortByName, "en")
+
+	if named[0].Symbol != "AUD" || named[1].Symbol != "USD" {
+		t.Errorf("SortNamedSymbols(name) = %v, want AUD before USD", named)
+	}
+}
+
+func TestRegistryCodesAreSorted(t *testing.T) {
+	codes := Registry.Codes()
+	if !slices.IsSorted(codes) {
+		t.Errorf("Registry.Codes() is not sorted: %v", codes)
+	}
+}
+
+func TestExchangeRateRecordJSONIsReproducible(t *testing.T) {
+	record := &ExchangeRateRecord{
+		Base:  "EUR",
+		Date:  "2025-12-05",
+		Rates: map[string]float64{"USD": 1.08, "JPY": 161.5, "GBP": 0.86, "CHF": 0.94, "AUD": 1.65},
+	}
+
+	want := `{"base":"EUR","date":"2025-12-05","rates":{"AUD":1.65,"CHF":0.94,"GBP":0.86,"JPY":161.5,"USD":1.08}}`
+	for range 20 {
+		output, err := json.Marshal(record)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		if string(output) != want {
+			t.Fatalf("json.Marshal() = %s, want %s", output, want)
+		}
+	}
+}
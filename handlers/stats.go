@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type (
+	RateStats   = api.RateStats
+	StatsPeriod = api.StatsPeriod
+	StatsRecord = api.StatsRecord
+)
+
+// GetStats aggregates the rates published from start to end, both YYYY-MM-DD, per interval.
+// It returns nil when no rates were published in the range.
+func (s *RatesService) GetStats(base string, symbols string, start string, end string, interval string) (*StatsRecord, error) {
+	startDay, endDay, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := limitDateRange(startDay, endDay); err != nil {
+		return nil, err
+	}
+	interval, err = parseInterval(interval, IntervalMonth, IntervalWeek, IntervalMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedBase := NormalizeBase(base)
+
+	records, err := s.Repository.GetRatesBetween(normalizedBase, startDay.Format(time.DateOnly), endDay.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	periods := groupByPeriod(records, interval, startDay, endDay)
+	if len(periods) == 0 {
+		return nil, nil
+	}
+
+	symbolsArray := MakeSymbolsArray(symbols, normalizedBase)
+	stats := make([]StatsPeriod, 0, len(periods))
+	for _, period := range periods {
+		series := map[string][]float64{}
+		for _, record := range period.Records {
+			for symbol, rate := range filterRates(&record, symbolsArray, nil).Rates {
+				series[symbol] = append(series[symbol], rate)
+			}
+		}
+
+		rates := make(map[string]RateStats, len(series))
+		for symbol, values := range series {
+			rates[symbol] = aggregateRates(values)
+		}
+		stats = append(stats, StatsPeriod{
+			Start: period.Start.Format(time.DateOnly),
+			End:   period.End.Format(time.DateOnly),
+			Rates: rates,
+		})
+	}
+
+	return &StatsRecord{
+		Base:      normalizedBase,
+		StartDate: startDay.Format(time.DateOnly),
+		EndDate:   endDay.Format(time.DateOnly),
+		Interval:  interval,
+		Periods:   stats,
+	}, nil
+}
+
+func aggregateRates(values []float64) RateStats {
+	stats := RateStats{Min: values[0], Max: values[0], Count: len(values)}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+		stats.Min = math.Min(stats.Min, value)
+		stats.Max = math.Max(stats.Max, value)
+	}
+	stats.Average = sum / float64(len(values))
+
+	var squares float64
+	for _, value := range values {
+		squares += (value - stats.Average) * (value - stats.Average)
+	}
+	stats.StdDev = math.Sqrt(squares / float64(len(values)))
+
+	return stats
+}
+
+// GetStats handles requests for aggregated rates over a period.
+//
+// @Summary      Get rate statistics over a period
+// @Description  Computes the average, minimum, maximum and population standard deviation of the daily rates of every currency, per week or per month of the requested date range.
+// @Description  Weeks run from Monday to Sunday. The first and last periods are clipped to the requested range, and periods without publications are left out.
+// @Tags         rates
+// @Produce      json
+// @Param        start     query     string  true   "Start date (YYYY-MM-DD)"  Format(date)
+// @Param        end       query     string  true   "End date (YYYY-MM-DD); the range spans at most 366 days"  Format(date)
+// @Param        base      query     string  false  "Base currency code; unsupported codes fall back to the default (default: EUR)"  minlength(3)  maxlength(3)
+// @Param        symbols   query     string  false  "Comma-separated list of target currency symbols"
+// @Param        interval  query     string  false  "Aggregation interval (default: month)"  Enums(week, month)
+// @Success      200       {object}  api.StatsRecord
+// @Failure      400       {object}  api.Error
+// @Failure      404       {object}  api.Error
+// @Failure      500       {object}  api.Error
+// @Router       /v1/rates/stats [get]
+func GetStats(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	if query.Get("start") == "" || query.Get("end") == "" {
+		utils.ErrorHandler(writer, "start and end are required", http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	record, err := service.GetStats(query.Get("base"), query.Get("symbols"), query.Get("start"), query.Get("end"), query.Get("interval"))
+	if errors.Is(err, ErrInvalidDate) || errors.Is(err, ErrInvalidDateRange) || errors.Is(err, ErrInvalidInterval) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if record == nil {
+		utils.ErrorHandler(writer, "Rates not found", http.StatusNotFound)
+		return
+	}
+
+	output, err := json.Marshal(record)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	writer.Write(output)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newRangeRepository(records []ExchangeRateRecord) *MockRatesRepository {
+	return &MockRatesRepository{
+		GetRatesBetweenFunc: func(base string, start string, end string) ([]ExchangeRateRecord, error) {
+			var inRange []ExchangeRateRecord
+			for _, record := range records {
+				if record.Date >= start && record.Date <= end {
+					record.Base = base
+					inRange = append(inRange, record)
+				}
+			}
+			return inRange, nil
+		},
+	}
+}
+
+var januaryRates = []ExchangeRateRecord{
+	{Date: "2025-01-27", Rates: map[string]float64{"USD": 1.04, "GBP": 0.84}},
+	{Date: "2025-01-28", Rates: map[string]float64{"USD": 1.05, "GBP": 0.84}},
+	{Date: "2025-01-29", Rates: map[string]float64{"USD": 1.03, "GBP": 0.84}},
+	{Date: "2025-01-30", Rates: map[string]float64{"USD": 1.08, "GBP": 0.84}},
+	{Date: "2025-02-03", Rates: map[string]float64{"USD": 1.02, "GBP": 0.83}},
+}
+
+func TestAggregateRates(t *testing.T) {
+	got := aggregateRates([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+
+	want := RateStats{Average: 5, Min: 2, Max: 9, StdDev: 2, Count: 8}
+	if got != want {
+		t.Errorf("aggregateRates() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRatesService_GetStats(t *testing.T) {
+	service := NewRatesService(newRangeRepository(januaryRates))
+
+	got, err := service.GetStats("EUR", "USD", "2025-01-01", "2025-02-28", "")
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+
+	if got.Interval != IntervalMonth {
+		t.Errorf("GetStats() interval = %q, want %q", got.Interval, IntervalMonth)
+	}
+	if len(got.Periods) != 2 {
+		t.Fatalf("GetStats() returned %d periods, want 2", len(got.Periods))
+	}
+
+	january := got.Periods[0]
+	if january.Start != "2025-01-01" || january.End != "2025-01-31" {
+		t.Errorf("GetStats() first period = %s..%s, want 2025-01-01..2025-01-31", january.Start, january.End)
+	}
+	if _, ok := january.Rates["GBP"]; ok {
+		t.Errorf("GetStats() rates = %v, want only USD", january.Rates)
+	}
+
+	usd := january.Rates["USD"]
+	if usd.Count != 4 || usd.Min != 1.03 || usd.Max != 1.08 || math.Abs(usd.Average-1.05) > 1e-9 {
+		t.Errorf("GetStats() January USD = %+v, want 4 rates from 1.03 to 1.08 averaging 1.05", usd)
+	}
+	if math.Abs(usd.StdDev-math.Sqrt(0.00035)) > 1e-9 {
+		t.Errorf("GetStats() January USD std dev = %v, want %v", usd.StdDev, math.Sqrt(0.00035))
+	}
+}
+
+func TestRatesService_GetStats_Weekly(t *testing.T) {
+	got, err := NewRatesService(newRangeRepository(januaryRates)).GetStats("EUR", "", "2025-01-01", "2025-02-28", IntervalWeek)
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+
+	if len(got.Periods) != 2 {
+		t.Fatalf("GetStats() returned %d periods, want 2", len(got.Periods))
+	}
+	if got.Periods[1].Start != "2025-02-03" || got.Periods[1].End != "2025-02-09" {
+		t.Errorf("GetStats() second week = %s..%s, want 2025-02-03..2025-02-09", got.Periods[1].Start, got.Periods[1].End)
+	}
+	if gbp := got.Periods[0].Rates["GBP"]; gbp.StdDev != 0 || gbp.Count != 4 {
+		t.Errorf("GetStats() first week GBP = %+v, want 4 constant rates", gbp)
+	}
+}
+
+func TestRatesService_GetStats_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		interval string
+		wantErr  error
+	}{
+		{name: "invalid date", start: "2025-01", end: "2025-02-28", wantErr: ErrInvalidDate},
+		{name: "start after end", start: "2025-03-01", end: "2025-02-28", wantErr: ErrInvalidDateRange},
+		{name: "range too long", start: "2024-01-01", end: "2025-01-01", wantErr: ErrInvalidDateRange},
+		{name: "unsupported interval", start: "2025-01-01", end: "2025-02-28", interval: "day", wantErr: ErrInvalidInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRatesService(newRangeRepository(januaryRates)).GetStats("EUR", "", tt.start, tt.end, tt.interval)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetStats() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetStatsHandler(t *testing.T) {
+	useMockRepository(t, newRangeRepository(januaryRates))
+
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantPeriods    int
+	}{
+		{name: "monthly stats", query: "?start=2025-01-01&end=2025-02-28", wantStatusCode: http.StatusOK, wantPeriods: 2},
+		{name: "weekly stats", query: "?start=2025-01-01&end=2025-01-31&interval=week", wantStatusCode: http.StatusOK, wantPeriods: 1},
+		{name: "missing dates", query: "?interval=week", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid interval", query: "?start=2025-01-01&end=2025-02-28&interval=quarter", wantStatusCode: http.StatusBadRequest},
+		{name: "no rates in range", query: "?start=2024-01-01&end=2024-12-31", wantStatusCode: http.StatusNotFound},
+		{name: "range too long", query: "?start=2023-01-01&end=2024-12-31", wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, StatsPath+tt.query, nil)
+			recorder := httptest.NewRecorder()
+
+			GetStats(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetStats() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var record StatsRecord
+			if err := json.Unmarshal(recorder.Body.Bytes(), &record); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(record.Periods) != tt.wantPeriods {
+				t.Errorf("GetStats() periods = %d, want %d", len(record.Periods), tt.wantPeriods)
+			}
+		})
+	}
+}
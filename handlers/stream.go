@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kamaal111/forex-api/utils"
+)
+
+// StreamHeartbeatInterval is the time between two heartbeat comments on an idle stream, which
+// keeps proxies from closing the connection.
+var StreamHeartbeatInterval = 15 * time.Second
+
+// streamRetry is the reconnection delay, in milliseconds, suggested to clients.
+const streamRetry = 5000
+
+// rateStream writes rate records as server-sent events. The id of every event lists the date
+// of the last record sent per base, e.g. EUR:2025-01-03,USD:2025-01-02, so a client
+// reconnecting with Last-Event-ID only receives rates newer than those of the same base.
+type rateStream struct {
+	writer     http.ResponseWriter
+	controller *http.ResponseController
+	symbols    []string
+	// sent holds the date of the last record sent per base.
+	sent map[string]string
+}
+
+func (s *rateStream) send(record *ExchangeRateRecord) error {
+	if record.Date <= s.sent[record.Base] {
+		return nil
+	}
+
+	data, err := json.Marshal(filterRates(record, s.symbols, record.Freshness))
+	if err != nil {
+		return err
+	}
+	s.sent[record.Base] = record.Date
+	if _, err := fmt.Fprintf(s.writer, "id: %s\nevent: rates\ndata: %s\n\n", s.eventID(), data); err != nil {
+		return err
+	}
+	return s.controller.Flush()
+}
+
+// eventID returns the dates of the last records sent, per base in alphabetical order.
+func (s *rateStream) eventID() string {
+	var positions []string
+	for _, base := range slices.Sorted(maps.Keys(s.sent)) {
+		if date := s.sent[base]; date != "" {
+			positions = append(positions, base+":"+date)
+		}
+	}
+	return strings.Join(positions, ",")
+}
+
+// resume sets the dates already received per base from a Last-Event-ID header. Bases missing
+// from the header get their latest rates again.
+func (s *rateStream) resume(lastEventID string, bases []string) {
+	for position := range strings.SplitSeq(lastEventID, ",") {
+		base, date, found := strings.Cut(strings.TrimSpace(position), ":")
+		if found && slices.Contains(bases, base) {
+			s.sent[base] = date
+		}
+	}
+}
+
+func (s *rateStream) heartbeat() error {
+	if _, err := fmt.Fprint(s.writer, ": heartbeat\n\n"); err != nil {
+		return err
+	}
+	return s.controller.Flush()
+}
+
+// GetStream handles requests to stream rate updates.
+//
+// @Summary      Stream rate updates
+// @Description  Opens a Server-Sent Events stream that pushes the latest exchange rates of the requested bases as an event named rates, first on connection and then whenever rates with a newer date are published.
+// @Description  Each event id lists the date of the latest rates sent per base, e.g. EUR:2025-01-03,USD:2025-01-02. Clients reconnecting with a Last-Event-ID header only receive rates newer than the date of their base, and the latest rates of bases it doesn't list. Heartbeat comments are sent while the stream is idle.
+// @Tags         rates
+// @Produce      text/event-stream
+// @Param        base           query     string  false  "Comma-separated list of base currency codes (default: EUR)"
+// @Param        symbols        query     string  false  "Comma-separated list of target currency symbols"
+// @Param        Last-Event-ID  header    string  false  "Id of the last event received, to resume after a reconnection"
+// @Success      200            {object}  api.ExchangeRateRecord
+// @Failure      500            {object}  api.Error
+// @Router       /v1/rates/stream [get]
+func GetStream(writer http.ResponseWriter, request *http.Request) {
+	var bases []string
+	for base := range strings.SplitSeq(request.URL.Query().Get("base"), ",") {
+		if normalized := NormalizeBase(base); !slices.Contains(bases, normalized) {
+			bases = append(bases, normalized)
+		}
+	}
+
+	subscription := Feed.Subscribe(bases...)
+	defer subscription.Close()
+
+	stream := &rateStream{
+		writer:     writer,
+		controller: http.NewResponseController(writer),
+		sent:       map[string]string{},
+	}
+	stream.resume(request.Header.Get("Last-Event-ID"), bases)
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var current []*ExchangeRateRecord
+	for _, base := range bases {
+		record, err := service.GetLatestRate(base, "")
+		if err != nil {
+			closeService()
+			utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if record != nil {
+			current = append(current, record)
+		}
+	}
+	closeService()
+
+	// The symbols apply to every base, so none of the bases is left out of them.
+	stream.symbols = MakeSymbolsArray(request.URL.Query().Get("symbols"), "")
+
+	writer.Header().Set("content-type", "text/event-stream")
+	writer.Header().Set("cache-control", "no-cache")
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+	fmt.Fprintf(writer, "retry: %d\n\n", streamRetry)
+
+	for _, record := range current {
+		if err := stream.send(record); err != nil {
+			return
+		}
+	}
+	if err := stream.controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := stream.heartbeat(); err != nil {
+				return
+			}
+		case update, ok := <-subscription.Updates():
+			if !ok {
+				return
+			}
+			if err := stream.send(update.Record); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type streamEvent struct {
+	id   string
+	name string
+	data string
+}
+
+// readEvent reads the next event from a stream, skipping comments and retry fields.
+func readEvent(t *testing.T, reader *bufio.Reader) streamEvent {
+	t.Helper()
+
+	var event streamEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if event.data != "" {
+				return event
+			}
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func openStream(t *testing.T, server *httptest.Server, query string, lastEventID string) *bufio.Reader {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+StreamPath+query, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetStream() status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if contentType := resp.Header.Get("content-type"); contentType != "text/event-stream" {
+		t.Fatalf("GetStream() content-type = %q, want %q", contentType, "text/event-stream")
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+func useFeed(t *testing.T, feed *RateFeed) {
+	t.Helper()
+
+	original := Feed
+	Feed = feed
+	t.Cleanup(func() { Feed = original })
+}
+
+func TestGetStream(t *testing.T) {
+	repo := newChangingRepository("2025-12-04")
+	useMockRepository(t, repo)
+	useFeed(t, NewRateFeed(10*time.Millisecond))
+
+	server := httptest.NewServer(http.HandlerFunc(GetStream))
+	t.Cleanup(server.Close)
+
+	reader := openStream(t, server, "?base=EUR&symbols=USD", "")
+
+	first := readEvent(t, reader)
+	if first.id != "EUR:2025-12-04" || first.name != "rates" {
+		t.Errorf("first event = %s %s, want rates EUR:2025-12-04", first.name, first.id)
+	}
+	var record ExchangeRateRecord
+	if err := json.Unmarshal([]byte(first.data), &record); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if len(record.Rates) != 1 || record.Rates["USD"] != 1.08 {
+		t.Errorf("first event rates = %v, want only USD", record.Rates)
+	}
+
+	repo.publish("2025-12-05")
+
+	next := readEvent(t, reader)
+	if next.id != "EUR:2025-12-05" {
+		t.Errorf("next event id = %q, want %q", next.id, "EUR:2025-12-05")
+	}
+}
+
+func TestGetStream_LastEventIDPerBase(t *testing.T) {
+	repo := newChangingRepository("2025-12-04")
+	useMockRepository(t, repo)
+	useFeed(t, NewRateFeed(10*time.Millisecond))
+
+	server := httptest.NewServer(http.HandlerFunc(GetStream))
+	t.Cleanup(server.Close)
+
+	// The client received EUR before the connection dropped, but not USD.
+	reader := openStream(t, server, "?base=EUR,USD,GBP", "EUR:2025-12-04,GBP:2025-12-04")
+
+	event := readEvent(t, reader)
+	if event.id != "EUR:2025-12-04,GBP:2025-12-04,USD:2025-12-04" {
+		t.Errorf("first event after reconnection = %q, want the USD rates", event.id)
+	}
+	var record ExchangeRateRecord
+	if err := json.Unmarshal([]byte(event.data), &record); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if record.Base != "USD" {
+		t.Errorf("first event after reconnection has base %s, want USD", record.Base)
+	}
+
+	repo.publish("2025-12-05")
+
+	for range 2 {
+		readEvent(t, reader)
+	}
+	if event := readEvent(t, reader); event.id != "EUR:2025-12-05,GBP:2025-12-05,USD:2025-12-05" {
+		t.Errorf("event id after the update of every base = %q", event.id)
+	}
+}
+
+func TestGetStream_Heartbeat(t *testing.T) {
+	useMockRepository(t, newChangingRepository("2025-12-04"))
+	useFeed(t, NewRateFeed(time.Hour))
+
+	original := StreamHeartbeatInterval
+	StreamHeartbeatInterval = 10 * time.Millisecond
+	t.Cleanup(func() { StreamHeartbeatInterval = original })
+
+	server := httptest.NewServer(http.HandlerFunc(GetStream))
+	t.Cleanup(server.Close)
+
+	reader := openStream(t, server, "", "EUR:2025-12-04")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if line == ": heartbeat\n" {
+			return
+		}
+		if strings.HasPrefix(line, "data: ") {
+			t.Fatalf("GetStream() sent %q, want only heartbeats", line)
+		}
+	}
+}
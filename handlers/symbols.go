@@ -1,11 +1,9 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 
-	"github.com/kamaal111/forex-api/database"
 	"github.com/kamaal111/forex-api/utils"
 )
 
@@ -15,21 +13,25 @@ import (
 // @Description  Returns a list of all available currency symbols.
 // @Tags         rates
 // @Produce      json
-// @Success      200  {object}  SymbolsRecord
-// @Failure      404  {object}  utils.Error
-// @Failure      500  {object}  utils.Error
+// @Param        sort  query     string  false  "Order of the symbols, by currency code or English name (default: stored order)"  Enums(code, name)
+// @Success      200   {object}  api.SymbolsRecord
+// @Failure      400   {object}  api.Error
+// @Failure      404   {object}  api.Error
+// @Failure      500   {object}  api.Error
 // @Router       /v1/rates/symbols [get]
 func GetSymbols(writer http.ResponseWriter, request *http.Request) {
-	ctx := context.Background()
-	client, err := database.CreateClient(ctx)
+	sortBy, err := parseSort(request.URL.Query().Get("sort"))
 	if err != nil {
-		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
 		return
 	}
-	defer client.Close()
 
-	repo := NewFirestoreRatesRepository(ctx, client)
-	service := NewRatesService(repo)
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
 
 	record, err := service.GetAllSymbols()
 	if err != nil {
@@ -41,6 +43,8 @@ func GetSymbols(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	SortSymbols(record.Symbols, sortBy)
+
 	output, err := json.Marshal(record)
 	if err != nil {
 		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
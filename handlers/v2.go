@@ -0,0 +1,150 @@
+package handlers
+
+import "net/http"
+
+// GetLatestV2 handles requests for the latest exchange rates in a v2 envelope.
+//
+// @Summary      Get latest exchange rates
+// @Description  Get the latest currency exchange rates, optionally filtered by base currency and target symbols.
+// @Description  The freshness of the rates is reported in the metadata and in the X-Data-Staleness header.
+// @Tags         v2
+// @Produce      json
+// @Param        base     query     string  false  "Base currency code; unsupported codes fall back to the default (default: EUR)"  minlength(3)  maxlength(3)
+// @Param        symbols  query     string  false  "Comma-separated list of target currency symbols"
+// @Success      200      {object}  Envelope{data=api.ExchangeRateRecord}
+// @Header       200      {integer}  X-Data-Staleness      "Seconds the rates have been overdue, 0 when up to date"
+// @Header       200      {string}   X-Data-Expected-Date  "Date of the latest publication that should be available"
+// @Failure      404      {object}  Envelope
+// @Failure      500      {object}  Envelope
+// @Router       /v2/rates/latest [get]
+func GetLatestV2(writer http.ResponseWriter, request *http.Request) {
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	query := request.URL.Query()
+	record, err := service.GetLatestRate(query.Get("base"), query.Get("symbols"))
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if record == nil {
+		EnvelopeErrorHandler(writer, "Rates not found", http.StatusNotFound)
+		return
+	}
+
+	freshness := record.Freshness
+	record.Freshness = nil
+
+	setFreshnessHeaders(writer, freshness)
+	writeEnvelope(writer, record, Meta{Date: record.Date, Freshness: freshness})
+}
+
+// GetSymbolsV2 handles requests for a page of the available currency symbols in a v2 envelope.
+//
+// @Summary      Get available currency symbols
+// @Description  Returns a page of the available currency symbols.
+// @Tags         v2
+// @Produce      json
+// @Param        sort      query     string   false  "Order of the symbols, by currency code or English name (default: stored order)"  Enums(code, name)
+// @Param        page      query     integer  false  "Page to return, starting at 1 (default: 1)"  minimum(1)
+// @Param        per_page  query     integer  false  "Number of symbols per page (default: 50)"  minimum(1)  maximum(200)
+// @Success      200       {object}  Envelope{data=[]string}
+// @Failure      400       {object}  Envelope
+// @Failure      404       {object}  Envelope
+// @Failure      500       {object}  Envelope
+// @Router       /v2/rates/symbols [get]
+func GetSymbolsV2(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	sortBy, err := parseSort(query.Get("sort"))
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, perPage, err := parsePagination(query)
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	record, err := service.GetAllSymbols()
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if record == nil {
+		EnvelopeErrorHandler(writer, "symbols not found", http.StatusNotFound)
+		return
+	}
+
+	SortSymbols(record.Symbols, sortBy)
+	symbols, pagination := paginate(record.Symbols, page, perPage)
+
+	writeEnvelope(writer, symbols, Meta{Date: record.Date, Pagination: pagination})
+}
+
+// GetCurrenciesV2 handles requests for a page of the currencies with names and signs in a v2
+// envelope.
+//
+// @Summary      Get currencies with names and signs
+// @Description  Returns a page of the available currencies with their human-readable names and currency signs.
+// @Description  Names and sign placement are localized, falling back from the region to the language and finally to English.
+// @Tags         v2
+// @Produce      json
+// @Param        sort             query     string   false  "Order of the currencies, by code or localized name (default: stored order)"  Enums(code, name)
+// @Param        locale           query     string   false  "Locale of the currency names, e.g. de or nl-BE (overrides Accept-Language)"
+// @Param        page             query     integer  false  "Page to return, starting at 1 (default: 1)"  minimum(1)
+// @Param        per_page         query     integer  false  "Number of currencies per page (default: 50)"  minimum(1)  maximum(200)
+// @Param        Accept-Language  header    string   false  "Preferred locales of the currency names"
+// @Success      200              {object}  Envelope{data=[]api.NamedSymbol}
+// @Failure      400              {object}  Envelope
+// @Failure      404              {object}  Envelope
+// @Failure      500              {object}  Envelope
+// @Router       /v2/currencies [get]
+func GetCurrenciesV2(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	sortBy, err := parseSort(query.Get("sort"))
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, perPage, err := parsePagination(query)
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	service, closeService, err := openRatesService(request.Context())
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer closeService()
+
+	locale := negotiateLocale(writer, request)
+
+	record, err := service.GetLocalizedNamedSymbols(locale)
+	if err != nil {
+		EnvelopeErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if record == nil {
+		EnvelopeErrorHandler(writer, "symbols not found", http.StatusNotFound)
+		return
+	}
+
+	SortNamedSymbols(record.Data, sortBy, record.Locale)
+	currencies, pagination := paginate(record.Data, page, perPage)
+
+	writeEnvelope(writer, currencies, Meta{Date: record.Date, Locale: record.Locale, Pagination: pagination})
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/kamaal111/forex-api/utils"
+)
+
+// decodedEnvelope keeps the data raw so every test can decode it into its own type.
+type decodedEnvelope struct {
+	Data   json.RawMessage `json:"data"`
+	Meta   Meta            `json:"meta"`
+	Errors []utils.Error   `json:"errors"`
+}
+
+func serveV2(t *testing.T, handler http.HandlerFunc, target string) (*httptest.ResponseRecorder, decodedEnvelope) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var envelope decodedEnvelope
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to decode envelope %q: %v", recorder.Body.String(), err)
+	}
+	return recorder, envelope
+}
+
+func TestGetLatestV2Handler(t *testing.T) {
+	useMockRepository(t, &MockRatesRepository{
+		GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+			return &ExchangeRateRecord{Base: base, Date: "2025-01-03", Rates: map[string]float64{"USD": 1.03, "GBP": 0.83}}, nil
+		},
+	})
+
+	recorder, envelope := serveV2(t, GetLatestV2, V2LatestPath+"?symbols=USD")
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GetLatestV2() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	var record ExchangeRateRecord
+	if err := json.Unmarshal(envelope.Data, &record); err != nil {
+		t.Fatalf("failed to decode data: %v", err)
+	}
+	if record.Base != "EUR" || len(record.Rates) != 1 || record.Freshness != nil {
+		t.Errorf("GetLatestV2() data = %+v, want the EUR rates for USD without freshness", record)
+	}
+	if envelope.Meta.Date != "2025-01-03" || envelope.Meta.Freshness == nil || !envelope.Meta.Freshness.Stale {
+		t.Errorf("GetLatestV2() meta = %+v, want the date and stale freshness", envelope.Meta)
+	}
+	if envelope.Errors == nil || len(envelope.Errors) != 0 {
+		t.Errorf("GetLatestV2() errors = %v, want an empty list", envelope.Errors)
+	}
+	if recorder.Header().Get(DataExpectedDateHeader) == "" {
+		t.Errorf("GetLatestV2() didn't set %s", DataExpectedDateHeader)
+	}
+}
+
+func TestGetLatestV2Handler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		record         *ExchangeRateRecord
+		err            error
+		wantStatusCode int
+	}{
+		{name: "no rates", wantStatusCode: http.StatusNotFound},
+		{name: "database error", err: errors.New("database error"), wantStatusCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useMockRepository(t, &MockRatesRepository{
+				GetLatestRateFunc: func(base string) (*ExchangeRateRecord, error) {
+					return tt.record, tt.err
+				},
+			})
+
+			recorder, envelope := serveV2(t, GetLatestV2, V2LatestPath)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Errorf("GetLatestV2() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if envelope.Data != nil {
+				t.Errorf("GetLatestV2() data = %s, want it left out", envelope.Data)
+			}
+			if len(envelope.Errors) != 1 || envelope.Errors[0].Status != tt.wantStatusCode {
+				t.Errorf("GetLatestV2() errors = %+v, want one with status %d", envelope.Errors, tt.wantStatusCode)
+			}
+		})
+	}
+}
+
+func TestGetSymbolsV2Handler(t *testing.T) {
+	useMockRepository(t, &MockRatesRepository{
+		GetAllSymbolsFunc: func() (*SymbolsRecord, error) {
+			return &SymbolsRecord{Date: "2025-11-21", Symbols: []string{"USD", "EUR", "GBP", "CHF", "JPY"}}, nil
+		},
+	})
+
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantSymbols    []string
+		wantPagination *Pagination
+	}{
+		{
+			name:           "first page by default",
+			wantStatusCode: http.StatusOK,
+			wantSymbols:    []string{"USD", "EUR", "GBP", "CHF", "JPY"},
+			wantPagination: &Pagination{Page: 1, PerPage: defaultPerPage, Total: 5, TotalPages: 1},
+		},
+		{
+			name:           "sorted page",
+			query:          "?sort=code&page=2&per_page=2",
+			wantStatusCode: http.StatusOK,
+			wantSymbols:    []string{"GBP", "JPY"},
+			wantPagination: &Pagination{Page: 2, PerPage: 2, Total: 5, TotalPages: 3},
+		},
+		{name: "invalid page", query: "?page=-1", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid sort", query: "?sort=size", wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, envelope := serveV2(t, GetSymbolsV2, V2SymbolsPath+tt.query)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("GetSymbolsV2() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var symbols []string
+			if err := json.Unmarshal(envelope.Data, &symbols); err != nil {
+				t.Fatalf("failed to decode data: %v", err)
+			}
+			if !slices.Equal(symbols, tt.wantSymbols) {
+				t.Errorf("GetSymbolsV2() data = %v, want %v", symbols, tt.wantSymbols)
+			}
+			if envelope.Meta.Pagination == nil || *envelope.Meta.Pagination != *tt.wantPagination {
+				t.Errorf("GetSymbolsV2() pagination = %+v, want %+v", envelope.Meta.Pagination, tt.wantPagination)
+			}
+		})
+	}
+}
+
+func TestGetCurrenciesV2Handler(t *testing.T) {
+	useMockRepository(t, &MockRatesRepository{
+		GetAllSymbolsFunc: func() (*SymbolsRecord, error) {
+			return &SymbolsRecord{Date: "2025-11-21", Symbols: []string{"USD", "EUR", "GBP"}}, nil
+		},
+	})
+
+	recorder, envelope := serveV2(t, GetCurrenciesV2, V2CurrenciesPath+"?sort=code&locale=de&per_page=2")
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GetCurrenciesV2() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	var currencies []NamedSymbol
+	if err := json.Unmarshal(envelope.Data, &currencies); err != nil {
+		t.Fatalf("failed to decode data: %v", err)
+	}
+	if len(currencies) != 2 || currencies[0].Symbol != "EUR" || currencies[1].Symbol != "GBP" {
+		t.Errorf("GetCurrenciesV2() data = %+v, want EUR and GBP", currencies)
+	}
+	if envelope.Meta.Locale != "de" || envelope.Meta.Date != "2025-11-21" {
+		t.Errorf("GetCurrenciesV2() meta = %+v, want the German locale and the symbols date", envelope.Meta)
+	}
+	want := Pagination{Page: 1, PerPage: 2, Total: 3, TotalPages: 2}
+	if envelope.Meta.Pagination == nil || *envelope.Meta.Pagination != want {
+		t.Errorf("GetCurrenciesV2() pagination = %+v, want %+v", envelope.Meta.Pagination, want)
+	}
+}
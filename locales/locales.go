@@ -0,0 +1,209 @@
+// Package locales holds the localized currency data served by the API. Every locale is an
+// embedded JSON file in data/ named after its language tag, so supporting a new locale only
+// takes adding a file.
+package locales
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultTag = "en"
+
+	SignBefore = "before"
+	SignAfter  = "after"
+)
+
+//go:embed data/*.json
+var dataFiles embed.FS
+
+type Locale struct {
+	// Tag is the BCP 47 language tag of the locale, taken from its file name.
+	Tag  string `json:"-"`
+	Name string `json:"name"`
+	// SignPlacement tells whether the currency sign goes before or after the amount.
+	SignPlacement string `json:"sign_placement"`
+	// SignSpacing tells whether the currency sign is separated from the amount by a space.
+	SignSpacing      bool   `json:"sign_spacing"`
+	DecimalSeparator string `json:"decimal_separator"`
+	// GroupSeparator separates groups of thousands, it may be empty to disable grouping.
+	GroupSeparator string `json:"group_separator"`
+	// Names maps currency codes to their localized names.
+	Names map[string]string `json:"names"`
+}
+
+// CurrencyName returns the localized name of a currency, or fallback when the locale
+// doesn't translate it.
+func (l *Locale) CurrencyName(code string, fallback string) string {
+	if name, ok := l.Names[code]; ok && name != "" {
+		return name
+	}
+	return fallback
+}
+
+var registry = func() map[string]*Locale {
+	loaded, err := load()
+	if err != nil {
+		panic(err)
+	}
+	return loaded
+}()
+
+func load() (map[string]*Locale, error) {
+	entries, err := dataFiles.ReadDir("data")
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[string]*Locale, len(entries))
+	for _, entry := range entries {
+		content, err := dataFiles.ReadFile(path.Join("data", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var locale Locale
+		if err := json.Unmarshal(content, &locale); err != nil {
+			return nil, fmt.Errorf("locale %s: %w", entry.Name(), err)
+		}
+		if locale.SignPlacement != SignBefore && locale.SignPlacement != SignAfter {
+			return nil, fmt.Errorf("locale %s: invalid sign_placement %q", entry.Name(), locale.SignPlacement)
+		}
+		if locale.DecimalSeparator == "" {
+			return nil, fmt.Errorf("locale %s: missing decimal_separator", entry.Name())
+		}
+
+		locale.Tag = normalizeTag(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+		loaded[locale.Tag] = &locale
+	}
+
+	if _, ok := loaded[DefaultTag]; !ok {
+		return nil, fmt.Errorf("default locale %q is missing", DefaultTag)
+	}
+	return loaded, nil
+}
+
+// Default returns the locale used when nothing else matches.
+func Default() *Locale {
+	return registry[DefaultTag]
+}
+
+// Tags returns the tags of all available locales in alphabetical order.
+func Tags() []string {
+	tags := make([]string, 0, len(registry))
+	for tag := range registry {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// Lookup finds the locale for a language tag. A regional tag such as de-AT falls back to
+// its language, de, when there is no dedicated locale for the region.
+func Lookup(tag string) (*Locale, bool) {
+	normalized := normalizeTag(tag)
+	if normalized == "" {
+		return nil, false
+	}
+
+	if locale, ok := registry[normalized]; ok {
+		return locale, true
+	}
+
+	language, _, _ := strings.Cut(normalized, "-")
+	locale, ok := registry[language]
+	return locale, ok
+}
+
+// Negotiate picks a locale from an explicitly requested tag, then from an Accept-Language
+// header in order of preference, and finally falls back to the default locale.
+func Negotiate(requested string, acceptLanguage string) *Locale {
+	if locale, ok := Lookup(requested); ok {
+		return locale
+	}
+
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale, ok := Lookup(tag); ok {
+			return locale
+		}
+	}
+
+	return Default()
+}
+
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weightedTag
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+		tags = append(tags, weightedTag{tag: tag, weight: weight})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].weight > tags[j].weight })
+
+	ordered := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ordered = append(ordered, tag.tag)
+	}
+	return ordered
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// FormatNumber renders a non-negative number rounded half away from zero to the given number
+// of decimals, using the locale's decimal and group separators.
+func (l *Locale) FormatNumber(value float64, decimals int) string {
+	// Numbers from 2^52 up are whole, so they need no rounding, and scaling them can overflow
+	// or change their digits.
+	rounded := value
+	if value < 1<<52 {
+		scale := math.Pow10(decimals)
+		rounded = math.Round(value*scale) / scale
+	}
+	formatted := strconv.FormatFloat(rounded, 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(formatted, ".")
+
+	var builder strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			builder.WriteString(l.GroupSeparator)
+		}
+		builder.WriteRune(digit)
+	}
+
+	if fraction != "" {
+		builder.WriteString(l.DecimalSeparator)
+		builder.WriteString(fraction)
+	}
+	return builder.String()
+}
@@ -0,0 +1,108 @@
+package locales
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantTag string
+		wantOK  bool
+	}{
+		{name: "exact match", tag: "de", wantTag: "de", wantOK: true},
+		{name: "case insensitive", tag: "NL", wantTag: "nl", wantOK: true},
+		{name: "region falls back to language", tag: "de-AT", wantTag: "de", wantOK: true},
+		{name: "underscore separator", tag: "ja_JP", wantTag: "ja", wantOK: true},
+		{name: "unknown language", tag: "fr", wantOK: false},
+		{name: "empty tag", tag: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, ok := Lookup(tt.tag)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup(%q) ok = %v, want %v", tt.tag, ok, tt.wantOK)
+			}
+			if ok && locale.Tag != tt.wantTag {
+				t.Errorf("Lookup(%q) tag = %q, want %q", tt.tag, locale.Tag, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		requested      string
+		acceptLanguage string
+		wantTag        string
+	}{
+		{name: "defaults to English", wantTag: "en"},
+		{name: "explicit locale wins", requested: "ja", acceptLanguage: "de", wantTag: "ja"},
+		{name: "unknown explicit locale falls back to header", requested: "fr", acceptLanguage: "nl-BE", wantTag: "nl"},
+		{name: "first supported header language", acceptLanguage: "fr-FR, de;q=0.8, nl;q=0.5", wantTag: "de"},
+		{name: "respects quality values", acceptLanguage: "nl;q=0.4, ja;q=0.9", wantTag: "ja"},
+		{name: "ignores refused languages", acceptLanguage: "de;q=0, nl", wantTag: "nl"},
+		{name: "nothing supported", acceptLanguage: "fr, es", wantTag: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.requested, tt.acceptLanguage).Tag; got != tt.wantTag {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.requested, tt.acceptLanguage, got, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestLocalesData(t *testing.T) {
+	for _, tag := range []string{"en", "de", "nl", "ja"} {
+		if _, ok := Lookup(tag); !ok {
+			t.Errorf("missing locale %q", tag)
+		}
+	}
+
+	de, _ := Lookup("de")
+	if de.SignPlacement != SignAfter {
+		t.Errorf("de sign placement = %q, want %q", de.SignPlacement, SignAfter)
+	}
+	if got := de.CurrencyName("USD", "US Dollar"); got != "US-Dollar" {
+		t.Errorf("de USD name = %q, want %q", got, "US-Dollar")
+	}
+	if got := Default().CurrencyName("USD", "US Dollar"); got != "US Dollar" {
+		t.Errorf("default USD name = %q, want fallback %q", got, "US Dollar")
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	de, _ := Lookup("de")
+
+	tests := []struct {
+		name     string
+		locale   *Locale
+		value    float64
+		decimals int
+		want     string
+	}{
+		{name: "small number", locale: Default(), value: 5, decimals: 2, want: "5.00"},
+		{name: "groups thousands", locale: Default(), value: 1234567.5, decimals: 2, want: "1,234,567.50"},
+		{name: "no decimals", locale: Default(), value: 999.5, decimals: 0, want: "1,000"},
+		{name: "exact group boundary", locale: Default(), value: 100000, decimals: 0, want: "100,000"},
+		{name: "locale separators", locale: de, value: 1234.5, decimals: 2, want: "1.234,50"},
+		{name: "no grouping", locale: &Locale{DecimalSeparator: "."}, value: 1234.5, decimals: 1, want: "1234.5"},
+		{name: "whole number beyond float precision", locale: Default(), value: 1e20, decimals: 2, want: "100,000,000,000,000,000,000.00"},
+		{name: "scaling would overflow", locale: &Locale{DecimalSeparator: "."}, value: math.MaxFloat64, decimals: 2, want: strconv.FormatFloat(math.MaxFloat64, 'f', 2, 64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.FormatNumber(tt.value, tt.decimals); got != tt.want {
+				t.Errorf("FormatNumber(%v, %d) = %q, want %q", tt.value, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,14 +4,48 @@
 // @version         1.0
 // @description     API for fetching currency exchange rates.
 //
-// @host            localhost:8000
 // @BasePath        /
+//
+// @securityDefinitions.apikey  AdminToken
+// @in                          header
+// @name                        Authorization
+// @description                 Token of an admin, sent as "Bearer <token>".
+//
+// @securityDefinitions.apikey  WebhookToken
+// @in                          header
+// @name                        Authorization
+// @description                 Token returned when the first webhook of an owner is registered, sent as "Bearer <token>".
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/kamaal111/forex-api/config"
 	"github.com/kamaal111/forex-api/routers"
 )
 
 func main() {
-	routers.Start()
+	cfg, options, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+
+	if options.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := routers.Start(cfg); err != nil {
+		log.Fatal(err)
+	}
 }
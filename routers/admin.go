@@ -0,0 +1,24 @@
+package routers
+
+import (
+	"net/http"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/kamaal111/forex-api/admin"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// adminGroup serves the admin API to the admins of credentials, making corrections through
+// client and recording them in auditCollection. Without credentials the admin API isn't served
+// at all.
+func adminGroup(mux *http.ServeMux, client *firestore.Client, credentials []string, auditCollection string) {
+	if len(credentials) == 0 {
+		return
+	}
+
+	api := admin.NewAPI(admin.FirestoreStore{Client: client, AuditCollection: auditCollection}, credentials)
+	v1.handle(mux, handlers.AdminRatesPath, api.HandleRates)
+	v1.handle(mux, handlers.AdminSymbolsPath, api.RegenerateSymbols)
+	v1.handle(mux, handlers.AdminAuditPath, api.ListAudit)
+}
@@ -0,0 +1,222 @@
+package routers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	// compressionMinSize is the smallest response body, in bytes, worth compressing.
+	compressionMinSize = 1024
+
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+var brotliWriters = sync.Pool{
+	New: func() any { return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression) },
+}
+
+func compressionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		writer := &compressionWriter{ResponseWriter: w, encoding: encoding}
+		defer writer.Close()
+		next.ServeHTTP(writer, r)
+	})
+}
+
+// negotiateEncoding picks the preferred supported encoding from an Accept-Encoding header,
+// favouring Brotli over gzip when the client weighs them equally.
+func negotiateEncoding(header string) string {
+	weights := map[string]float64{}
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		weight := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		weights[name] = weight
+	}
+
+	best := ""
+	bestWeight := 0.0
+	for _, encoding := range []string{encodingBrotli, encodingGzip} {
+		weight, ok := weights[encoding]
+		if !ok {
+			weight, ok = weights["*"]
+		}
+		if ok && weight > bestWeight {
+			best = encoding
+			bestWeight = weight
+		}
+	}
+	return best
+}
+
+// compressionWriter buffers the start of a response until it knows whether the body is
+// large enough to compress, then either streams it through an encoder or writes it as is.
+type compressionWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+	buffer   bytes.Buffer
+	encoder  io.WriteCloser
+	decided  bool
+}
+
+func (w *compressionWriter) WriteHeader(code int) {
+	if w.decided || w.status != 0 {
+		return
+	}
+	w.status = code
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		w.passthrough()
+	}
+}
+
+func (w *compressionWriter) Write(content []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(content)
+		}
+		return w.ResponseWriter.Write(content)
+	}
+
+	w.buffer.Write(content)
+	if w.buffer.Len() >= compressionMinSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(content), nil
+}
+
+func (w *compressionWriter) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *compressionWriter) Close() error {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	switch encoder := w.encoder.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(encoder)
+	case *brotli.Writer:
+		brotliWriters.Put(encoder)
+	}
+	w.encoder = nil
+	return err
+}
+
+func (w *compressionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressionWriter) decide() error {
+	if w.buffer.Len() < compressionMinSize || !w.compressible() {
+		return w.passthrough()
+	}
+
+	header := w.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	header.Del("Accept-Ranges")
+
+	switch w.encoding {
+	case encodingBrotli:
+		encoder := brotliWriters.Get().(*brotli.Writer)
+		encoder.Reset(w.ResponseWriter)
+		w.encoder = encoder
+	default:
+		encoder := gzipWriters.Get().(*gzip.Writer)
+		encoder.Reset(w.ResponseWriter)
+		w.encoder = encoder
+	}
+
+	w.decided = true
+	w.ResponseWriter.WriteHeader(w.status)
+	_, err := w.encoder.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+func (w *compressionWriter) passthrough() error {
+	w.decided = true
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+func (w *compressionWriter) compressible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if contentType == "" {
+		contentType = http.DetectContentType(w.buffer.Bytes())
+	}
+	switch {
+	case strings.HasPrefix(contentType, "text/event-stream"):
+		return false
+	case strings.HasPrefix(contentType, "text/"),
+		strings.Contains(contentType, "json"),
+		strings.Contains(contentType, "yaml"),
+		strings.Contains(contentType, "xml"),
+		strings.Contains(contentType, "javascript"):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package routers
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "no header", header: "", want: ""},
+		{name: "gzip only", header: "gzip", want: "gzip"},
+		{name: "brotli preferred on equal weight", header: "gzip, deflate, br", want: "br"},
+		{name: "respects weights", header: "br;q=0.5, gzip;q=0.8", want: "gzip"},
+		{name: "refused encoding", header: "br;q=0, gzip;q=0", want: ""},
+		{name: "wildcard", header: "*", want: "br"},
+		{name: "unsupported only", header: "deflate, identity", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negotiateEncoding(tt.header); got != tt.want {
+				t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	largeBody := `{"rates":{` + strings.Repeat(`"USD":1.08,`, 200) + `"GBP":0.86}}`
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		wantEncoding   string
+	}{
+		{name: "compresses large JSON with gzip", acceptEncoding: "gzip", contentType: "application/json", body: largeBody, wantEncoding: "gzip"},
+		{name: "compresses large JSON with brotli", acceptEncoding: "gzip, br", contentType: "application/json", body: largeBody, wantEncoding: "br"},
+		{name: "skips bodies below the threshold", acceptEncoding: "gzip, br", contentType: "application/json", body: `{"status":"ok"}`, wantEncoding: ""},
+		{name: "skips clients without support", acceptEncoding: "", contentType: "application/json", body: largeBody, wantEncoding: ""},
+		{name: "skips event streams", acceptEncoding: "gzip", contentType: "text/event-stream", body: largeBody, wantEncoding: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-type", tt.contentType)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tt.body))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			recorder := httptest.NewRecorder()
+
+			compressionMiddleware(testHandler).ServeHTTP(recorder, req)
+
+			if recorder.Code != http.StatusOK {
+				t.Errorf("compressionMiddleware() status = %d, want %d", recorder.Code, http.StatusOK)
+			}
+
+			if vary := recorder.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Errorf("compressionMiddleware() Vary = %q, want %q", vary, "Accept-Encoding")
+			}
+
+			encoding := recorder.Header().Get("Content-Encoding")
+			if encoding != tt.wantEncoding {
+				t.Fatalf("compressionMiddleware() Content-Encoding = %q, want %q", encoding, tt.wantEncoding)
+			}
+
+			var reader io.Reader = recorder.Body
+			switch encoding {
+			case "gzip":
+				gzipReader, err := gzip.NewReader(recorder.Body)
+				if err != nil {
+					t.Fatalf("failed to open gzip body: %v", err)
+				}
+				reader = gzipReader
+			case "br":
+				reader = brotli.NewReader(recorder.Body)
+			}
+
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if string(body) != tt.body {
+				t.Errorf("compressionMiddleware() body round trip mismatch: got %d bytes, want %d", len(body), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware_PreservesStatusCode(t *testing.T) {
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not found","status":404}`))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+
+	loggerMiddleware(compressionMiddleware(testHandler)).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("compressionMiddleware() status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
@@ -7,5 +7,7 @@ import (
 )
 
 func currenciesGroup(mux *http.ServeMux) {
-	mux.Handle(handlers.CurrenciesPath, loggerMiddleware(http.HandlerFunc(handlers.GetCurrencies)))
+	v1.handle(mux, handlers.CurrenciesPath, handlers.GetCurrencies)
+	v1.handle(mux, handlers.CurrencyPath, handlers.GetCurrency)
+	v1.handle(mux, handlers.FormatPath, handlers.GetFormat)
 }
@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// graphqlGroup serves the GraphQL endpoint. It isn't versioned, the schema evolves by adding
+// fields instead.
+func graphqlGroup(mux *http.ServeMux) {
+	mux.Handle(handlers.GraphQLPath, withMiddleware(handlers.ServeGraphQL))
+}
@@ -0,0 +1,50 @@
+package routers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/kamaal111/forex-api/grpcapi"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// listenGRPC listens on address for the gRPC API and returns a function serving it until it
+// fails or ctx is done. It shares the rate feed of the HTTP stream, so both poll the repository
+// once, and serves TLS with tlsConfig, the configuration of the HTTP server, when that isn't
+// nil.
+func listenGRPC(ctx context.Context, address string, tlsConfig *tls.Config) (func() error, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for gRPC on %s: %w", address, err)
+	}
+
+	server := grpcapi.NewServer(handlers.Feed)
+	var options []grpc.ServerOption
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer, healthServer := grpcapi.NewGRPCServer(server, options...)
+	go server.ReportHealth(ctx, healthServer, grpcapi.HealthCheckInterval)
+	go func() {
+		<-ctx.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			// Rate streams only end with their clients, so the ones still open are cut off.
+			grpcServer.Stop()
+		}
+	}()
+
+	return func() error { return grpcServer.Serve(listener) }, nil
+}
@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+func healthGroup(mux *http.ServeMux) {
+	mux.Handle(handlers.HealthPath, withMiddleware(handlers.GetHealth))
+	mux.Handle(handlers.ReadinessPath, withMiddleware(handlers.GetReadiness))
+	mux.Handle(handlers.VersionPath, withMiddleware(handlers.GetVersion))
+}
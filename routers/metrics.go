@@ -0,0 +1,34 @@
+package routers
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// publicVars are the expvar variables served on /debug/vars. The defaults of expvar, cmdline
+// and memstats, are left out: the command line can hold secrets passed as flags.
+var publicVars = []string{"recovered_panics"}
+
+func metricsGroup(mux *http.ServeMux) {
+	mux.Handle(handlers.DebugVarsPath, withMiddleware(getVars))
+}
+
+// getVars writes publicVars in the JSON format of expvar.Handler.
+func getVars(writer http.ResponseWriter, request *http.Request) {
+	var body strings.Builder
+	body.WriteString("{\n")
+	for i, name := range publicVars {
+		if i > 0 {
+			body.WriteString(",\n")
+		}
+		fmt.Fprintf(&body, "%q: %s", name, expvar.Get(name).String())
+	}
+	body.WriteString("\n}\n")
+
+	writer.Header().Set("content-type", "application/json; charset=utf-8")
+	writer.Write([]byte(body.String()))
+}
@@ -0,0 +1,30 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+func TestGetVars(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, handlers.DebugVarsPath, nil)
+	recorder := httptest.NewRecorder()
+
+	getVars(recorder, req)
+
+	var vars map[string]json.RawMessage
+	if err := json.Unmarshal(recorder.Body.Bytes(), &vars); err != nil {
+		t.Fatalf("getVars() body %s isn't JSON: %v", recorder.Body, err)
+	}
+	if _, ok := vars["recovered_panics"]; !ok {
+		t.Errorf("getVars() = %s, want recovered_panics", recorder.Body)
+	}
+	for _, name := range []string{"cmdline", "memstats"} {
+		if _, ok := vars[name]; ok {
+			t.Errorf("getVars() published %s", name)
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package routers
 
 import (
+	"expvar"
 	"log"
 	"net/http"
+	"runtime/debug"
 	"time"
 )
 
+// recoveredPanics counts the panics caught by recoveryMiddleware, published on /debug/vars.
+var recoveredPanics = expvar.NewInt("recovered_panics")
+
+func withMiddleware(handler http.HandlerFunc) http.Handler {
+	return loggerMiddleware(compressionMiddleware(recoveryMiddleware(validationMiddleware(handler))))
+}
+
 func loggerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -16,6 +25,36 @@ func loggerMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+func recoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		observer := &responseObserver{ResponseWriter: w}
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			recoveredPanics.Add(1)
+			log.Printf(
+				"panic serving %s %s (query: %q, remote: %s, user-agent: %q): %v\n%s",
+				r.Method, r.URL.Path, r.URL.RawQuery, r.RemoteAddr, r.UserAgent(), recovered, debug.Stack(),
+			)
+
+			if observer.status != 0 {
+				// The response has already started. Aborting makes net/http cut the connection or
+				// stream, so the client sees a failure rather than a truncated body.
+				panic(http.ErrAbortHandler)
+			}
+			writeError(observer, r, "Internal server error", http.StatusInternalServerError)
+		}()
+
+		next.ServeHTTP(observer, r)
+	})
+}
+
 type responseObserver struct {
 	http.ResponseWriter
 	status int
@@ -25,3 +64,14 @@ func (o *responseObserver) WriteHeader(code int) {
 	o.ResponseWriter.WriteHeader(code)
 	o.status = code
 }
+
+func (o *responseObserver) Write(content []byte) (int, error) {
+	if o.status == 0 {
+		o.status = http.StatusOK
+	}
+	return o.ResponseWriter.Write(content)
+}
+
+func (o *responseObserver) Unwrap() http.ResponseWriter {
+	return o.ResponseWriter
+}
@@ -7,5 +7,7 @@ import (
 )
 
 func openapiGroup(mux *http.ServeMux) {
-	mux.Handle(handlers.OpenAPISpecPath, loggerMiddleware(http.HandlerFunc(handlers.GetOpenAPISpec)))
+	mux.Handle(handlers.OpenAPISpecPath, withMiddleware(handlers.GetOpenAPISpec))
+	mux.Handle(handlers.OpenAPI3Path, withMiddleware(handlers.GetOpenAPI3Spec))
+	mux.Handle(handlers.DocsPath, withMiddleware(handlers.GetDocs))
 }
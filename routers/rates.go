@@ -7,6 +7,12 @@ import (
 )
 
 func ratesGroup(mux *http.ServeMux) {
-	mux.Handle(handlers.LatestPath, loggerMiddleware(http.HandlerFunc(handlers.GetLatest)))
-	mux.Handle(handlers.SymbolsPath, loggerMiddleware(http.HandlerFunc(handlers.GetSymbols)))
+	v1.handle(mux, handlers.LatestPath, handlers.GetLatest)
+	v1.handle(mux, handlers.HistoricalPath, handlers.GetHistorical)
+	v1.handle(mux, handlers.SymbolsPath, handlers.GetSymbols)
+	v1.handle(mux, handlers.BatchPath, handlers.PostBatch)
+	v1.handle(mux, handlers.FluctuationPath, handlers.GetFluctuation)
+	v1.handle(mux, handlers.StatsPath, handlers.GetStats)
+	v1.handle(mux, handlers.CandlesPath, handlers.GetCandles)
+	v1.handle(mux, handlers.StreamPath, handlers.GetStream)
 }
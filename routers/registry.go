@@ -0,0 +1,66 @@
+package routers
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/kamaal111/forex-api/config"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// configureCurrencyRegistry layers the optional registry overrides on top of the embedded
+// defaults and keeps the registry fresh on SIGHUP and, when configured, on an interval. The
+// Firestore overrides are read through client.
+func configureCurrencyRegistry(cfg *config.Config, client *firestore.Client) {
+	sources := []handlers.CurrencySource{handlers.EmbeddedCurrencySource{}}
+	if cfg.CurrencyRegistryFile != "" {
+		sources = append(sources, handlers.FileCurrencySource{Path: cfg.CurrencyRegistryFile})
+	}
+	if cfg.CurrencyRegistryCollection != "" {
+		sources = append(sources, handlers.FirestoreCurrencySource{Client: client, Collection: cfg.CurrencyRegistryCollection})
+	}
+	handlers.Registry.SetSources(sources...)
+
+	if len(sources) > 1 {
+		reloadCurrencyRegistry()
+	}
+
+	go watchCurrencyRegistry(cfg.CurrencyRegistryReloadInterval)
+}
+
+func watchCurrencyRegistry(interval time.Duration) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+
+	var ticks <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-hangups:
+		case <-ticks:
+		}
+		reloadCurrencyRegistry()
+	}
+}
+
+func reloadCurrencyRegistry() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := handlers.Registry.Reload(ctx); err != nil {
+		log.Printf("failed to reload currency registry, keeping the current one: %v", err)
+		return
+	}
+	log.Printf("Loaded %d currencies", len(handlers.Registry.Codes()))
+}
@@ -1,28 +1,94 @@
 package routers
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/kamaal111/forex-api/utils"
+	"github.com/kamaal111/forex-api/config"
+	"github.com/kamaal111/forex-api/database"
+	"github.com/kamaal111/forex-api/handlers"
 )
 
-func Start() {
-	serverAddress := os.Getenv("SERVER_ADDRESS")
-	if serverAddress == "" {
-		serverAddress = fmt.Sprintf(":%s", utils.UnwrapEnvironment("PORT"))
+// shutdownTimeout is how long the servers wait for running requests once asked to stop.
+const shutdownTimeout = 10 * time.Second
+
+// Start applies cfg and serves the API until the HTTP or gRPC server fails, returning why, or
+// until the process is interrupted or terminated, stopping both servers and returning nil.
+func Start(cfg *config.Config) error {
+	database.ProjectID = cfg.GCPProjectID
+	handlers.MaxDataAge = cfg.MaxDataAge
+	handlers.Feed.Interval = cfg.RateFeedInterval
+	handlers.GraphQLMaxDepth = cfg.GraphQLMaxDepth
+	handlers.GraphQLMaxComplexity = cfg.GraphQLMaxComplexity
+	handlers.OpenAPIServers = cfg.OpenAPIServers
+
+	client, err := database.CreateClient(context.Background())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	handlers.UseFirestoreClient(client)
+
+	configureCurrencyRegistry(cfg, client)
+	if err := configureSpecValidation(cfg); err != nil {
+		return err
 	}
+	configureVersions(cfg)
 
 	mux := http.NewServeMux()
 	ratesGroup(mux)
 	currenciesGroup(mux)
+	v2Group(mux)
+	webhooksGroup(mux, client, cfg.WebhooksCollection)
+	adminGroup(mux, client, cfg.AdminTokens, cfg.AdminAuditCollection)
+	graphqlGroup(mux)
 	openapiGroup(mux)
-	mux.Handle("/", loggerMiddleware(http.HandlerFunc(notFound)))
+	healthGroup(mux)
+	metricsGroup(mux)
+	mux.Handle("/", withMiddleware(notFound))
+
+	server, certificates, err := newServer(cfg, mux)
+	if err != nil {
+		return err
+	}
+	if certificates != nil {
+		go certificates.watch(context.Background(), cfg.TLSReloadInterval)
+	}
 
-	log.Printf("Listening on %s...", serverAddress)
+	// ctx is done once the servers are asked to stop or one of them failed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	err := http.ListenAndServe(serverAddress, mux)
-	log.Fatal(err)
+	failures := make(chan error, 2)
+	if cfg.GRPCAddress != "" {
+		serveGRPC, err := listenGRPC(ctx, cfg.GRPCAddress, server.TLSConfig)
+		if err != nil {
+			return err
+		}
+		log.Printf("Serving gRPC on %s...", cfg.GRPCAddress)
+		go func() { failures <- serveGRPC() }()
+	}
+
+	log.Printf("Listening on %s...", cfg.Address())
+	go func() { failures <- serve(server) }()
+
+	select {
+	case err := <-failures:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Print("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		// Streams never go idle, so the ones still open are cut off.
+		server.Close()
+	}
+	return nil
 }
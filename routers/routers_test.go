@@ -142,3 +142,86 @@ func TestResponseObserver_Write(t *testing.T) {
 		t.Errorf("responseObserver.Write() body = %q, want %q", recorder.Body.String(), "test content")
 	}
 }
+
+func TestRecoveryMiddleware(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data map[string]string
+		data["boom"] = "nil map"
+	})
+
+	before := recoveredPanics.Value()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?base=USD", nil)
+	recorder := httptest.NewRecorder()
+
+	recoveryMiddleware(panicking).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("recoveryMiddleware() status = %d, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+
+	contentType := recorder.Header().Get("content-type")
+	if contentType != "application/json" {
+		t.Errorf("recoveryMiddleware() content-type = %q, want %q", contentType, "application/json")
+	}
+
+	var response utils.Error
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Status != http.StatusInternalServerError {
+		t.Errorf("recoveryMiddleware() status in body = %d, want %d", response.Status, http.StatusInternalServerError)
+	}
+
+	if got := recoveredPanics.Value() - before; got != 1 {
+		t.Errorf("recoveredPanics increased by %d, want 1", got)
+	}
+}
+
+func TestRecoveryMiddleware_ResponseAlreadyStarted(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		panic("late failure")
+	})
+
+	before := recoveredPanics.Value()
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	recorder := httptest.NewRecorder()
+
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("recoveryMiddleware() panicked with %v, want http.ErrAbortHandler", recovered)
+		}
+		if got := recoveredPanics.Value() - before; got != 1 {
+			t.Errorf("recoveredPanics increased by %d, want 1", got)
+		}
+		if recorder.Body.String() != "partial" {
+			t.Errorf("recoveryMiddleware() body = %q, want %q", recorder.Body.String(), "partial")
+		}
+	}()
+
+	recoveryMiddleware(panicking).ServeHTTP(recorder, req)
+}
+
+func TestRecoveryMiddleware_PassesThrough(t *testing.T) {
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	before := recoveredPanics.Value()
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	recorder := httptest.NewRecorder()
+
+	recoveryMiddleware(testHandler).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("recoveryMiddleware() status = %d, want %d", recorder.Code, http.StatusCreated)
+	}
+
+	if recoveredPanics.Value() != before {
+		t.Errorf("recoveredPanics changed without a panic")
+	}
+}
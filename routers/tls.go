@@ -0,0 +1,169 @@
+package routers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kamaal111/forex-api/config"
+)
+
+const (
+	// readHeaderTimeout cuts off clients that are slow to send their request headers, which
+	// would otherwise hold connections open for free.
+	readHeaderTimeout = 10 * time.Second
+	// idleTimeout closes kept-alive connections without requests.
+	idleTimeout = 2 * time.Minute
+)
+
+// newServer builds the HTTP server of cfg. It serves TLS when a certificate is configured,
+// asking clients for certificates signed by the client CAs when those are configured too, and
+// h2c next to HTTP/1.1 when enabled. The returned reloader is nil without TLS. The server has
+// no write timeout, which would cut off the rate streams.
+func newServer(cfg *config.Config, handler http.Handler) (*http.Server, *certificateReloader, error) {
+	server := &http.Server{
+		Addr:              cfg.Address(),
+		Handler:           handler,
+		Protocols:         new(http.Protocols),
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	server.Protocols.SetHTTP1(true)
+
+	if cfg.TLSCertFile == "" {
+		server.Protocols.SetUnencryptedHTTP2(cfg.H2C)
+		return server, nil, nil
+	}
+
+	certificates, err := newCertificateReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	server.Protocols.SetHTTP2(true)
+	server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: certificates.GetCertificate}
+
+	if cfg.TLSClientCAFile != "" {
+		pool, err := loadCertificatePool(cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		server.TLSConfig.ClientCAs = pool
+		server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return server, certificates, nil
+}
+
+// serve serves server until it fails, over TLS when it has a TLS configuration.
+func serve(server *http.Server) error {
+	if server.TLSConfig != nil {
+		// The certificate comes from TLSConfig.GetCertificate.
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
+
+func loadCertificatePool(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CAs: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// certificateReloader serves the certificate of a certificate and key file pair, and loads it
+// again once either file changes, so rotated certificates are picked up without a restart.
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	// modified holds the modification times of the files the certificate was loaded from.
+	modified [2]time.Time
+}
+
+func newCertificateReloader(certFile string, keyFile string) (*certificateReloader, error) {
+	reloader := &certificateReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, nil
+}
+
+// reload loads the certificate when its files changed since the last load, and reports
+// whether it did. A pair that fails to load leaves the current certificate in place, which
+// covers rotations that replace one file before the other.
+func (r *certificateReloader) reload() (bool, error) {
+	var modified [2]time.Time
+	for i, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		modified[i] = info.ModTime()
+	}
+
+	r.mu.RLock()
+	unchanged := r.certificate != nil && modified == r.modified
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certificate = &certificate
+	r.modified = modified
+	return true, nil
+}
+
+// watch reloads the certificate on SIGHUP and every interval until ctx is done.
+func (r *certificateReloader) watch(ctx context.Context, interval time.Duration) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+		case <-ticker.C:
+		}
+
+		reloaded, err := r.reload()
+		switch {
+		case err != nil:
+			log.Printf("keeping the current TLS certificate: %v", err)
+		case reloaded:
+			log.Printf("Loaded TLS certificate from %s", r.certFile)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package routers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/config"
+)
+
+// testCertificate is a certificate with its key, signed by parent or by itself.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	der         []byte
+}
+
+func newTestCertificate(t *testing.T, name string, template x509.Certificate, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("failed to generate serial number: %v", err)
+	}
+	template.SerialNumber = serial
+	template.Subject = pkix.Name{CommonName: name}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	signer, signerKey := &template, key
+	if parent != nil {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return &testCertificate{certificate: certificate, key: key, der: der}
+}
+
+func newTestCA(t *testing.T, name string) *testCertificate {
+	return newTestCertificate(t, name, x509.Certificate{
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+}
+
+func newTestServerCertificate(t *testing.T, ca *testCertificate) *testCertificate {
+	return newTestCertificate(t, "localhost", x509.Certificate{
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+	}, ca)
+}
+
+func newTestClientCertificate(t *testing.T, ca *testCertificate) *testCertificate {
+	return newTestCertificate(t, "client", x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+	}, ca)
+}
+
+func (c *testCertificate) certificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der})
+}
+
+func (c *testCertificate) keyPEM(t *testing.T) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	certificate, err := tls.X509KeyPair(c.certificatePEM(), c.keyPEM(t))
+	if err != nil {
+		t.Fatalf("failed to build TLS certificate: %v", err)
+	}
+	return certificate
+}
+
+// writeTestFile writes content to name in dir with the given modification time, so reloads
+// don't depend on the resolution of the file system clock.
+func writeTestFile(t *testing.T, dir string, name string, content []byte, modified time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatalf("failed to touch %s: %v", name, err)
+	}
+	return path
+}
+
+func TestCertificateReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "ca")
+	first := newTestServerCertificate(t, ca)
+	loaded := time.Now().Add(-time.Minute)
+	certFile := writeTestFile(t, dir, "server.pem", first.certificatePEM(), loaded)
+	keyFile := writeTestFile(t, dir, "server-key.pem", first.keyPEM(t), loaded)
+
+	reloader, err := newCertificateReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertificateReloader() error = %v", err)
+	}
+	assertServedCertificate(t, reloader, first)
+
+	if reloaded, err := reloader.reload(); reloaded || err != nil {
+		t.Errorf("reload() of unchanged files = %v, %v, want false, nil", reloaded, err)
+	}
+
+	// A rotation that has replaced the certificate but not the key yet keeps the old pair.
+	second := newTestServerCertificate(t, ca)
+	rotated := loaded.Add(30 * time.Second)
+	writeTestFile(t, dir, "server.pem", second.certificatePEM(), rotated)
+	if reloaded, err := reloader.reload(); reloaded || err == nil {
+		t.Errorf("reload() of a mismatched pair = %v, %v, want false and an error", reloaded, err)
+	}
+	assertServedCertificate(t, reloader, first)
+
+	writeTestFile(t, dir, "server-key.pem", second.keyPEM(t), rotated)
+	if reloaded, err := reloader.reload(); !reloaded || err != nil {
+		t.Errorf("reload() of a rotated pair = %v, %v, want true, nil", reloaded, err)
+	}
+	assertServedCertificate(t, reloader, second)
+}
+
+func TestNewCertificateReloader_MissingFile(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := newCertificateReloader(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem")); err == nil {
+		t.Error("newCertificateReloader() of missing files error = nil, want an error")
+	}
+}
+
+func assertServedCertificate(t *testing.T, reloader *certificateReloader, want *testCertificate) {
+	t.Helper()
+	served, err := reloader.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate() error = %v", err)
+	}
+	if served.Leaf == nil || !served.Leaf.Equal(want.certificate) {
+		t.Errorf("GetCertificate() served serial %v, want %v", served.Leaf.SerialNumber, want.certificate.SerialNumber)
+	}
+}
+
+// startTestServer serves a handler reporting the protocol of each request with the server
+// newServer builds for cfg, and returns its URL.
+func startTestServer(t *testing.T, cfg *config.Config) string {
+	t.Helper()
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte(request.Proto))
+	})
+	server, _, err := newServer(cfg, handler)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	scheme := "http"
+	if server.TLSConfig != nil {
+		scheme = "https"
+		go server.ServeTLS(listener, "", "")
+	} else {
+		go server.Serve(listener)
+	}
+	t.Cleanup(func() { server.Close() })
+	return scheme + "://" + listener.Addr().String()
+}
+
+func TestNewServer_Timeouts(t *testing.T) {
+	server, _, err := newServer(testServerConfig(), http.NotFoundHandler())
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+
+	if server.ReadHeaderTimeout != readHeaderTimeout || server.IdleTimeout != idleTimeout {
+		t.Errorf("newServer() timeouts = %v read header, %v idle, want %v, %v", server.ReadHeaderTimeout, server.IdleTimeout, readHeaderTimeout, idleTimeout)
+	}
+	if server.WriteTimeout != 0 {
+		t.Errorf("newServer() write timeout = %v, want none so streams stay open", server.WriteTimeout)
+	}
+}
+
+func TestNewServer_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newTestCA(t, "server ca")
+	clientCA := newTestCA(t, "client ca")
+	serverCertificate := newTestServerCertificate(t, serverCA)
+
+	cfg := testServerConfig()
+	cfg.TLSCertFile = writeTestFile(t, dir, "server.pem", serverCertificate.certificatePEM(), time.Now())
+	cfg.TLSKeyFile = writeTestFile(t, dir, "server-key.pem", serverCertificate.keyPEM(t), time.Now())
+	cfg.TLSClientCAFile = writeTestFile(t, dir, "clients.pem", clientCA.certificatePEM(), time.Now())
+	url := startTestServer(t, cfg)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCA.certificate)
+	newClient := func(certificates ...tls.Certificate) *http.Client {
+		transport := &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certificates},
+			ForceAttemptHTTP2: true,
+		}
+		t.Cleanup(transport.CloseIdleConnections)
+		return &http.Client{Transport: transport}
+	}
+
+	if response, err := newClient().Get(url); err == nil {
+		response.Body.Close()
+		t.Error("GET without a client certificate succeeded, want a handshake failure")
+	}
+
+	strangerCA := newTestCA(t, "stranger ca")
+	if response, err := newClient(newTestClientCertificate(t, strangerCA).tlsCertificate(t)).Get(url); err == nil {
+		response.Body.Close()
+		t.Error("GET with a client certificate of another CA succeeded, want a handshake failure")
+	}
+
+	response, err := newClient(newTestClientCertificate(t, clientCA).tlsCertificate(t)).Get(url)
+	if err != nil {
+		t.Fatalf("GET with a client certificate error = %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK || response.ProtoMajor != 2 {
+		t.Errorf("GET = %d over %s, want 200 over HTTP/2", response.StatusCode, response.Proto)
+	}
+}
+
+func TestNewServer_H2C(t *testing.T) {
+	cfg := testServerConfig()
+	cfg.H2C = true
+	url := startTestServer(t, cfg)
+
+	for _, tt := range []struct {
+		name      string
+		protocols func(*http.Protocols)
+		wantMajor int
+	}{
+		{name: "HTTP/1.1", protocols: func(p *http.Protocols) { p.SetHTTP1(true) }, wantMajor: 1},
+		{name: "h2c", protocols: func(p *http.Protocols) { p.SetUnencryptedHTTP2(true) }, wantMajor: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &http.Transport{Protocols: new(http.Protocols)}
+			tt.protocols(transport.Protocols)
+			defer transport.CloseIdleConnections()
+
+			response, err := (&http.Client{Transport: transport}).Get(url)
+			if err != nil {
+				t.Fatalf("GET error = %v", err)
+			}
+			defer response.Body.Close()
+			if response.ProtoMajor != tt.wantMajor {
+				t.Errorf("GET over %s, want HTTP/%d", response.Proto, tt.wantMajor)
+			}
+		})
+	}
+}
+
+func testServerConfig() *config.Config {
+	cfg := config.Default()
+	cfg.ServerAddress = "127.0.0.1:0"
+	cfg.GCPProjectID = "forex"
+	return cfg
+}
@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// v2Group serves the v2 API, which wraps every response in a handlers.Envelope.
+func v2Group(mux *http.ServeMux) {
+	v2.handle(mux, handlers.V2LatestPath, handlers.GetLatestV2)
+	v2.handle(mux, handlers.V2SymbolsPath, handlers.GetSymbolsV2)
+	v2.handle(mux, handlers.V2CurrenciesPath, handlers.GetCurrenciesV2)
+	v2.handle(mux, "/v2/", notFound)
+}
@@ -0,0 +1,143 @@
+package routers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/kamaal111/forex-api/config"
+	"github.com/kamaal111/forex-api/docs"
+)
+
+// specValidation checks traffic against the OpenAPI spec. It's nil unless enabled with
+// validate_requests or validate_responses.
+var specValidation *specValidator
+
+// maxValidatedBodyBytes bounds how much of a response is buffered for validation. Larger
+// responses are sent as they are, without being validated.
+var maxValidatedBodyBytes = 4 << 20
+
+type specValidator struct {
+	validator *docs.Validator
+	// requests rejects requests with query parameters that don't match the spec.
+	requests bool
+	// responses turns responses that don't match the spec into errors. It buffers every
+	// response, so it's meant for tests and staging rather than production.
+	responses bool
+}
+
+func configureSpecValidation(cfg *config.Config) error {
+	if !cfg.ValidateRequests && !cfg.ValidateResponses {
+		return nil
+	}
+
+	validator, err := docs.NewValidator()
+	if err != nil {
+		return fmt.Errorf("failed to load the OpenAPI spec for validation: %w", err)
+	}
+	specValidation = &specValidator{validator: validator, requests: cfg.ValidateRequests, responses: cfg.ValidateResponses}
+	return nil
+}
+
+func validationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if specValidation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		specValidation.serve(w, r, next)
+	})
+}
+
+func (v *specValidator) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if v.requests {
+		err := v.validator.ValidateQuery(r)
+		if err != nil && !errors.Is(err, docs.ErrUndocumented) {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !v.responses || v.validator.Streams(r) {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	recorder := &responseRecorder{writer: w, header: http.Header{}}
+	next.ServeHTTP(recorder, r)
+	if recorder.passthrough {
+		log.Printf("response to %s %s is larger than %d bytes and was not validated", r.Method, r.URL.Path, maxValidatedBodyBytes)
+		return
+	}
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+
+	err := v.validator.ValidateResponse(r, recorder.status, recorder.header, recorder.body.Bytes())
+	if err != nil && !errors.Is(err, docs.ErrUndocumented) {
+		log.Printf("response to %s %s does not match the OpenAPI spec: %v", r.Method, r.URL.Path, err)
+		writeError(w, r, "Response does not match the OpenAPI spec", http.StatusInternalServerError)
+		return
+	}
+
+	recorder.send()
+}
+
+// responseRecorder buffers a response so it can be validated before it's sent. Once the body
+// outgrows maxValidatedBodyBytes, it sends what it buffered and passes the rest through.
+type responseRecorder struct {
+	writer      http.ResponseWriter
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	passthrough bool
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+}
+
+func (r *responseRecorder) Write(content []byte) (int, error) {
+	if r.passthrough {
+		return r.writer.Write(content)
+	}
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if r.body.Len()+len(content) <= maxValidatedBodyBytes {
+		return r.body.Write(content)
+	}
+
+	r.passthrough = true
+	r.send()
+	return r.writer.Write(content)
+}
+
+// send writes the buffered response to the underlying writer.
+func (r *responseRecorder) send() {
+	for key, values := range r.header {
+		r.writer.Header()[key] = values
+	}
+	r.writer.WriteHeader(r.status)
+	r.writer.Write(r.body.Bytes())
+	r.body.Reset()
+}
+
+// Flush leaves buffered responses alone, they're sent once validated.
+func (r *responseRecorder) Flush() {
+	if r.passthrough {
+		http.NewResponseController(r.writer).Flush()
+	}
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.writer
+}
@@ -0,0 +1,126 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kamaal111/forex-api/docs"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+func useSpecValidation(t *testing.T, requests bool, responses bool) {
+	t.Helper()
+
+	validator, err := docs.NewValidator()
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	original := specValidation
+	specValidation = &specValidator{validator: validator, requests: requests, responses: responses}
+	t.Cleanup(func() { specValidation = original })
+}
+
+func TestValidationMiddleware(t *testing.T) {
+	matching := func(w http.ResponseWriter, r *http.Request) {
+		utils.WriteJSON(w, http.StatusOK, map[string]any{"date": "2025-11-21", "symbols": []string{"EUR"}})
+	}
+	drifting := func(w http.ResponseWriter, r *http.Request) {
+		utils.WriteJSON(w, http.StatusOK, map[string]any{"date": 20251121})
+	}
+
+	tests := []struct {
+		name           string
+		requests       bool
+		responses      bool
+		target         string
+		handler        http.HandlerFunc
+		wantStatusCode int
+	}{
+		{name: "passes valid requests", requests: true, target: "/v1/rates/symbols?sort=code", handler: matching, wantStatusCode: http.StatusOK},
+		{name: "rejects invalid query parameters", requests: true, target: "/v1/rates/symbols?sort=size", handler: matching, wantStatusCode: http.StatusBadRequest},
+		{name: "rejects undocumented query parameters", requests: true, target: "/v1/rates/symbols?order=code", handler: matching, wantStatusCode: http.StatusBadRequest},
+		{name: "ignores undocumented paths", requests: true, responses: true, target: "/debug/vars?debug=1", handler: drifting, wantStatusCode: http.StatusOK},
+		{name: "passes matching responses", responses: true, target: "/v1/rates/symbols", handler: matching, wantStatusCode: http.StatusOK},
+		{name: "fails drifting responses", responses: true, target: "/v1/rates/symbols", handler: drifting, wantStatusCode: http.StatusInternalServerError},
+		{name: "leaves responses alone without response validation", requests: true, target: "/v1/rates/symbols", handler: drifting, wantStatusCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useSpecValidation(t, tt.requests, tt.responses)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			recorder := httptest.NewRecorder()
+
+			validationMiddleware(tt.handler).ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Errorf("validationMiddleware() status = %d, want %d: %s", recorder.Code, tt.wantStatusCode, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestValidationMiddleware_LargeResponses(t *testing.T) {
+	useSpecValidation(t, false, true)
+	original := maxValidatedBodyBytes
+	maxValidatedBodyBytes = 64
+	t.Cleanup(func() { maxValidatedBodyBytes = original })
+
+	body := strings.Repeat("x", 100)
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/symbols", nil)
+	recorder := httptest.NewRecorder()
+
+	validationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/plain")
+		w.Write([]byte(body[:50]))
+		w.Write([]byte(body[50:]))
+	})).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != body {
+		t.Errorf("validationMiddleware() = %d %q, want the response passed through unvalidated", recorder.Code, recorder.Body.String())
+	}
+	if got := recorder.Header().Get("content-type"); got != "text/plain" {
+		t.Errorf("validationMiddleware() content-type = %q, want %q", got, "text/plain")
+	}
+}
+
+func TestValidationMiddleware_Unwrap(t *testing.T) {
+	useSpecValidation(t, false, true)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/symbols", nil)
+	recorder := httptest.NewRecorder()
+
+	validationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok || unwrapper.Unwrap() != recorder {
+			t.Error("validationMiddleware() writer doesn't unwrap to the response writer")
+		}
+		http.NewResponseController(w).Flush()
+		utils.WriteJSON(w, http.StatusOK, map[string]any{"date": 20251121})
+	})).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("validationMiddleware() status = %d, want %d after flushing a drifting response", recorder.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestValidationMiddleware_Disabled(t *testing.T) {
+	original := specValidation
+	specValidation = nil
+	t.Cleanup(func() { specValidation = original })
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/symbols?sort=size", nil)
+	recorder := httptest.NewRecorder()
+
+	validationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("validationMiddleware() status = %d, want %d", recorder.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,74 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kamaal111/forex-api/config"
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+// apiVersion groups the routes of one major version of the API. The versions share the
+// handlers and their RatesService, they differ in the shape of their responses and in their
+// lifecycle.
+type apiVersion struct {
+	// writeError reports the errors raised by the middleware in the shape of the version.
+	writeError func(w http.ResponseWriter, message string, code int)
+	// deprecation is when the version was, or will be, deprecated. Zero while it isn't.
+	deprecation time.Time
+	// sunset is when the version is expected to stop being served. Zero while unplanned.
+	sunset time.Time
+	// deprecationLink points to documentation about the deprecation.
+	deprecationLink string
+}
+
+var (
+	v1 = &apiVersion{writeError: utils.ErrorHandler}
+	v2 = &apiVersion{writeError: handlers.EnvelopeErrorHandler}
+)
+
+type versionContextKey struct{}
+
+// configureVersions sets the lifecycle of v1, which config.Config.Validate has checked.
+func configureVersions(cfg *config.Config) {
+	v1.deprecation = cfg.V1Deprecation
+	v1.sunset = cfg.V1Sunset
+	v1.deprecationLink = cfg.V1DeprecationLink
+}
+
+func (v *apiVersion) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	mux.Handle(pattern, v.middleware(withMiddleware(handler)))
+}
+
+// middleware announces the lifecycle of the version and makes it available to the middleware
+// further down the chain.
+func (v *apiVersion) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		// Deprecation is defined by RFC 9745 and Sunset by RFC 8594.
+		if !v.deprecation.IsZero() {
+			header.Set("Deprecation", fmt.Sprintf("@%d", v.deprecation.Unix()))
+			if v.deprecationLink != "" {
+				header.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, v.deprecationLink))
+			}
+		}
+		if !v.sunset.IsZero() {
+			header.Set("Sunset", v.sunset.UTC().Format(http.TimeFormat))
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), versionContextKey{}, v)))
+	})
+}
+
+// writeError reports an error raised by the middleware in the shape of the version the request
+// was routed to, falling back to utils.ErrorHandler outside versioned routes.
+func writeError(w http.ResponseWriter, r *http.Request, message string, code int) {
+	if version, ok := r.Context().Value(versionContextKey{}).(*apiVersion); ok {
+		version.writeError(w, message, code)
+		return
+	}
+	utils.ErrorHandler(w, message, code)
+}
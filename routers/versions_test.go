@@ -0,0 +1,98 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+func TestAPIVersionMiddleware_LifecycleHeaders(t *testing.T) {
+	deprecation := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		version         *apiVersion
+		wantDeprecation string
+		wantSunset      string
+		wantLink        string
+	}{
+		{
+			name:    "current version",
+			version: &apiVersion{writeError: utils.ErrorHandler},
+		},
+		{
+			name:            "deprecated version",
+			version:         &apiVersion{writeError: utils.ErrorHandler, deprecation: deprecation},
+			wantDeprecation: "@1767225600",
+		},
+		{
+			name:            "version with a sunset",
+			version:         &apiVersion{writeError: utils.ErrorHandler, deprecation: deprecation, sunset: sunset, deprecationLink: "https://example.com/v1"},
+			wantDeprecation: "@1767225600",
+			wantSunset:      "Wed, 01 Jul 2026 00:00:00 GMT",
+			wantLink:        `<https://example.com/v1>; rel="deprecation"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler := tt.version.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, handlers.LatestPath, nil))
+
+			for header, want := range map[string]string{"Deprecation": tt.wantDeprecation, "Sunset": tt.wantSunset, "Link": tt.wantLink} {
+				if got := recorder.Header().Get(header); got != want {
+					t.Errorf("middleware() %s = %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIVersionMiddleware_ErrorShape(t *testing.T) {
+	panicking := func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}
+
+	tests := []struct {
+		name         string
+		version      *apiVersion
+		handler      http.HandlerFunc
+		wantStatus   int
+		wantEnvelope bool
+	}{
+		{name: "v1 not found", version: v1, handler: notFound, wantStatus: http.StatusNotFound},
+		{name: "v2 not found", version: v2, handler: notFound, wantStatus: http.StatusNotFound, wantEnvelope: true},
+		{name: "v1 panic", version: v1, handler: panicking, wantStatus: http.StatusInternalServerError},
+		{name: "v2 panic", version: v2, handler: panicking, wantStatus: http.StatusInternalServerError, wantEnvelope: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler := tt.version.middleware(recoveryMiddleware(tt.handler))
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v2/unknown", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode body %q: %v", recorder.Body.String(), err)
+			}
+			if _, ok := body["errors"]; ok != tt.wantEnvelope {
+				t.Errorf("body = %s, want an envelope: %v", recorder.Body.String(), tt.wantEnvelope)
+			}
+		})
+	}
+}
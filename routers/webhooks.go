@@ -0,0 +1,30 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/kamaal111/forex-api/handlers"
+	"github.com/kamaal111/forex-api/webhooks"
+)
+
+// webhooksGroup serves the webhook subscriptions stored in collection, read through client, and
+// starts dispatching the rate updates of the shared feed to them. Every replica dispatches; the
+// deliveries claimed in the store keep them from delivering twice.
+func webhooksGroup(mux *http.ServeMux, client *firestore.Client, collection string) {
+	store := webhooks.FirestoreStore{
+		Client:               client,
+		Collection:           collection,
+		DeadLetterCollection: collection + "_dead_letters",
+		ClaimCollection:      collection + "_deliveries",
+	}
+
+	dispatcher := webhooks.NewDispatcher(store)
+	go dispatcher.Run(context.Background(), handlers.Feed)
+
+	api := &webhooks.API{Dispatcher: dispatcher}
+	v1.handle(mux, handlers.WebhooksPath, api.HandleWebhooks)
+	v1.handle(mux, handlers.WebhookPath, api.DeleteWebhook)
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"cloud.google.com/go/firestore"
+
+	"github.com/kamaal111/forex-api/client"
 )
 
 const (
@@ -18,6 +20,8 @@ const (
 	pollInterval    = 100 * time.Millisecond
 	shutdownTimeout = 5 * time.Second
 	firestoreHost   = "127.0.0.1:8080"
+	// AdminToken is the token of the "integration" admin of the servers started by the tests.
+	AdminToken = "integration-admin-token"
 )
 
 type ServerProcess struct {
@@ -106,6 +110,10 @@ func StartServer(projectID string, port int) (*ServerProcess, error) {
 		fmt.Sprintf("GCP_PROJECT_ID=%s", projectID),
 		fmt.Sprintf("SERVER_ADDRESS=%s", serverAddress),
 		fmt.Sprintf("FIRESTORE_EMULATOR_HOST=%s", firestoreHost),
+		// Fail on any drift between the OpenAPI spec and the responses of the server.
+		"VALIDATE_REQUESTS=true",
+		"VALIDATE_RESPONSES=true",
+		"ADMIN_TOKENS=integration:"+AdminToken,
 	)
 
 	cmd.Stdout = nil
@@ -151,40 +159,22 @@ func (s *ServerProcess) BaseURL() string {
 	return s.baseURL
 }
 
-func (s *ServerProcess) GetSymbols() (*http.Response, error) {
-	url := fmt.Sprintf("%s/v1/rates/symbols", s.baseURL)
-	return http.Get(url)
-}
-
-func (s *ServerProcess) GetCurrencies() (*http.Response, error) {
-	url := fmt.Sprintf("%s/v1/currencies", s.baseURL)
-	return http.Get(url)
-}
-
-func (s *ServerProcess) GetLatest(base, symbols string) (*http.Response, error) {
-	url := fmt.Sprintf("%s/v1/rates/latest", s.baseURL)
-	if base != "" || symbols != "" {
-		url += "?"
-		if base != "" {
-			url += fmt.Sprintf("base=%s", base)
-		}
-		if symbols != "" {
-			if base != "" {
-				url += "&"
-			}
-			url += fmt.Sprintf("symbols=%s", symbols)
-		}
+// Client returns an API client for the server. Retries are disabled so failures surface
+// right away.
+func (s *ServerProcess) Client() *client.Client {
+	apiClient, err := client.New(s.baseURL, client.WithRetries(1, 0))
+	if err != nil {
+		panic(err)
 	}
-
-	return http.Get(url)
+	return apiClient
 }
 
 func waitForServer(baseURL string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
-	client := &http.Client{Timeout: pollInterval}
+	httpClient := &http.Client{Timeout: pollInterval}
 
 	for time.Now().Before(deadline) {
-		resp, err := client.Get(baseURL + "/v1/rates/latest")
+		resp, err := httpClient.Get(baseURL + "/healthz")
 		if err == nil {
 			resp.Body.Close()
 			return nil
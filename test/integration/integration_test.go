@@ -2,36 +2,14 @@ package integration
 
 import (
 	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"testing"
-)
-
-type ExchangeRateRecord struct {
-	Base  string             `json:"base"`
-	Date  string             `json:"date"`
-	Rates map[string]float64 `json:"rates"`
-}
-
-type SymbolsRecord struct {
-	Date    string   `json:"date"`
-	Symbols []string `json:"symbols"`
-}
-
-type ErrorResponse struct {
-	Message string `json:"message"`
-}
-
-type NamedSymbol struct {
-	Symbol string `json:"symbol"`
-	Name   string `json:"name"`
-	Sign   string `json:"sign"`
-}
 
-type CurrenciesRecord struct {
-	Date string        `json:"date"`
-	Data []NamedSymbol `json:"data"`
-}
+	"github.com/kamaal111/forex-api/admin"
+	"github.com/kamaal111/forex-api/client"
+	"github.com/kamaal111/forex-api/handlers"
+)
 
 func TestGetLatestEndpoint(t *testing.T) {
 	if testing.Short() {
@@ -49,14 +27,9 @@ func TestGetLatestEndpoint(t *testing.T) {
 			t.Fatalf("Failed to clear collection: %v", err)
 		}
 
-		resp, err := tc.Server.GetLatest("", "")
-		if err != nil {
-			t.Fatalf("Failed to make request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusNotFound {
-			t.Errorf("Expected status 404, got %d", resp.StatusCode)
+		_, err := tc.Server.Client().Latest(tc.Ctx, "")
+		if !client.IsNotFound(err) {
+			t.Errorf("Expected a 404 error, got %v", err)
 		}
 	})
 
@@ -78,21 +51,10 @@ func TestGetLatestEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed data: %v", err)
 		}
 
-		resp, err := tc.Server.GetLatest("", "")
+		record, err := tc.Server.Client().Latest(tc.Ctx, "")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record ExchangeRateRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if record.Base != "EUR" {
 			t.Errorf("Expected base EUR, got %s", record.Base)
@@ -123,21 +85,10 @@ func TestGetLatestEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed data: %v", err)
 		}
 
-		resp, err := tc.Server.GetLatest("USD", "")
+		record, err := tc.Server.Client().Latest(tc.Ctx, "USD")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record ExchangeRateRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if record.Base != "USD" {
 			t.Errorf("Expected base USD, got %s", record.Base)
@@ -163,21 +114,10 @@ func TestGetLatestEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed data: %v", err)
 		}
 
-		resp, err := tc.Server.GetLatest("EUR", "USD,GBP")
+		record, err := tc.Server.Client().Latest(tc.Ctx, "EUR", "USD", "GBP")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record ExchangeRateRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if len(record.Rates) != 2 {
 			t.Errorf("Expected 2 rates, got %d", len(record.Rates))
@@ -221,21 +161,10 @@ func TestGetLatestEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed new data: %v", err)
 		}
 
-		resp, err := tc.Server.GetLatest("EUR", "")
+		record, err := tc.Server.Client().Latest(tc.Ctx, "EUR")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record ExchangeRateRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if record.Date != "2025-11-21" {
 			t.Errorf("Expected latest date 2025-11-21, got %s", record.Date)
@@ -262,14 +191,9 @@ func TestGetSymbolsEndpoint(t *testing.T) {
 			t.Fatalf("Failed to clear collection: %v", err)
 		}
 
-		resp, err := tc.Server.GetSymbols()
-		if err != nil {
-			t.Fatalf("Failed to make request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusNotFound {
-			t.Errorf("Expected status 404, got %d", resp.StatusCode)
+		_, err := tc.Server.Client().Symbols(tc.Ctx, "")
+		if !client.IsNotFound(err) {
+			t.Errorf("Expected a 404 error, got %v", err)
 		}
 	})
 
@@ -298,21 +222,10 @@ func TestGetSymbolsEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed latest data: %v", err)
 		}
 
-		resp, err := tc.Server.GetSymbols()
+		record, err := tc.Server.Client().Symbols(tc.Ctx, "")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record SymbolsRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if record.Date != latestDate {
 			t.Errorf("Expected date %q, got %q", latestDate, record.Date)
@@ -349,14 +262,9 @@ func TestGetCurrenciesEndpoint(t *testing.T) {
 			t.Fatalf("Failed to clear collection: %v", err)
 		}
 
-		resp, err := tc.Server.GetCurrencies()
-		if err != nil {
-			t.Fatalf("Failed to make request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusNotFound {
-			t.Errorf("Expected status 404, got %d", resp.StatusCode)
+		_, err := tc.Server.Client().Currencies(tc.Ctx, "", "")
+		if !client.IsNotFound(err) {
+			t.Errorf("Expected a 404 error, got %v", err)
 		}
 	})
 
@@ -373,27 +281,16 @@ func TestGetCurrenciesEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed data: %v", err)
 		}
 
-		resp, err := tc.Server.GetCurrencies()
+		record, err := tc.Server.Client().Currencies(tc.Ctx, "", "")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record CurrenciesRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if record.Date != "2025-11-21" {
 			t.Errorf("Expected date %q, got %q", "2025-11-21", record.Date)
 		}
 
-		wantSymbols := []NamedSymbol{
+		wantSymbols := []client.NamedSymbol{
 			{Symbol: "EUR", Name: "Euro", Sign: "€"},
 			{Symbol: "USD", Name: "US Dollar", Sign: "$"},
 			{Symbol: "GBP", Name: "British Pound Sterling", Sign: "£"},
@@ -438,21 +335,10 @@ func TestGetCurrenciesEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed latest data: %v", err)
 		}
 
-		resp, err := tc.Server.GetCurrencies()
+		record, err := tc.Server.Client().Currencies(tc.Ctx, "", "")
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, string(body))
-		}
-
-		var record CurrenciesRecord
-		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
-			t.Fatalf("Failed to decode response: %v", err)
-		}
 
 		if record.Date != "2025-11-21" {
 			t.Errorf("Expected date %q, got %q", "2025-11-21", record.Date)
@@ -476,7 +362,7 @@ func TestGetCurrenciesEndpoint(t *testing.T) {
 			t.Fatalf("Failed to seed data: %v", err)
 		}
 
-		resp, err := tc.Server.GetCurrencies()
+		resp, err := http.Get(tc.Server.BaseURL() + handlers.CurrenciesPath)
 		if err != nil {
 			t.Fatalf("Failed to make request: %v", err)
 		}
@@ -511,7 +397,7 @@ func TestContentType(t *testing.T) {
 		t.Fatalf("Failed to seed data: %v", err)
 	}
 
-	resp, err := tc.Server.GetLatest("", "")
+	resp, err := http.Get(tc.Server.BaseURL() + handlers.LatestPath)
 	if err != nil {
 		t.Fatalf("Failed to make request: %v", err)
 	}
@@ -522,3 +408,102 @@ func TestContentType(t *testing.T) {
 		t.Errorf("Expected Content-Type application/json, got %s", contentType)
 	}
 }
+
+func TestAdminCorrections(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc := NewTestContext()
+	if err := tc.Setup(0); err != nil {
+		t.Fatalf("Failed to setup test context: %v", err)
+	}
+	defer tc.Teardown()
+
+	for _, collection := range []string{"exchange_rates", "symbols", "admin_audit"} {
+		if err := tc.ClearCollection(collection); err != nil {
+			t.Fatalf("Failed to clear collection: %v", err)
+		}
+	}
+	_, err := tc.DB.Collection("exchange_rates").Doc("EUR-2025-11-21").Set(tc.Ctx, map[string]any{
+		"base":  "EUR",
+		"date":  "2025-11-21",
+		"rates": map[string]float64{"USD": 10.8},
+	})
+	if err != nil {
+		t.Fatalf("Failed to seed data: %v", err)
+	}
+
+	correct := func(t *testing.T, method string, path string, body string) admin.AuditEntry {
+		t.Helper()
+		req, err := http.NewRequest(method, tc.Server.BaseURL()+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+AdminToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+
+		var entry admin.AuditEntry
+		if err := json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if entry.Actor != "integration" {
+			t.Errorf("Expected actor integration, got %q", entry.Actor)
+		}
+		return entry
+	}
+
+	t.Run("corrects the rates of a date", func(t *testing.T) {
+		entry := correct(t, http.MethodPut, "/v1/admin/rates/EUR/2025-11-21", `{"rates":{"USD":1.08,"GBP":0.87},"reason":"USD off by a factor 10"}`)
+		if entry.OldValue == nil || entry.NewValue == nil {
+			t.Errorf("Expected old and new values, got %+v", entry)
+		}
+
+		record, err := tc.Server.Client().Latest(tc.Ctx, "")
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		if record.Rates["USD"] != 1.08 || record.Rates["GBP"] != 0.87 {
+			t.Errorf("Expected the corrected rates, got %v", record.Rates)
+		}
+	})
+
+	t.Run("regenerates the symbols", func(t *testing.T) {
+		correct(t, http.MethodPost, handlers.AdminSymbolsPath, `{"reason":"GBP was missing"}`)
+
+		record, err := tc.Server.Client().Symbols(tc.Ctx, "")
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		if record.Date != "2025-11-21" || strings.Join(record.Symbols, ",") != "EUR,GBP,USD" {
+			t.Errorf("Expected the symbols of 2025-11-21, got %+v", record)
+		}
+	})
+
+	t.Run("deletes the rates of a date", func(t *testing.T) {
+		correct(t, http.MethodDelete, "/v1/admin/rates/EUR/2025-11-21", `{"reason":"published by mistake"}`)
+
+		_, err := tc.Server.Client().Latest(tc.Ctx, "")
+		if !client.IsNotFound(err) {
+			t.Errorf("Expected a 404 error, got %v", err)
+		}
+	})
+
+	t.Run("records every correction in the audit log", func(t *testing.T) {
+		documents, err := tc.DB.Collection("admin_audit").Documents(tc.Ctx).GetAll()
+		if err != nil {
+			t.Fatalf("Failed to read the audit log: %v", err)
+		}
+		if len(documents) != 3 {
+			t.Errorf("Expected 3 audit entries, got %d", len(documents))
+		}
+	})
+}
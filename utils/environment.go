@@ -1,19 +1,19 @@
 package utils
 
 import (
-	"log"
+	"fmt"
 	"os"
 	"strings"
 )
 
-func UnwrapEnvironment(keys ...string) string {
+// UnwrapEnvironment returns the value of the first of keys that is set and not empty.
+func UnwrapEnvironment(keys ...string) (string, error) {
 	for _, key := range keys {
 		value := os.Getenv(key)
 		if value != "" {
-			return value
+			return value, nil
 		}
 	}
 
-	log.Fatalf("%s not defined in environment\n", strings.Join(keys, ", "))
-	return "" // unreachable code
+	return "", fmt.Errorf("%s not defined in environment", strings.Join(keys, ", "))
 }
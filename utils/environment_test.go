@@ -19,8 +19,8 @@ func TestUnwrapEnvironment(t *testing.T) {
 		os.Setenv("TEST_ENV_VAR", "test_value")
 		defer os.Unsetenv("TEST_ENV_VAR")
 
-		got := UnwrapEnvironment("TEST_ENV_VAR")
-		if got != "test_value" {
+		got, err := UnwrapEnvironment("TEST_ENV_VAR")
+		if err != nil || got != "test_value" {
 			t.Errorf("UnwrapEnvironment() = %q, want %q", got, "test_value")
 		}
 	})
@@ -35,8 +35,8 @@ func TestUnwrapEnvironment(t *testing.T) {
 			os.Unsetenv("TEST_ENV_VAR_3")
 		}()
 
-		got := UnwrapEnvironment("TEST_ENV_VAR_1", "TEST_ENV_VAR_2", "TEST_ENV_VAR_3")
-		if got != "second_value" {
+		got, err := UnwrapEnvironment("TEST_ENV_VAR_1", "TEST_ENV_VAR_2", "TEST_ENV_VAR_3")
+		if err != nil || got != "second_value" {
 			t.Errorf("UnwrapEnvironment() = %q, want %q", got, "second_value")
 		}
 	})
@@ -49,13 +49,18 @@ func TestUnwrapEnvironment(t *testing.T) {
 			os.Unsetenv("TEST_SECOND")
 		}()
 
-		got := UnwrapEnvironment("TEST_FIRST", "TEST_SECOND")
-		if got != "first" {
+		got, err := UnwrapEnvironment("TEST_FIRST", "TEST_SECOND")
+		if err != nil || got != "first" {
 			t.Errorf("UnwrapEnvironment() = %q, want %q", got, "first")
 		}
 	})
-}
 
-// Note: Testing the fatal case (when no env var is set) would require
-// a subprocess approach since log.Fatalf calls os.Exit(1).
-// This is intentionally omitted as it would add complexity.
+	t.Run("returns an error when no variable is set", func(t *testing.T) {
+		os.Unsetenv("TEST_UNSET")
+
+		_, err := UnwrapEnvironment("TEST_UNSET")
+		if err == nil || err.Error() != "TEST_UNSET not defined in environment" {
+			t.Errorf("UnwrapEnvironment() error = %v, want a missing variable error", err)
+		}
+	})
+}
@@ -4,12 +4,12 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+
+	"github.com/kamaal111/forex-api/api"
 )
 
-type Error struct {
-	Message string `json:"message"`
-	Status  int    `json:"status"`
-}
+// Error is the body of every error response.
+type Error = api.Error
 
 func ErrorHandler(w http.ResponseWriter, message string, code int) {
 	errorResponse := Error{
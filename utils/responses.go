@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WriteJSON writes value as a JSON response with the given status code.
+func WriteJSON(writer http.ResponseWriter, status int, value any) {
+	output, err := json.Marshal(value)
+	if err != nil {
+		ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	writer.WriteHeader(status)
+	writer.Write(output)
+}
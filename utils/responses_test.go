@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	t.Run("writes value with status", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		WriteJSON(recorder, http.StatusCreated, map[string]string{"id": "abc"})
+
+		if recorder.Code != http.StatusCreated {
+			t.Errorf("WriteJSON() status = %d, want %d", recorder.Code, http.StatusCreated)
+		}
+		if contentType := recorder.Header().Get("content-type"); contentType != "application/json" {
+			t.Errorf("WriteJSON() content-type = %q, want %q", contentType, "application/json")
+		}
+		if body := recorder.Body.String(); body != `{"id":"abc"}` {
+			t.Errorf("WriteJSON() body = %q, want %q", body, `{"id":"abc"}`)
+		}
+	})
+
+	t.Run("fails on values that can't be encoded", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		WriteJSON(recorder, http.StatusOK, func() {})
+
+		if recorder.Code != http.StatusInternalServerError {
+			t.Errorf("WriteJSON() status = %d, want %d", recorder.Code, http.StatusInternalServerError)
+		}
+	})
+}
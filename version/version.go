@@ -0,0 +1,55 @@
+// Package version holds build metadata that is injected at build time through -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/kamaal111/forex-api/version.Commit=$(git rev-parse HEAD)"
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	// Commit is the VCS revision the binary was built from.
+	Commit = ""
+	// BuildTime is the moment the binary was built, preferably in RFC 3339 format. The toolchain
+	// doesn't record it, so it's only known when injected.
+	BuildTime = ""
+)
+
+type Info struct {
+	Commit string `json:"commit"`
+	// CommitTime is when Commit was made, as stamped by the Go toolchain.
+	CommitTime string `json:"commit_time"`
+	BuildTime  string `json:"build_time"`
+	GoVersion  string `json:"go_version"`
+}
+
+// Get returns the build metadata, falling back to the VCS information the Go toolchain
+// stamps into the binary for the commit when nothing was injected through -ldflags.
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				info.CommitTime = setting.Value
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.CommitTime == "" {
+		info.CommitTime = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
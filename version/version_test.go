@@ -0,0 +1,47 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	originalCommit, originalBuildTime := Commit, BuildTime
+	defer func() {
+		Commit, BuildTime = originalCommit, originalBuildTime
+	}()
+
+	t.Run("returns injected values", func(t *testing.T) {
+		Commit = "abc1234"
+		BuildTime = "2025-12-05T10:00:00Z"
+
+		info := Get()
+
+		if info.Commit != "abc1234" {
+			t.Errorf("Get().Commit = %q, want %q", info.Commit, "abc1234")
+		}
+		if info.BuildTime != "2025-12-05T10:00:00Z" {
+			t.Errorf("Get().BuildTime = %q, want %q", info.BuildTime, "2025-12-05T10:00:00Z")
+		}
+		if info.GoVersion != runtime.Version() {
+			t.Errorf("Get().GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+		}
+	})
+
+	t.Run("never returns empty values", func(t *testing.T) {
+		Commit = ""
+		BuildTime = ""
+
+		info := Get()
+
+		if info.Commit == "" {
+			t.Error("Get().Commit is empty")
+		}
+		if info.CommitTime == "" {
+			t.Error("Get().CommitTime is empty")
+		}
+		if info.BuildTime != "unknown" {
+			t.Errorf("Get().BuildTime = %q, want %q, the toolchain doesn't record it", info.BuildTime, "unknown")
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrForbiddenAddress = errors.New("url must not point to a loopback, private, link-local or unspecified address")
+	ErrUnresolvableHost = errors.New("url host can't be resolved")
+)
+
+// forbiddenAddress reports whether deliveries must not reach address, because it belongs to
+// the network the server runs in rather than to the receiver.
+func forbiddenAddress(address netip.Addr) bool {
+	address = address.Unmap()
+	return address.IsLoopback() || address.IsPrivate() || address.IsLinkLocalUnicast() ||
+		address.IsLinkLocalMulticast() || address.IsUnspecified()
+}
+
+// checkHost resolves the host of a webhook URL and rejects it when one of its addresses is
+// forbidden.
+func (d *Dispatcher) checkHost(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ErrInvalidURL
+	}
+
+	host := parsed.Hostname()
+	addresses, err := d.LookupIP(ctx, host)
+	if err != nil || len(addresses) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnresolvableHost, host)
+	}
+	for _, address := range addresses {
+		if forbiddenAddress(address) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// lookupIP resolves host with the default resolver. IP literals are returned as they are.
+func lookupIP(ctx context.Context, host string) ([]netip.Addr, error) {
+	if address, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{address}, nil
+	}
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+// newDeliveryClient returns a client that refuses to connect to forbidden addresses. The check
+// runs on the address being dialed, so a host that resolved to a public address when the
+// webhook was registered can't be pointed at the internal network later.
+func newDeliveryClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network string, address string, connection syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if forbiddenAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
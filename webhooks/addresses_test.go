@@ -0,0 +1,68 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestDispatcher_CheckHost(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		addresses []string
+		wantErr   error
+	}{
+		{name: "public address", url: "https://example.com/hook", addresses: []string{"93.184.215.14", "2606:2800:21f:cb07:6820:80da:af6b:8b2c"}},
+		{name: "loopback", url: "http://localhost:9000/hook", addresses: []string{"127.0.0.1"}, wantErr: ErrForbiddenAddress},
+		{name: "IPv6 loopback literal", url: "http://[::1]/hook", wantErr: ErrForbiddenAddress},
+		{name: "private", url: "http://intranet.example.com/hook", addresses: []string{"10.0.0.8"}, wantErr: ErrForbiddenAddress},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest", wantErr: ErrForbiddenAddress},
+		{name: "unspecified", url: "http://0.0.0.0/hook", wantErr: ErrForbiddenAddress},
+		{name: "IPv4 mapped private", url: "http://[::ffff:192.168.1.1]/hook", wantErr: ErrForbiddenAddress},
+		{name: "one private address among public ones", url: "https://example.com/hook", addresses: []string{"93.184.215.14", "192.168.1.1"}, wantErr: ErrForbiddenAddress},
+		{name: "unresolvable", url: "https://missing.example.com/hook", wantErr: ErrUnresolvableHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := NewDispatcher(NewMemoryStore())
+			dispatcher.LookupIP = func(ctx context.Context, host string) ([]netip.Addr, error) {
+				if address, err := netip.ParseAddr(host); err == nil {
+					return []netip.Addr{address}, nil
+				}
+				var addresses []netip.Addr
+				for _, address := range tt.addresses {
+					addresses = append(addresses, netip.MustParseAddr(address))
+				}
+				return addresses, nil
+			}
+
+			if err := dispatcher.checkHost(context.Background(), tt.url); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkHost() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDispatcher_DeliveryClientRefusesLoopback(t *testing.T) {
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	// The webhook passed the check when it was registered, but its host now resolves to
+	// loopback.
+	dispatcher := NewDispatcher(NewMemoryStore())
+	payload := &Payload{ID: "delivery", Event: EventRatesPublished, WebhookID: "hook"}
+
+	err := dispatcher.send(context.Background(), Webhook{ID: "hook", URL: server.URL, Secret: "secret"}, payload, []byte("{}"))
+
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("send() error = %v, want %v", err, ErrForbiddenAddress)
+	}
+	if len(target.payloads(t)) != 0 {
+		t.Error("send() reached the loopback receiver")
+	}
+}
@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ownerTokenSize is the number of random bytes in an owner token.
+const ownerTokenSize = 32
+
+// bearerToken returns the bearer token in the Authorization header of request, if any.
+func bearerToken(request *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(request.Header.Get("Authorization"), " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// ownerKey identifies the owner of token in the store. Only the SHA-256 of the token is
+// stored, so reading the store doesn't give access to the webhooks.
+func ownerKey(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
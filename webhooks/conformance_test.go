@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kamaal111/forex-api/docs"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// TestSpecConformance runs every documented webhook operation and fails when a response
+// doesn't match the spec.
+func TestSpecConformance(t *testing.T) {
+	validator, err := docs.NewValidator()
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	store := NewMemoryStore()
+	store.Create(context.Background(), &Webhook{ID: "hook", URL: "https://example.com/hook", Secret: "secret", Owner: ownerKey(testToken)})
+	store.Create(context.Background(), &Webhook{ID: "kept", URL: "https://example.com/kept", Secret: "secret", Owner: ownerKey(testToken)})
+	api := newTestAPI(store)
+	mux := http.NewServeMux()
+	mux.HandleFunc(handlers.WebhooksPath, api.HandleWebhooks)
+	mux.HandleFunc(handlers.WebhookPath, api.DeleteWebhook)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		token  string
+	}{
+		{name: "create", method: http.MethodPost, target: handlers.WebhooksPath, body: `{"url":"https://example.com/hook","threshold":{"symbol":"USD","value":1.1}}`},
+		{name: "create with an invalid URL", method: http.MethodPost, target: handlers.WebhooksPath, body: `{"url":"example.com"}`},
+		{name: "create for an unknown owner", method: http.MethodPost, target: handlers.WebhooksPath, body: `{"url":"https://example.com/hook"}`, token: "unknown"},
+		{name: "list", method: http.MethodGet, target: handlers.WebhooksPath, token: testToken},
+		{name: "list without a token", method: http.MethodGet, target: handlers.WebhooksPath},
+		{name: "delete", method: http.MethodDelete, target: "/v1/webhooks/hook", token: testToken},
+		{name: "delete an unknown webhook", method: http.MethodDelete, target: "/v1/webhooks/unknown", token: testToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, req)
+
+			if err := validator.ValidateResponse(req, recorder.Code, recorder.Header(), recorder.Body.Bytes()); err != nil {
+				t.Errorf("ValidateResponse() for status %d error = %v", recorder.Code, err)
+			}
+		})
+	}
+}
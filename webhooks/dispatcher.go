@@ -0,0 +1,307 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// maxDrainBytes is the most of a delivery response body read before closing it. Longer bodies
+// cost their connection rather than the time to read them.
+const maxDrainBytes = 64 << 10
+
+// Dispatcher delivers webhooks for the updates of a rate feed. Its client refuses to connect to
+// loopback, private, link-local and unspecified addresses.
+type Dispatcher struct {
+	Store  Store
+	Client *http.Client
+	// MaxAttempts is how many times a delivery is tried before it goes to the dead-letter log.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles on every further retry.
+	Backoff time.Duration
+	// RefreshInterval is how often the watched bases are reloaded from the store, to pick up
+	// webhooks registered by other instances.
+	RefreshInterval time.Duration
+	Now             func() time.Time
+	// LookupIP resolves the hosts of webhooks when they are registered.
+	LookupIP func(ctx context.Context, host string) ([]netip.Addr, error)
+
+	refresh chan struct{}
+}
+
+func NewDispatcher(store Store) *Dispatcher {
+	return &Dispatcher{
+		Store:           store,
+		Client:          newDeliveryClient(10 * time.Second),
+		MaxAttempts:     5,
+		Backoff:         2 * time.Second,
+		RefreshInterval: 5 * time.Minute,
+		Now:             time.Now,
+		LookupIP:        lookupIP,
+		refresh:         make(chan struct{}, 1),
+	}
+}
+
+// Refresh makes a running dispatcher reload the watched bases, after webhooks were added or
+// removed.
+func (d *Dispatcher) Refresh() {
+	select {
+	case d.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// Run subscribes to the bases of the registered webhooks on feed and dispatches their updates
+// until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, feed *handlers.RateFeed) {
+	ticker := time.NewTicker(d.RefreshInterval)
+	defer ticker.Stop()
+
+	var subscription *handlers.FeedSubscription
+	var updates <-chan handlers.RateUpdate
+	var watched []string
+	defer func() {
+		if subscription != nil {
+			subscription.Close()
+		}
+	}()
+
+	resubscribe := func() {
+		bases, err := d.watchedBases(ctx)
+		if err != nil {
+			log.Printf("webhooks: failed to load webhooks: %v", err)
+			return
+		}
+		if slices.Equal(bases, watched) {
+			return
+		}
+
+		// Subscribe before closing the previous subscription, so the feed keeps running and
+		// remembers the dates it has seen.
+		var next *handlers.FeedSubscription
+		if len(bases) > 0 {
+			next = feed.Subscribe(bases...)
+		}
+		if subscription != nil {
+			subscription.Close()
+		}
+		subscription, watched, updates = next, bases, nil
+		if next != nil {
+			updates = next.Updates()
+		}
+	}
+	resubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			resubscribe()
+		case <-d.refresh:
+			resubscribe()
+		case update, ok := <-updates:
+			if !ok {
+				updates = nil
+				continue
+			}
+			go d.Dispatch(ctx, update)
+		}
+	}
+}
+
+func (d *Dispatcher) watchedBases(ctx context.Context) ([]string, error) {
+	webhooks, err := d.Store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var bases []string
+	for _, webhook := range webhooks {
+		if !slices.Contains(bases, webhook.Base) {
+			bases = append(bases, webhook.Base)
+		}
+	}
+	slices.Sort(bases)
+	return bases, nil
+}
+
+// Dispatch delivers an update to every webhook it concerns and waits for the deliveries to
+// finish. Every instance of the server sees the same updates, so each delivery is claimed in the
+// store first and only the instance that claims it delivers it. The claims also keep a restart
+// from delivering the rates it sees first again, so a publication made while no instance was
+// running is still delivered once.
+func (d *Dispatcher) Dispatch(ctx context.Context, update handlers.RateUpdate) {
+	webhooks, err := d.Store.List(ctx)
+	if err != nil {
+		log.Printf("webhooks: failed to load webhooks for %s rates of %s: %v", update.Record.Base, update.Record.Date, err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, webhook := range webhooks {
+		payload := d.payload(webhook, update)
+		if payload == nil {
+			continue
+		}
+		claimed, err := d.Store.Claim(ctx, payload.ID)
+		if err != nil {
+			// A duplicate, which receivers can spot by its id, beats a lost delivery.
+			log.Printf("webhooks: failed to claim delivery %s, delivering anyway: %v", payload.ID, err)
+		} else if !claimed {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Deliver(ctx, webhook, payload)
+		}()
+	}
+	wg.Wait()
+}
+
+// payload builds what to send to a webhook for an update, or nil when the webhook isn't
+// concerned by it.
+func (d *Dispatcher) payload(webhook Webhook, update handlers.RateUpdate) *Payload {
+	if webhook.Base != update.Record.Base {
+		return nil
+	}
+	if update.Previous == nil {
+		// The first rates the feed sees may have been published long before, so they only go to
+		// webhooks registered by their day. A crossing needs the rates it moved from.
+		if webhook.CreatedAt.UTC().Format(time.DateOnly) > update.Record.Date || webhook.Threshold != nil {
+			return nil
+		}
+	}
+
+	payload := &Payload{
+		ID:        deliveryID(webhook, update.Record),
+		Event:     webhook.Event(),
+		WebhookID: webhook.ID,
+		CreatedAt: d.Now().UTC(),
+		Rates:     update.Record,
+	}
+
+	if webhook.Threshold != nil {
+		payload.Crossing = crossing(&webhook, update.Previous, update.Record)
+		if payload.Crossing == nil {
+			return nil
+		}
+	}
+
+	symbols := webhook.Symbols
+	if webhook.Threshold != nil {
+		symbols = []string{webhook.Threshold.Symbol}
+	}
+	if len(symbols) > 0 {
+		filtered := *update.Record
+		filtered.Rates = map[string]float64{}
+		for _, symbol := range symbols {
+			if rate, ok := update.Record.Rates[symbol]; ok {
+				filtered.Rates[symbol] = rate
+			}
+		}
+		payload.Rates = &filtered
+	}
+	return payload
+}
+
+// deliveryID identifies the delivery of record to webhook. It is derived rather than random, so
+// every instance dispatching the same update claims the same delivery.
+func deliveryID(webhook Webhook, record *handlers.ExchangeRateRecord) string {
+	digest := sha256.Sum256([]byte(webhook.ID + "/" + record.Base + "/" + record.Date))
+	return hex.EncodeToString(digest[:16])
+}
+
+// Deliver posts a payload to a webhook, retrying with exponential backoff. It records a dead
+// letter when every attempt fails and reports whether the delivery succeeded.
+func (d *Dispatcher) Deliver(ctx context.Context, webhook Webhook, payload *Payload) bool {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("webhooks: failed to encode delivery %s: %v", payload.ID, err)
+		return false
+	}
+
+	backoff := d.Backoff
+	attempts := 0
+	for {
+		attempts++
+		err = d.send(ctx, webhook, payload, body)
+		if err == nil {
+			return true
+		}
+		if attempts >= d.MaxAttempts || !wait(ctx, backoff) {
+			break
+		}
+		backoff *= 2
+	}
+
+	log.Printf("webhooks: giving up on delivery %s to %s: %v", payload.ID, webhook.URL, err)
+	letter := DeadLetter{
+		WebhookID: webhook.ID,
+		URL:       webhook.URL,
+		Payload:   string(body),
+		Attempts:  attempts,
+		Error:     err.Error(),
+		FailedAt:  d.Now().UTC(),
+	}
+	if err := d.Store.AddDeadLetter(context.WithoutCancel(ctx), letter); err != nil {
+		log.Printf("webhooks: failed to record dead letter for delivery %s: %v", payload.ID, err)
+	}
+	return false
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook Webhook, payload *Payload, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := d.Now()
+	request.Header.Set("content-type", "application/json")
+	request.Header.Set(EventHeader, payload.Event)
+	request.Header.Set(DeliveryHeader, payload.ID)
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	request.Header.Set(SignatureHeader, api.Sign(webhook.Secret, timestamp, body))
+
+	response, err := d.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// Reading what's left of the body lets the connection be reused for the next delivery.
+	defer io.Copy(io.Discard, io.LimitReader(response.Body, maxDrainBytes))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// wait sleeps for duration and reports whether it did so without ctx being done first.
+func wait(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
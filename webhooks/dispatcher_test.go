@@ -0,0 +1,220 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+// receiver records the deliveries posted to it and fails the first failures of them.
+type receiver struct {
+	mu         sync.Mutex
+	failures   int
+	deliveries []*http.Request
+	bodies     [][]byte
+}
+
+func (r *receiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, request)
+	r.bodies = append(r.bodies, body)
+
+	if r.failures > 0 {
+		r.failures--
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (r *receiver) payloads(t *testing.T) []Payload {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payloads := make([]Payload, 0, len(r.bodies))
+	for _, body := range r.bodies {
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("failed to decode delivery: %v", err)
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads
+}
+
+func newTestDispatcher(store Store) *Dispatcher {
+	dispatcher := NewDispatcher(store)
+	// The test receivers listen on loopback, which the delivery client refuses.
+	dispatcher.Client = &http.Client{Timeout: time.Second}
+	dispatcher.Backoff = time.Millisecond
+	dispatcher.MaxAttempts = 3
+	return dispatcher
+}
+
+func rateUpdate(previousUSD float64, currentUSD float64) handlers.RateUpdate {
+	return handlers.RateUpdate{
+		Previous: &handlers.ExchangeRateRecord{Base: "EUR", Date: "2025-12-04", Rates: map[string]float64{"USD": previousUSD, "GBP": 0.87}},
+		Record:   &handlers.ExchangeRateRecord{Base: "EUR", Date: "2025-12-05", Rates: map[string]float64{"USD": currentUSD, "GBP": 0.88}},
+	}
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	target := &receiver{failures: 1}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	store := NewMemoryStore()
+	dispatcher := newTestDispatcher(store)
+	webhook := Webhook{ID: "hook", URL: server.URL, Secret: "secret"}
+	payload := &Payload{ID: "delivery", Event: EventRatesPublished, WebhookID: "hook"}
+
+	if !dispatcher.Deliver(context.Background(), webhook, payload) {
+		t.Fatal("Deliver() = false, want true after a retry")
+	}
+
+	if len(target.deliveries) != 2 {
+		t.Fatalf("Deliver() attempts = %d, want 2", len(target.deliveries))
+	}
+	for i, request := range target.deliveries {
+		seconds, err := strconv.ParseInt(request.Header.Get(TimestampHeader), 10, 64)
+		if err != nil {
+			t.Fatalf("delivery %d timestamp = %q", i, request.Header.Get(TimestampHeader))
+		}
+		want := api.Sign("secret", time.Unix(seconds, 0), target.bodies[i])
+		if signature := request.Header.Get(SignatureHeader); signature != want {
+			t.Errorf("delivery %d signature = %q, want %q", i, signature, want)
+		}
+		if request.Header.Get(DeliveryHeader) != "delivery" || request.Header.Get(EventHeader) != EventRatesPublished {
+			t.Errorf("delivery %d headers = %v", i, request.Header)
+		}
+	}
+	if letters := store.DeadLetters(); len(letters) != 0 {
+		t.Errorf("Deliver() recorded dead letters %+v, want none", letters)
+	}
+}
+
+func TestDispatcher_Deliver_DeadLetter(t *testing.T) {
+	target := &receiver{failures: 10}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	store := NewMemoryStore()
+	dispatcher := newTestDispatcher(store)
+	webhook := Webhook{ID: "hook", URL: server.URL, Secret: "secret"}
+
+	if dispatcher.Deliver(context.Background(), webhook, &Payload{ID: "delivery"}) {
+		t.Fatal("Deliver() = true, want false")
+	}
+
+	if len(target.deliveries) != dispatcher.MaxAttempts {
+		t.Errorf("Deliver() attempts = %d, want %d", len(target.deliveries), dispatcher.MaxAttempts)
+	}
+	letters := store.DeadLetters()
+	if len(letters) != 1 {
+		t.Fatalf("Deliver() recorded %d dead letters, want 1", len(letters))
+	}
+	if letters[0].WebhookID != "hook" || letters[0].Attempts != dispatcher.MaxAttempts || letters[0].Error == "" {
+		t.Errorf("dead letter = %+v", letters[0])
+	}
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	for _, webhook := range []Webhook{
+		{ID: "published", URL: server.URL, Base: "EUR", Symbols: []string{"GBP"}},
+		{ID: "crossed", URL: server.URL, Base: "EUR", Threshold: &Threshold{Symbol: "USD", Value: 1.1}},
+		{ID: "not-crossed", URL: server.URL, Base: "EUR", Threshold: &Threshold{Symbol: "USD", Value: 1.2}},
+		{ID: "other-base", URL: server.URL, Base: "USD"},
+	} {
+		store.Create(ctx, &webhook)
+	}
+
+	newTestDispatcher(store).Dispatch(ctx, rateUpdate(1.09, 1.11))
+
+	payloads := target.payloads(t)
+	if len(payloads) != 2 {
+		t.Fatalf("Dispatch() delivered %d payloads, want 2", len(payloads))
+	}
+	for _, payload := range payloads {
+		switch payload.WebhookID {
+		case "published":
+			if payload.Event != EventRatesPublished || len(payload.Rates.Rates) != 1 || payload.Rates.Rates["GBP"] != 0.88 {
+				t.Errorf("published payload = %+v, want the new GBP rate", payload)
+			}
+		case "crossed":
+			if payload.Event != EventThresholdCrossed || payload.Crossing == nil || payload.Crossing.Direction != DirectionUp {
+				t.Errorf("crossed payload = %+v, want an upward crossing", payload)
+			}
+		default:
+			t.Errorf("Dispatch() delivered to %q", payload.WebhookID)
+		}
+	}
+}
+
+func TestDispatcher_Dispatch_FirstSighting(t *testing.T) {
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	for _, webhook := range []Webhook{
+		{ID: "registered-before", URL: server.URL, Base: "EUR", CreatedAt: time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)},
+		{ID: "registered-after", URL: server.URL, Base: "EUR", CreatedAt: time.Date(2025, 12, 6, 9, 0, 0, 0, time.UTC)},
+		{ID: "threshold", URL: server.URL, Base: "EUR", Threshold: &Threshold{Symbol: "USD", Value: 1.1}},
+	} {
+		store.Create(ctx, &webhook)
+	}
+
+	update := rateUpdate(1.09, 1.11)
+	update.Previous = nil
+	// The server restarts and sees the same rates first again.
+	newTestDispatcher(store).Dispatch(ctx, update)
+	newTestDispatcher(store).Dispatch(ctx, update)
+
+	payloads := target.payloads(t)
+	if len(payloads) != 1 || payloads[0].WebhookID != "registered-before" {
+		t.Errorf("Dispatch() of the first sighting delivered %+v, want one delivery to registered-before", payloads)
+	}
+}
+
+func TestDispatcher_Dispatch_OncePerInstanceGroup(t *testing.T) {
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	store := NewMemoryStore()
+	store.Create(context.Background(), &Webhook{ID: "published", URL: server.URL, Base: "EUR"})
+
+	// Two replicas of the server see the same update.
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			newTestDispatcher(store).Dispatch(context.Background(), rateUpdate(1.09, 1.11))
+		})
+	}
+	wg.Wait()
+
+	if payloads := target.payloads(t); len(payloads) != 1 {
+		t.Errorf("Dispatch() by two instances delivered %d payloads, want 1", len(payloads))
+	}
+}
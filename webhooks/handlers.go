@@ -0,0 +1,195 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/utils"
+)
+
+type (
+	Registration   = api.Registration
+	WebhooksRecord = api.WebhooksRecord
+)
+
+const maxRegistrationBytes = 64 << 10
+
+// API serves the webhook subscription endpoints.
+type API struct {
+	Dispatcher *Dispatcher
+}
+
+// HandleWebhooks routes requests to the webhook collection by method.
+func (a *API) HandleWebhooks(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		a.ListWebhooks(writer, request)
+	case http.MethodPost:
+		a.CreateWebhook(writer, request)
+	default:
+		writer.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPost}, ", "))
+		utils.ErrorHandler(writer, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// CreateWebhook handles requests to register a webhook.
+//
+// @Summary      Register a webhook
+// @Description  Registers a URL to receive a signed POST when rates with a newer date are published for a base, or, with a threshold, when a rate crosses a value.
+// @Description  Deliveries carry an X-Webhook-Signature header with the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body, keyed with the secret returned here.
+// @Description  The URL must not resolve to a loopback, private, link-local or unspecified address.
+// @Description  Without an Authorization header the webhook gets a new owner, whose token is returned here and nowhere else. Send it as a bearer token to add more webhooks to the same owner, and to list and delete them.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      api.Registration  true  "Webhook to register"
+// @Success      201      {object}  api.Webhook
+// @Failure      400      {object}  api.Error
+// @Failure      401      {object}  api.Error
+// @Failure      500      {object}  api.Error
+// @Router       /v1/webhooks [post]
+func (a *API) CreateWebhook(writer http.ResponseWriter, request *http.Request) {
+	var token, owner string
+	if _, ok := bearerToken(request); ok {
+		owner, ok = a.authenticate(writer, request)
+		if !ok {
+			return
+		}
+	} else {
+		token = randomHex(ownerTokenSize)
+		owner = ownerKey(token)
+	}
+
+	var registration Registration
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxRegistrationBytes))
+	if err := decoder.Decode(&registration); err != nil {
+		utils.ErrorHandler(writer, "body must be a JSON webhook registration", http.StatusBadRequest)
+		return
+	}
+
+	webhook := &Webhook{
+		URL:       registration.URL,
+		Base:      registration.Base,
+		Symbols:   registration.Symbols,
+		Threshold: registration.Threshold,
+		Secret:    registration.Secret,
+		Owner:     owner,
+		CreatedAt: a.Dispatcher.Now().UTC(),
+	}
+	if err := normalize(webhook); err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := a.Dispatcher.checkHost(request.Context(), webhook.URL); err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := a.Dispatcher.Store.Create(request.Context(), webhook); err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.Dispatcher.Refresh()
+
+	webhook.Token = token
+	utils.WriteJSON(writer, http.StatusCreated, webhook)
+}
+
+// ListWebhooks handles requests for the registered webhooks.
+//
+// @Summary      List webhooks
+// @Description  Lists the webhooks of the owner of the token, oldest first. Secrets are left out.
+// @Tags         webhooks
+// @Produce      json
+// @Security     WebhookToken
+// @Success      200  {object}  api.WebhooksRecord
+// @Failure      401  {object}  api.Error
+// @Failure      500  {object}  api.Error
+// @Router       /v1/webhooks [get]
+func (a *API) ListWebhooks(writer http.ResponseWriter, request *http.Request) {
+	owner, ok := a.authenticate(writer, request)
+	if !ok {
+		return
+	}
+
+	webhooks, err := a.Dispatcher.Store.ListOwned(request.Context(), owner)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	record := WebhooksRecord{Webhooks: make([]Webhook, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+		record.Webhooks = append(record.Webhooks, webhook)
+	}
+	utils.WriteJSON(writer, http.StatusOK, record)
+}
+
+// DeleteWebhook handles requests to remove a webhook.
+//
+// @Summary      Delete a webhook
+// @Description  Removes a webhook of the owner of the token, it receives no further deliveries.
+// @Tags         webhooks
+// @Security     WebhookToken
+// @Param        id   path  string  true  "Webhook id"
+// @Success      204
+// @Failure      401  {object}  api.Error
+// @Failure      404  {object}  api.Error
+// @Failure      405  {object}  api.Error
+// @Failure      500  {object}  api.Error
+// @Router       /v1/webhooks/{id} [delete]
+func (a *API) DeleteWebhook(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodDelete {
+		writer.Header().Set("Allow", http.MethodDelete)
+		utils.ErrorHandler(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	owner, ok := a.authenticate(writer, request)
+	if !ok {
+		return
+	}
+
+	err := a.Dispatcher.Store.Delete(request.Context(), request.PathValue("id"), owner)
+	if errors.Is(err, ErrNotFound) {
+		utils.ErrorHandler(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.Dispatcher.Refresh()
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate returns the owner of the bearer token of request, or answers 401 and returns
+// false. A token is known while its owner has at least one webhook.
+func (a *API) authenticate(writer http.ResponseWriter, request *http.Request) (string, bool) {
+	token, ok := bearerToken(request)
+	if !ok {
+		unauthorized(writer)
+		return "", false
+	}
+
+	owner := ownerKey(token)
+	webhooks, err := a.Dispatcher.Store.ListOwned(request.Context(), owner)
+	if err != nil {
+		utils.ErrorHandler(writer, err.Error(), http.StatusInternalServerError)
+		return "", false
+	}
+	if len(webhooks) == 0 {
+		unauthorized(writer)
+		return "", false
+	}
+	return owner, true
+}
+
+func unauthorized(writer http.ResponseWriter) {
+	writer.Header().Set("WWW-Authenticate", `Bearer realm="forex-api webhooks"`)
+	utils.ErrorHandler(writer, "a valid webhook token is required", http.StatusUnauthorized)
+}
@@ -0,0 +1,201 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+const testToken = "owner-token-0123456789"
+
+// newTestAPI returns an API resolving every host name to a public address, as example.com does.
+func newTestAPI(store Store) *API {
+	dispatcher := NewDispatcher(store)
+	dispatcher.LookupIP = func(ctx context.Context, host string) ([]netip.Addr, error) {
+		if address, err := netip.ParseAddr(host); err == nil {
+			return []netip.Addr{address}, nil
+		}
+		return []netip.Addr{netip.MustParseAddr("93.184.215.14")}, nil
+	}
+	return &API{Dispatcher: dispatcher}
+}
+
+func TestCreateWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+	}{
+		{name: "registers a webhook", body: `{"url":"https://example.com/hook","base":"usd"}`, wantStatusCode: http.StatusCreated},
+		{name: "registers a threshold", body: `{"url":"https://example.com/hook","threshold":{"symbol":"USD","value":1.1}}`, wantStatusCode: http.StatusCreated},
+		{name: "rejects malformed body", body: `[]`, wantStatusCode: http.StatusBadRequest},
+		{name: "rejects invalid URL", body: `{"url":"example.com"}`, wantStatusCode: http.StatusBadRequest},
+		{name: "rejects a loopback address", body: `{"url":"http://127.0.0.1:9000/hook"}`, wantStatusCode: http.StatusBadRequest},
+		{name: "rejects invalid threshold", body: `{"url":"https://example.com/hook","threshold":{"symbol":"XYZ","value":1}}`, wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			api := newTestAPI(store)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/webhooks", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+
+			api.HandleWebhooks(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("CreateWebhook() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusCreated {
+				return
+			}
+
+			var webhook Webhook
+			if err := json.Unmarshal(recorder.Body.Bytes(), &webhook); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if webhook.ID == "" || webhook.Secret == "" || webhook.Token == "" {
+				t.Errorf("CreateWebhook() = %+v, want an id, a secret and a token", webhook)
+			}
+			stored, _ := store.List(context.Background())
+			if len(stored) != 1 || stored[0].ID != webhook.ID || stored[0].Owner != ownerKey(webhook.Token) {
+				t.Errorf("CreateWebhook() stored %+v", stored)
+			}
+		})
+	}
+}
+
+func TestCreateWebhookHandler_ExistingOwner(t *testing.T) {
+	store := NewMemoryStore()
+	store.Create(context.Background(), &Webhook{ID: "hook", URL: "https://example.com/hook", Owner: ownerKey(testToken)})
+	api := newTestAPI(store)
+
+	tests := []struct {
+		name           string
+		token          string
+		wantStatusCode int
+	}{
+		{name: "adds to the owner", token: "Bearer " + testToken, wantStatusCode: http.StatusCreated},
+		{name: "rejects an unknown token", token: "Bearer unknown", wantStatusCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/webhooks", strings.NewReader(`{"url":"https://example.com/other"}`))
+			req.Header.Set("Authorization", tt.token)
+			recorder := httptest.NewRecorder()
+
+			api.HandleWebhooks(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Fatalf("CreateWebhook() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusCreated {
+				return
+			}
+			if strings.Contains(recorder.Body.String(), `"token"`) {
+				t.Errorf("CreateWebhook() = %s, want no new token for an existing owner", recorder.Body)
+			}
+			if owned, _ := store.ListOwned(context.Background(), ownerKey(testToken)); len(owned) != 2 {
+				t.Errorf("CreateWebhook() left the owner with %d webhooks, want 2", len(owned))
+			}
+		})
+	}
+}
+
+func TestListWebhooksHandler(t *testing.T) {
+	store := NewMemoryStore()
+	store.Create(context.Background(), &Webhook{ID: "hook", URL: "https://example.com/hook", Base: "EUR", Secret: "secret", Owner: ownerKey(testToken)})
+	store.Create(context.Background(), &Webhook{ID: "other", URL: "https://example.com/other", Base: "EUR", Owner: ownerKey("someone else")})
+	api := newTestAPI(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	recorder := httptest.NewRecorder()
+
+	api.HandleWebhooks(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("ListWebhooks() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if strings.Contains(recorder.Body.String(), "secret") {
+		t.Errorf("ListWebhooks() = %s, want secrets left out", recorder.Body.String())
+	}
+
+	var record WebhooksRecord
+	if err := json.Unmarshal(recorder.Body.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(record.Webhooks) != 1 || record.Webhooks[0].ID != "hook" {
+		t.Errorf("ListWebhooks() = %+v, want only the webhook of the owner", record)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+	recorder = httptest.NewRecorder()
+	api.HandleWebhooks(recorder, req)
+	if recorder.Code != http.StatusUnauthorized || recorder.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("ListWebhooks() without a token status = %d, want %d with a challenge", recorder.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestHandleWebhooks_MethodNotAllowed(t *testing.T) {
+	api := newTestAPI(NewMemoryStore())
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/webhooks", nil)
+	recorder := httptest.NewRecorder()
+
+	api.HandleWebhooks(recorder, req)
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("HandleWebhooks() status = %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := recorder.Header().Get("Allow"); allow != "GET, POST" {
+		t.Errorf("HandleWebhooks() Allow = %q, want %q", allow, "GET, POST")
+	}
+}
+
+func TestDeleteWebhookHandler(t *testing.T) {
+	store := NewMemoryStore()
+	store.Create(context.Background(), &Webhook{ID: "hook", URL: "https://example.com/hook", Owner: ownerKey(testToken)})
+	store.Create(context.Background(), &Webhook{ID: "kept", URL: "https://example.com/kept", Owner: ownerKey(testToken)})
+	store.Create(context.Background(), &Webhook{ID: "other", URL: "https://example.com/other", Owner: ownerKey("someone else")})
+	api := newTestAPI(store)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/webhooks/{id}", api.DeleteWebhook)
+
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		token          string
+		wantStatusCode int
+	}{
+		{name: "rejects a missing token", method: http.MethodDelete, id: "hook", wantStatusCode: http.StatusUnauthorized},
+		{name: "deletes the webhook", method: http.MethodDelete, id: "hook", token: testToken, wantStatusCode: http.StatusNoContent},
+		{name: "already deleted", method: http.MethodDelete, id: "hook", token: testToken, wantStatusCode: http.StatusNotFound},
+		{name: "webhook of another owner", method: http.MethodDelete, id: "other", token: testToken, wantStatusCode: http.StatusNotFound},
+		{name: "rejects other methods", method: http.MethodGet, id: "hook", token: testToken, wantStatusCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/v1/webhooks/"+tt.id, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatusCode {
+				t.Errorf("DeleteWebhook() status = %d, want %d", recorder.Code, tt.wantStatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// claimRetention is how long a delivery claim must be kept: long enough for every instance to
+// have seen the update it was claimed for.
+const claimRetention = 7 * 24 * time.Hour
+
+var ErrNotFound = errors.New("webhook not found")
+
+// DeadLetter records a delivery that failed on every attempt.
+type DeadLetter struct {
+	WebhookID string    `json:"webhook_id" firestore:"webhook_id"`
+	URL       string    `json:"url" firestore:"url"`
+	Payload   string    `json:"payload" firestore:"payload"`
+	Attempts  int       `json:"attempts" firestore:"attempts"`
+	Error     string    `json:"error" firestore:"error"`
+	FailedAt  time.Time `json:"failed_at" firestore:"failed_at"`
+}
+
+// Store persists webhook subscriptions and the dead-letter log.
+type Store interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	// List returns every webhook, oldest first.
+	List(ctx context.Context) ([]Webhook, error)
+	// ListOwned returns the webhooks of owner, oldest first.
+	ListOwned(ctx context.Context, owner string) ([]Webhook, error)
+	// Delete removes a webhook of owner, or returns ErrNotFound when owner has none with the id.
+	Delete(ctx context.Context, id string, owner string) error
+	AddDeadLetter(ctx context.Context, letter DeadLetter) error
+	// Claim records that the calling instance makes the delivery with id, and reports false
+	// when another instance already claimed it.
+	Claim(ctx context.Context, id string) (bool, error)
+}
+
+// MemoryStore keeps webhooks in memory, they are lost on restart.
+type MemoryStore struct {
+	mu          sync.Mutex
+	webhooks    []Webhook
+	deadLetters []DeadLetter
+	claims      map[string]bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{claims: map[string]bool{}}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, webhook *Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhooks = append(s.webhooks, *webhook)
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.webhooks), nil
+}
+
+func (s *MemoryStore) ListOwned(ctx context.Context, owner string) ([]Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var webhooks []Webhook
+	for _, webhook := range s.webhooks {
+		if webhook.Owner == owner {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := slices.IndexFunc(s.webhooks, func(webhook Webhook) bool { return webhook.ID == id && webhook.Owner == owner })
+	if index < 0 {
+		return ErrNotFound
+	}
+	s.webhooks = slices.Delete(s.webhooks, index, index+1)
+	return nil
+}
+
+func (s *MemoryStore) AddDeadLetter(ctx context.Context, letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadLetters = append(s.deadLetters, letter)
+	return nil
+}
+
+func (s *MemoryStore) Claim(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claims[id] {
+		return false, nil
+	}
+	s.claims[id] = true
+	return true, nil
+}
+
+// DeadLetters returns the failed deliveries recorded so far.
+func (s *MemoryStore) DeadLetters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.deadLetters)
+}
+
+// FirestoreStore keeps one document per webhook in Collection, keyed by webhook id, and the
+// dead-letter log in DeadLetterCollection. ClaimCollection holds one document per delivery,
+// keyed by delivery id, so every instance of the server can dispatch and each delivery is
+// still made once. Client is the Firestore client the server shares.
+type FirestoreStore struct {
+	Client               *firestore.Client
+	Collection           string
+	DeadLetterCollection string
+	ClaimCollection      string
+}
+
+func (s FirestoreStore) Create(ctx context.Context, webhook *Webhook) error {
+	_, err := s.Client.Collection(s.Collection).Doc(webhook.ID).Create(ctx, webhook)
+	return err
+}
+
+func (s FirestoreStore) List(ctx context.Context) ([]Webhook, error) {
+	return readWebhooks(s.Client.Collection(s.Collection).OrderBy("created_at", firestore.Asc).Documents(ctx))
+}
+
+func (s FirestoreStore) ListOwned(ctx context.Context, owner string) ([]Webhook, error) {
+	// Sorting here rather than in the query saves a composite index.
+	webhooks, err := readWebhooks(s.Client.Collection(s.Collection).Where("owner", "==", owner).Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(webhooks, func(a, b Webhook) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return webhooks, nil
+}
+
+func readWebhooks(documents *firestore.DocumentIterator) ([]Webhook, error) {
+	defer documents.Stop()
+
+	var webhooks []Webhook
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var webhook Webhook
+		if err := document.DataTo(&webhook); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (s FirestoreStore) Delete(ctx context.Context, id string, owner string) error {
+	if id == "" || strings.Contains(id, "/") {
+		return ErrNotFound
+	}
+
+	document := s.Client.Collection(s.Collection).Doc(id)
+	return s.Client.RunTransaction(ctx, func(ctx context.Context, transaction *firestore.Transaction) error {
+		snapshot, err := transaction.Get(document)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// Webhooks of other owners are reported as missing, so ids can't be probed.
+		if stored, _ := snapshot.DataAt("owner"); stored != owner {
+			return ErrNotFound
+		}
+		return transaction.Delete(document)
+	})
+}
+
+func (s FirestoreStore) AddDeadLetter(ctx context.Context, letter DeadLetter) error {
+	_, _, err := s.Client.Collection(s.DeadLetterCollection).Add(ctx, letter)
+	return err
+}
+
+func (s FirestoreStore) Claim(ctx context.Context, id string) (bool, error) {
+	now := time.Now().UTC()
+	claim := map[string]any{"claimed_at": now, "expires_at": now.Add(claimRetention)}
+	_, err := s.Client.Collection(s.ClaimCollection).Doc(id).Create(ctx, claim)
+	if status.Code(err) == codes.AlreadyExists {
+		return false, nil
+	}
+	return err == nil, err
+}
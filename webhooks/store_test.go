@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	for _, id := range []string{"first", "second"} {
+		if err := store.Create(ctx, &Webhook{ID: id, URL: "https://example.com/" + id, Owner: "owner"}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	store.Create(ctx, &Webhook{ID: "third", URL: "https://example.com/third", Owner: "other"})
+
+	if err := store.Delete(ctx, "third", "owner"); err != ErrNotFound {
+		t.Errorf("Delete() of the webhook of another owner error = %v, want %v", err, ErrNotFound)
+	}
+	if err := store.Delete(ctx, "first", "owner"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "first", "owner"); err != ErrNotFound {
+		t.Errorf("Delete() of a deleted webhook error = %v, want %v", err, ErrNotFound)
+	}
+
+	owned, err := store.ListOwned(ctx, "owner")
+	if err != nil {
+		t.Fatalf("ListOwned() error = %v", err)
+	}
+	if len(owned) != 1 || owned[0].ID != "second" {
+		t.Errorf("ListOwned() = %+v, want only the second webhook", owned)
+	}
+
+	webhooks, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(webhooks) != 2 || webhooks[0].ID != "second" || webhooks[1].ID != "third" {
+		t.Errorf("List() = %+v, want the second and third webhooks", webhooks)
+	}
+
+	if err := store.AddDeadLetter(ctx, DeadLetter{WebhookID: "second", Attempts: 5}); err != nil {
+		t.Fatalf("AddDeadLetter() error = %v", err)
+	}
+	if letters := store.DeadLetters(); len(letters) != 1 || letters[0].WebhookID != "second" {
+		t.Errorf("DeadLetters() = %+v, want the recorded letter", letters)
+	}
+
+	if claimed, err := store.Claim(ctx, "delivery"); err != nil || !claimed {
+		t.Errorf("Claim() = %v, %v, want the first claim to succeed", claimed, err)
+	}
+	if claimed, _ := store.Claim(ctx, "delivery"); claimed {
+		t.Error("Claim() of a claimed delivery = true, want false")
+	}
+}
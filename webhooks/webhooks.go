@@ -0,0 +1,95 @@
+// Package webhooks pushes rate events to URLs registered by downstream systems. Deliveries are
+// signed with a per-webhook secret, retried with exponential backoff, and recorded in a
+// dead-letter log once every attempt has failed.
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/kamaal111/forex-api/api"
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+type (
+	Threshold = api.Threshold
+	Webhook   = api.Webhook
+	Crossing  = api.Crossing
+	Payload   = api.Payload
+)
+
+const (
+	EventRatesPublished   = api.EventRatesPublished
+	EventThresholdCrossed = api.EventThresholdCrossed
+
+	DirectionUp   = api.DirectionUp
+	DirectionDown = api.DirectionDown
+
+	SignatureHeader = api.SignatureHeader
+	TimestampHeader = api.TimestampHeader
+	EventHeader     = api.EventHeader
+	DeliveryHeader  = api.DeliveryHeader
+)
+
+var (
+	ErrInvalidURL       = errors.New("url must be an absolute http or https URL")
+	ErrInvalidThreshold = errors.New("threshold needs a supported symbol other than the base and a positive value")
+)
+
+// normalize validates a webhook registration and fills in its defaults.
+func normalize(w *Webhook) error {
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidURL
+	}
+
+	w.Base = handlers.NormalizeBase(w.Base)
+	w.Symbols = handlers.MakeSymbolsArray(strings.Join(w.Symbols, ","), w.Base)
+
+	if w.Threshold != nil {
+		w.Threshold.Symbol = strings.ToUpper(strings.TrimSpace(w.Threshold.Symbol))
+		if !handlers.Registry.Contains(w.Threshold.Symbol) || w.Threshold.Symbol == w.Base || w.Threshold.Value <= 0 {
+			return ErrInvalidThreshold
+		}
+	}
+
+	if w.Secret == "" {
+		w.Secret = randomHex(32)
+	}
+	w.ID = randomHex(16)
+	return nil
+}
+
+// crossing reports whether the rate of the threshold symbol of w crossed the threshold
+// from previous to current. Touching the threshold counts as crossing it.
+func crossing(w *Webhook, previous *handlers.ExchangeRateRecord, current *handlers.ExchangeRateRecord) *Crossing {
+	symbol, value := w.Threshold.Symbol, w.Threshold.Value
+	before, ok := previous.Rates[symbol]
+	if !ok {
+		return nil
+	}
+	after, ok := current.Rates[symbol]
+	if !ok {
+		return nil
+	}
+
+	crossing := &Crossing{Symbol: symbol, Threshold: value, Previous: before, Current: after}
+	switch {
+	case before < value && after >= value:
+		crossing.Direction = DirectionUp
+	case before > value && after <= value:
+		crossing.Direction = DirectionDown
+	default:
+		return nil
+	}
+	return crossing
+}
+
+func randomHex(size int) string {
+	bytes := make([]byte, size)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
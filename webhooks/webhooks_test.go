@@ -0,0 +1,96 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/kamaal111/forex-api/handlers"
+)
+
+func TestWebhookNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		wantErr error
+	}{
+		{name: "new rates webhook", webhook: Webhook{URL: "https://example.com/hook", Base: "usd", Symbols: []string{"eur", "USD", "XYZ"}}},
+		{name: "threshold webhook", webhook: Webhook{URL: "http://localhost:9000/hook", Threshold: &Threshold{Symbol: "usd", Value: 1.1}}},
+		{name: "relative URL", webhook: Webhook{URL: "/hook"}, wantErr: ErrInvalidURL},
+		{name: "unsupported scheme", webhook: Webhook{URL: "ftp://example.com/hook"}, wantErr: ErrInvalidURL},
+		{name: "unknown threshold symbol", webhook: Webhook{URL: "https://example.com/hook", Threshold: &Threshold{Symbol: "XYZ", Value: 1}}, wantErr: ErrInvalidThreshold},
+		{name: "threshold on the base", webhook: Webhook{URL: "https://example.com/hook", Threshold: &Threshold{Symbol: "EUR", Value: 1}}, wantErr: ErrInvalidThreshold},
+		{name: "threshold without value", webhook: Webhook{URL: "https://example.com/hook", Threshold: &Threshold{Symbol: "USD"}}, wantErr: ErrInvalidThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := tt.webhook
+			err := normalize(&webhook)
+			if err != tt.wantErr {
+				t.Fatalf("normalize() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if webhook.ID == "" || len(webhook.Secret) != 64 {
+				t.Errorf("normalize() id = %q, secret = %q, want both generated", webhook.ID, webhook.Secret)
+			}
+		})
+	}
+}
+
+func TestWebhookNormalize_Defaults(t *testing.T) {
+	webhook := Webhook{URL: "https://example.com/hook", Base: "usd", Symbols: []string{"eur", "USD", "XYZ"}, Secret: "shh"}
+	if err := normalize(&webhook); err != nil {
+		t.Fatalf("normalize() error = %v", err)
+	}
+
+	if webhook.Base != "USD" {
+		t.Errorf("normalize() base = %q, want %q", webhook.Base, "USD")
+	}
+	if len(webhook.Symbols) != 1 || webhook.Symbols[0] != "EUR" {
+		t.Errorf("normalize() symbols = %v, want [EUR]", webhook.Symbols)
+	}
+	if webhook.Secret != "shh" {
+		t.Errorf("normalize() secret = %q, want the registered one", webhook.Secret)
+	}
+	if webhook.Event() != EventRatesPublished {
+		t.Errorf("Event() = %q, want %q", webhook.Event(), EventRatesPublished)
+	}
+}
+
+func TestWebhookCrossing(t *testing.T) {
+	webhook := Webhook{Threshold: &Threshold{Symbol: "USD", Value: 1.1}}
+
+	tests := []struct {
+		name          string
+		previous      float64
+		current       float64
+		wantDirection string
+	}{
+		{name: "crosses up", previous: 1.09, current: 1.11, wantDirection: DirectionUp},
+		{name: "reaches the threshold", previous: 1.09, current: 1.1, wantDirection: DirectionUp},
+		{name: "crosses down", previous: 1.12, current: 1.08, wantDirection: DirectionDown},
+		{name: "stays below", previous: 1.05, current: 1.09},
+		{name: "stays above", previous: 1.11, current: 1.15},
+		{name: "leaves the threshold upwards", previous: 1.1, current: 1.11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := &handlers.ExchangeRateRecord{Rates: map[string]float64{"USD": tt.previous}}
+			current := &handlers.ExchangeRateRecord{Rates: map[string]float64{"USD": tt.current}}
+
+			got := crossing(&webhook, previous, current)
+			if tt.wantDirection == "" {
+				if got != nil {
+					t.Errorf("crossing() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Direction != tt.wantDirection {
+				t.Errorf("crossing() = %+v, want direction %q", got, tt.wantDirection)
+			}
+		})
+	}
+}